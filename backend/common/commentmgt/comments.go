@@ -0,0 +1,342 @@
+package commentmgt
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/common/usermgt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrShowNotFound is returned when commenting a show which does not exist.
+	ErrShowNotFound = errors.New("the show cannot be found")
+
+	// ErrAuthorMuted is returned when a muted user posts or edits a comment.
+	ErrAuthorMuted = errors.New("the author of the comment has been muted")
+
+	// ErrCommentPermissionDenied is returned when a user edits a comment of another user.
+	ErrCommentPermissionDenied = errors.New("the comment belongs to another user")
+
+	// ErrEditWindowExpired is returned when a comment is edited after its edit window, or after
+	// it has been hidden or deleted by a moderator.
+	ErrEditWindowExpired = errors.New("the comment can no longer be edited")
+
+	// ErrAlreadyReported is returned when a user reports a comment twice.
+	ErrAlreadyReported = errors.New("the comment has already been reported by the user")
+
+	// ErrUserNotFound is returned when muting a user who does not exist.
+	ErrUserNotFound = errors.New("the user cannot be found")
+)
+
+// CommentRequestBody holds the request body for posting a comment.
+type CommentRequestBody struct {
+	// The episode of the show the comment is about, if any. Replies are always about the episode
+	// of their parent comment.
+	EpisodeID *uuid.UUID `json:"episodeId"`
+
+	// The comment this comment replies to, if any.
+	ParentID *uuid.UUID `json:"parentId"`
+
+	Body      string `json:"body" validate:"required,max=10000"`
+	IsSpoiler bool   `json:"isSpoiler"`
+}
+
+// UpdateCommentRequestBody holds the request body for editing a comment.
+type UpdateCommentRequestBody struct {
+	Body      string `json:"body" validate:"required,max=10000"`
+	IsSpoiler bool   `json:"isSpoiler"`
+}
+
+// ReportCommentRequestBody holds the request body for reporting a comment.
+type ReportCommentRequestBody struct {
+	Reason  string `json:"reason" validate:"required,oneof=spoiler spam abuse other"`
+	Details string `json:"details" validate:"max=2000"`
+}
+
+// ModerateCommentRequestBody holds the request body for moderating a comment.
+type ModerateCommentRequestBody struct {
+	Status string `json:"status" validate:"required,oneof=visible hidden deleted"`
+}
+
+// MuteUserRequestBody holds the request body for muting a user.
+type MuteUserRequestBody struct {
+	UserID uuid.UUID `json:"userId" validate:"required"`
+	Reason string    `json:"reason" validate:"max=2000"`
+
+	// The user is muted indefinitely when it is not set.
+	Until *time.Time `json:"until"`
+}
+
+// commentWords splits the text into lowercase words, folding full-width and compatibility
+// characters (NFKC) so that they can be compared with the banned words.
+func commentWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(norm.NFKC.String(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// FindBannedWords returns the banned words found in the text. A banned word only matches whole
+// words, and a banned phrase matches the same words in a row.
+func FindBannedWords(text string, bannedWords []string) []string {
+	words := commentWords(text)
+
+	return lo.Filter(bannedWords, func(bannedWord string, _ int) bool {
+		bannedPhrase := commentWords(bannedWord)
+		if len(bannedPhrase) == 0 {
+			return false
+		}
+
+		for i := 0; i+len(bannedPhrase) <= len(words); i++ {
+			if slices.Equal(words[i:i+len(bannedPhrase)], bannedPhrase) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// applyBody sets the body of the comment. Comments containing a banned word are flagged and held
+// for moderation; a held comment which no longer contains one is published again.
+func applyBody(comment *CommentModel, body string, isSpoiler bool, bannedWords []string) {
+	comment.Body = strings.TrimSpace(body)
+	comment.IsSpoiler = isSpoiler
+
+	switch {
+	case len(FindBannedWords(comment.Body, bannedWords)) > 0:
+		comment.IsFlagged = true
+		comment.Status = HiddenCommentStatus
+		comment.ModeratedAt = nil
+		comment.ModeratedBy = nil
+	case comment.IsFlagged && comment.ModeratedAt == nil:
+		comment.IsFlagged = false
+		comment.Status = VisibleCommentStatus
+	}
+}
+
+// IsUserMuted returns whether the user is currently muted.
+func IsUserMuted(tx *gorm.DB, userID uuid.UUID, now time.Time) (bool, error) {
+	var count int64
+	if result := tx.Model(&MutedUserModel{}).
+		Where("user_id = ? AND (until IS NULL OR until > ?)", userID, now).
+		Count(&count); result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// CreateComment posts a comment of the author on the show. A reply is posted on the episode of its
+// parent comment, which must not have been deleted.
+func CreateComment(tx *gorm.DB, showID uuid.UUID, authorID uuid.UUID, body *CommentRequestBody,
+	config *core.CommentConfig) (*CommentModel, error) {
+	now := time.Now()
+
+	muted, err := IsUserMuted(tx, authorID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if muted {
+		return nil, ErrAuthorMuted
+	}
+
+	var showCount int64
+	if result := tx.Model(&showmgt.ShowModel{}).Where("id = ?", showID).Count(&showCount); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if showCount == 0 {
+		return nil, ErrShowNotFound
+	}
+
+	comment := CommentModel{
+		ShowID:    showID,
+		EpisodeID: body.EpisodeID,
+		AuthorID:  authorID,
+		Status:    VisibleCommentStatus,
+		PostedAt:  now,
+	}
+
+	if body.ParentID != nil {
+		var parent CommentModel
+		if result := tx.
+			Where("id = ? AND show_id = ? AND status <> ?", *body.ParentID, showID, DeletedCommentStatus).
+			First(&parent); result.Error != nil {
+			return nil, result.Error
+		}
+
+		comment.EpisodeID = parent.EpisodeID
+		comment.ParentID = &parent.ID
+		comment.RootID = lo.Ternary(parent.RootID != nil, parent.RootID, &parent.ID)
+	} else if comment.EpisodeID != nil {
+		var episodeCount int64
+		if result := tx.Model(&showmgt.EpisodeModel{}).
+			Where("id = ? AND show_id = ?", *comment.EpisodeID, showID).
+			Count(&episodeCount); result.Error != nil {
+			return nil, result.Error
+		}
+
+		if episodeCount == 0 {
+			return nil, showmgt.ErrEpisodeNotFound
+		}
+	}
+
+	applyBody(&comment, body.Body, body.IsSpoiler, config.BannedWords)
+
+	if result := tx.Omit(clause.Associations).Create(&comment); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &comment, nil
+}
+
+// UpdateComment edits a comment of the author, within the edit window. Comments hidden or deleted
+// by a moderator cannot be edited anymore.
+func UpdateComment(tx *gorm.DB, comment *CommentModel, authorID uuid.UUID, body *UpdateCommentRequestBody,
+	config *core.CommentConfig) error {
+	now := time.Now()
+
+	if comment.AuthorID != authorID {
+		return ErrCommentPermissionDenied
+	}
+
+	if now.Sub(comment.PostedAt) > config.EditWindow ||
+		comment.Status == DeletedCommentStatus ||
+		(comment.Status == HiddenCommentStatus && comment.ModeratedAt != nil) {
+		return ErrEditWindowExpired
+	}
+
+	muted, err := IsUserMuted(tx, authorID, now)
+	if err != nil {
+		return err
+	}
+
+	if muted {
+		return ErrAuthorMuted
+	}
+
+	applyBody(comment, body.Body, body.IsSpoiler, config.BannedWords)
+	comment.EditedAt = &now
+
+	return tx.Omit(clause.Associations).Save(comment).Error
+}
+
+// ReportComment records the report of a visible comment by a user, and puts the comment back in
+// the moderation queue.
+func ReportComment(tx *gorm.DB, commentID uuid.UUID, reporterID uuid.UUID, body *ReportCommentRequestBody) error {
+	var comment CommentModel
+	if result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("id = ? AND status = ?", commentID, VisibleCommentStatus).
+		First(&comment); result.Error != nil {
+		return result.Error
+	}
+
+	var reportCount int64
+	if result := tx.Model(&CommentReportModel{}).
+		Where("comment_id = ? AND reporter_id = ?", commentID, reporterID).
+		Count(&reportCount); result.Error != nil {
+		return result.Error
+	}
+
+	if reportCount > 0 {
+		return ErrAlreadyReported
+	}
+
+	report := CommentReportModel{
+		CommentID:  commentID,
+		ReporterID: reporterID,
+		Reason:     body.Reason,
+		Details:    strings.TrimSpace(body.Details),
+	}
+	if result := tx.Omit(clause.Associations).Create(&report); result.Error != nil {
+		return result.Error
+	}
+
+	return tx.Model(&comment).Updates(map[string]any{
+		"report_count": gorm.Expr("report_count + 1"),
+		"moderated_at": nil,
+		"moderated_by": nil,
+	}).Error
+}
+
+// CanModerate checks whether the user can moderate the comments and mute their authors.
+func CanModerate(user core.PrincipalUser) bool {
+	return core.HasAnyRole(user, core.AdminRole, core.ModeratorRole)
+}
+
+// ModerationQueue returns the query of the comments waiting for moderation: the ones which have
+// been flagged or reported since they have last been moderated.
+func ModerationQueue(db *gorm.DB) *gorm.DB {
+	return db.Model(&CommentModel{}).
+		Where("(is_flagged OR report_count > 0) AND moderated_at IS NULL AND status <> ?", DeletedCommentStatus)
+}
+
+// ModerateComment sets the status of the comment, which removes it from the moderation queue.
+func ModerateComment(tx *gorm.DB, comment *CommentModel, status string, moderator string) error {
+	now := time.Now()
+	comment.Status = status
+	comment.ModeratedAt = &now
+	comment.ModeratedBy = &moderator
+
+	return tx.Model(comment).Select("status", "moderated_at", "moderated_by").Updates(comment).Error
+}
+
+// MuteUser mutes the user, or replaces the reason and end of their current mute.
+func MuteUser(tx *gorm.DB, body *MuteUserRequestBody, moderator string) (*MutedUserModel, error) {
+	var userCount int64
+	if result := tx.Model(&usermgt.UserModel{}).Where("id = ?", body.UserID).Count(&userCount); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if userCount == 0 {
+		return nil, ErrUserNotFound
+	}
+
+	mutedUser := MutedUserModel{
+		UserID:             body.UserID,
+		HasCreatedByColumn: core.HasCreatedByColumn{CreatedBy: moderator},
+		Reason:             strings.TrimSpace(body.Reason),
+		Until:              body.Until,
+	}
+
+	if result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "created_by", "reason", "until"}),
+	}).Create(&mutedUser); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &mutedUser, nil
+}
+
+// LoadThreads returns the replies of the given root comments, ordered by posting time.
+func LoadThreads(db *gorm.DB, roots []CommentModel) ([]CommentModel, error) {
+	replies := []CommentModel{}
+	if len(roots) == 0 {
+		return replies, nil
+	}
+
+	rootIDs := lo.Map(roots, func(root CommentModel, _ int) uuid.UUID {
+		return root.ID
+	})
+
+	if result := db.Preload("Author").
+		Where("root_id IN ?", rootIDs).
+		Order("posted_at").
+		Find(&replies); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return replies, nil
+}
@@ -0,0 +1,166 @@
+package commentmgt
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type CommentAuthorDTO struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+}
+
+// CommentDTO is a comment with its replies. The author and body of the comments which are not
+// visible are left out; they are only returned to keep the structure of their thread.
+type CommentDTO struct {
+	ID        uuid.UUID         `json:"id"`
+	ShowID    uuid.UUID         `json:"showId"`
+	EpisodeID *uuid.UUID        `json:"episodeId"`
+	ParentID  *uuid.UUID        `json:"parentId"`
+	Author    *CommentAuthorDTO `json:"author"`
+	Body      *string           `json:"body"`
+	IsSpoiler bool              `json:"isSpoiler"`
+	Status    string            `json:"status"`
+	PostedAt  time.Time         `json:"postedAt"`
+	EditedAt  *time.Time        `json:"editedAt"`
+	Replies   []*CommentDTO     `json:"replies,omitempty"`
+}
+
+// ToCommentDTO converts a CommentModel to a CommentDTO, without its replies.
+func ToCommentDTO(commentModel *CommentModel) *CommentDTO {
+	if commentModel == nil {
+		return nil
+	}
+
+	commentDTO := &CommentDTO{
+		ID:        commentModel.ID,
+		ShowID:    commentModel.ShowID,
+		EpisodeID: commentModel.EpisodeID,
+		ParentID:  commentModel.ParentID,
+		IsSpoiler: commentModel.IsSpoiler,
+		Status:    commentModel.Status,
+		PostedAt:  commentModel.PostedAt,
+		EditedAt:  commentModel.EditedAt,
+	}
+
+	if commentModel.Status == VisibleCommentStatus {
+		commentDTO.Author = &CommentAuthorDTO{ID: commentModel.AuthorID, Username: commentModel.Author.Username}
+		commentDTO.Body = &commentModel.Body
+	}
+
+	return commentDTO
+}
+
+// ToCommentThreadDTOs nests the replies under the given root comments. Comments which are not
+// visible are dropped, unless they have visible replies.
+func ToCommentThreadDTOs(roots []CommentModel, replies []CommentModel) []*CommentDTO {
+	repliesByParent := lo.GroupBy(replies, func(reply CommentModel) uuid.UUID {
+		return lo.FromPtr(reply.ParentID)
+	})
+
+	var toThread func(commentModel *CommentModel) *CommentDTO
+	toThread = func(commentModel *CommentModel) *CommentDTO {
+		commentDTO := ToCommentDTO(commentModel)
+
+		for i := range repliesByParent[commentModel.ID] {
+			if replyDTO := toThread(&repliesByParent[commentModel.ID][i]); replyDTO != nil {
+				commentDTO.Replies = append(commentDTO.Replies, replyDTO)
+			}
+		}
+
+		if commentDTO.Status != VisibleCommentStatus && len(commentDTO.Replies) == 0 {
+			return nil
+		}
+
+		return commentDTO
+	}
+
+	return lo.FilterMap(roots, func(root CommentModel, _ int) (*CommentDTO, bool) {
+		commentDTO := toThread(&root)
+
+		return commentDTO, commentDTO != nil
+	})
+}
+
+type CommentReportDTO struct {
+	ReporterID uuid.UUID `json:"reporterId"`
+	Reporter   string    `json:"reporter"`
+	Reason     string    `json:"reason"`
+	Details    string    `json:"details"`
+}
+
+// ModerationCommentDTO is a comment as seen by moderators, with its content whatever its status.
+type ModerationCommentDTO struct {
+	ID          uuid.UUID           `json:"id"`
+	ShowID      uuid.UUID           `json:"showId"`
+	EpisodeID   *uuid.UUID          `json:"episodeId"`
+	ParentID    *uuid.UUID          `json:"parentId"`
+	Author      *CommentAuthorDTO   `json:"author"`
+	Body        string              `json:"body"`
+	IsSpoiler   bool                `json:"isSpoiler"`
+	Status      string              `json:"status"`
+	IsFlagged   bool                `json:"isFlagged"`
+	ReportCount int                 `json:"reportCount"`
+	Reports     []*CommentReportDTO `json:"reports,omitempty"`
+	PostedAt    time.Time           `json:"postedAt"`
+	EditedAt    *time.Time          `json:"editedAt"`
+	ModeratedAt *time.Time          `json:"moderatedAt"`
+	ModeratedBy *string             `json:"moderatedBy"`
+}
+
+// ToModerationCommentDTO converts a CommentModel to a ModerationCommentDTO.
+func ToModerationCommentDTO(commentModel *CommentModel) *ModerationCommentDTO {
+	if commentModel == nil {
+		return nil
+	}
+
+	return &ModerationCommentDTO{
+		ID:        commentModel.ID,
+		ShowID:    commentModel.ShowID,
+		EpisodeID: commentModel.EpisodeID,
+		ParentID:  commentModel.ParentID,
+		Author:    &CommentAuthorDTO{ID: commentModel.AuthorID, Username: commentModel.Author.Username},
+		Body:      commentModel.Body,
+		IsSpoiler: commentModel.IsSpoiler,
+		Status:    commentModel.Status,
+		IsFlagged: commentModel.IsFlagged,
+		Reports: lo.Map(commentModel.Reports, func(report CommentReportModel, _ int) *CommentReportDTO {
+			return &CommentReportDTO{
+				ReporterID: report.ReporterID,
+				Reporter:   report.Reporter.Username,
+				Reason:     report.Reason,
+				Details:    report.Details,
+			}
+		}),
+		ReportCount: commentModel.ReportCount,
+		PostedAt:    commentModel.PostedAt,
+		EditedAt:    commentModel.EditedAt,
+		ModeratedAt: commentModel.ModeratedAt,
+		ModeratedBy: commentModel.ModeratedBy,
+	}
+}
+
+type MutedUserDTO struct {
+	UserID   uuid.UUID  `json:"userId"`
+	Username string     `json:"username"`
+	Reason   string     `json:"reason"`
+	Until    *time.Time `json:"until"`
+	MutedBy  string     `json:"mutedBy"`
+}
+
+// ToMutedUserDTO converts a MutedUserModel to a MutedUserDTO.
+func ToMutedUserDTO(mutedUserModel *MutedUserModel) *MutedUserDTO {
+	if mutedUserModel == nil {
+		return nil
+	}
+
+	return &MutedUserDTO{
+		UserID:   mutedUserModel.UserID,
+		Username: mutedUserModel.User.Username,
+		Reason:   mutedUserModel.Reason,
+		Until:    mutedUserModel.Until,
+		MutedBy:  mutedUserModel.CreatedBy,
+	}
+}
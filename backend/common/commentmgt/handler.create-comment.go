@@ -0,0 +1,125 @@
+package commentmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type createCommentHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	config              core.AppConfig
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type CreateCommentHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Config              core.AppConfig
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*createCommentHandler)(nil)
+
+func NewCreateCommentHandler(p CreateCommentHandlerParams) *createCommentHandler {
+	return &createCommentHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		config:              p.Config,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *createCommentHandler) Pattern() string {
+	return "POST /api/v1/shows/{id}/comments"
+}
+
+func (h *createCommentHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP posts a comment, or a reply to a comment, on a show or one of its episodes. Comments
+// containing a banned word are held for moderation: they are saved hidden and 202 is returned.
+func (h *createCommentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	var requestBody CommentRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var commentModel *CommentModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		commentModel, err = CreateComment(tx, showID, core.MustGetAuthUserFromRequest(r).GetID(), &requestBody,
+			h.config.GetCommentConfig())
+
+		return err
+	})
+
+	switch {
+	case err == nil && commentModel.Status == VisibleCommentStatus:
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, responseBuilder.Data(ToModerationCommentDTO(commentModel)).Build())
+	case err == nil:
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgCommentPendingModeration).
+			Data(ToModerationCommentDTO(commentModel)).
+			Build())
+	case errors.Is(err, ErrAuthorMuted):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentAuthorMuted).Build())
+	case errors.Is(err, ErrShowNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+	case errors.Is(err, showmgt.ErrEpisodeNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgEpisodeNotFound).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when creating a comment", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,106 @@
+package commentmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getCommentsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetCommentsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getCommentsHandler)(nil)
+
+func NewGetCommentsHandler(p GetCommentsHandlerParams) *getCommentsHandler {
+	return &getCommentsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getCommentsHandler) Pattern() string {
+	return "GET /api/v1/shows/{id}/comments"
+}
+
+func (h *getCommentsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a page of the comment threads of a show, the most recent first, with all
+// their replies. The comments of an episode are returned instead with the "episodeId" query
+// parameter.
+func (h *getCommentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+	threads := func() *gorm.DB {
+		query := db.Model(&CommentModel{}).
+			Where("show_id = ? AND parent_id IS NULL", showID).
+			Where("status = ? OR id IN (?)", VisibleCommentStatus,
+				db.Model(&CommentModel{}).Select("root_id").Where("root_id IS NOT NULL AND status = ?", VisibleCommentStatus))
+
+		if episodeID, err := uuid.Parse(r.URL.Query().Get("episodeId")); err == nil {
+			return query.Where("episode_id = ?", episodeID)
+		}
+
+		return query.Where("episode_id IS NULL")
+	}
+
+	var totalRows int64
+	if result := threads().Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var roots []CommentModel
+	if result := threads().
+		Preload("Author").
+		Order("posted_at DESC").
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Find(&roots); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting comments", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	replies, err := LoadThreads(db, roots)
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting comment replies", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(ToCommentThreadDTOs(roots, replies)).Pagination(totalRows).Build())
+}
@@ -0,0 +1,88 @@
+package commentmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getModerationQueueHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetModerationQueueHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getModerationQueueHandler)(nil)
+
+func NewGetModerationQueueHandler(p GetModerationQueueHandlerParams) *getModerationQueueHandler {
+	return &getModerationQueueHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getModerationQueueHandler) Pattern() string {
+	return "GET /api/v1/moderation/comments"
+}
+
+func (h *getModerationQueueHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a page of the comments waiting for moderation (see ModerationQueue), the most
+// reported first, with their reports.
+func (h *getModerationQueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	if !CanModerate(core.MustGetAuthUserFromRequest(r)) {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgPermissionDenied).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+
+	var totalRows int64
+	if result := ModerationQueue(db).Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var commentModels []CommentModel
+	if result := ModerationQueue(db).
+		Preload("Author").
+		Preload("Reports.Reporter").
+		Order("report_count DESC, posted_at").
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Find(&commentModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting the moderation queue", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	commentDTOs := lo.Map(commentModels, func(commentModel CommentModel, _ int) *ModerationCommentDTO {
+		return ToModerationCommentDTO(&commentModel)
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(commentDTOs).Pagination(totalRows).Build())
+}
@@ -0,0 +1,91 @@
+package commentmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getMutedUsersHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetMutedUsersHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getMutedUsersHandler)(nil)
+
+func NewGetMutedUsersHandler(p GetMutedUsersHandlerParams) *getMutedUsersHandler {
+	return &getMutedUsersHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getMutedUsersHandler) Pattern() string {
+	return "GET /api/v1/moderation/muted-users"
+}
+
+func (h *getMutedUsersHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a page of the users who are currently muted.
+func (h *getMutedUsersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	if !CanModerate(core.MustGetAuthUserFromRequest(r)) {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgPermissionDenied).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+	now := time.Now()
+	mutedUsers := func() *gorm.DB {
+		return db.Model(&MutedUserModel{}).Where("until IS NULL OR until > ?", now)
+	}
+
+	var totalRows int64
+	if result := mutedUsers().Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var mutedUserModels []MutedUserModel
+	if result := mutedUsers().
+		Preload("User").
+		Order("updated_at DESC").
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Find(&mutedUserModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting muted users", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	mutedUserDTOs := lo.Map(mutedUserModels, func(mutedUserModel MutedUserModel, _ int) *MutedUserDTO {
+		return ToMutedUserDTO(&mutedUserModel)
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(mutedUserDTOs).Pagination(totalRows).Build())
+}
@@ -0,0 +1,112 @@
+package commentmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type moderateCommentHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type ModerateCommentHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*moderateCommentHandler)(nil)
+
+func NewModerateCommentHandler(p ModerateCommentHandlerParams) *moderateCommentHandler {
+	return &moderateCommentHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *moderateCommentHandler) Pattern() string {
+	return "PUT /api/v1/moderation/comments/{id}"
+}
+
+func (h *moderateCommentHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP hides, restores or deletes a comment, and removes it from the moderation queue.
+func (h *moderateCommentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	if !CanModerate(core.MustGetAuthUserFromRequest(r)) {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgPermissionDenied).Build())
+
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentNotFound).Build())
+
+		return
+	}
+
+	var requestBody ModerateCommentRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var commentModel CommentModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Preload("Author").First(&commentModel, "id = ?", id); result.Error != nil {
+			return result.Error
+		}
+
+		return ModerateComment(tx, &commentModel, requestBody.Status, core.MustGetAuthUserFromRequest(r).GetUsername())
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(ToModerationCommentDTO(&commentModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when moderating a comment", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
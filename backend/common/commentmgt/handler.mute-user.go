@@ -0,0 +1,105 @@
+package commentmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type muteUserHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type MuteUserHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*muteUserHandler)(nil)
+
+func NewMuteUserHandler(p MuteUserHandlerParams) *muteUserHandler {
+	return &muteUserHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *muteUserHandler) Pattern() string {
+	return "POST /api/v1/moderation/muted-users"
+}
+
+func (h *muteUserHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP mutes a user (see MuteUser). Their existing comments are left as they are.
+func (h *muteUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	if !CanModerate(core.MustGetAuthUserFromRequest(r)) {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgPermissionDenied).Build())
+
+		return
+	}
+
+	var requestBody MuteUserRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var mutedUserModel *MutedUserModel
+
+	err := h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		mutedUserModel, err = MuteUser(tx, &requestBody, core.MustGetAuthUserFromRequest(r).GetUsername())
+		if err != nil {
+			return err
+		}
+
+		return tx.Preload("User").First(mutedUserModel, "user_id = ?", requestBody.UserID).Error
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, responseBuilder.Data(ToMutedUserDTO(mutedUserModel)).Build())
+	case errors.Is(err, ErrUserNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgUserNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when muting a user", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,102 @@
+package commentmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type reportCommentHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type ReportCommentHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*reportCommentHandler)(nil)
+
+func NewReportCommentHandler(p ReportCommentHandlerParams) *reportCommentHandler {
+	return &reportCommentHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *reportCommentHandler) Pattern() string {
+	return "POST /api/v1/comments/{id}/reports"
+}
+
+func (h *reportCommentHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP reports a visible comment to the moderators.
+func (h *reportCommentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentNotFound).Build())
+
+		return
+	}
+
+	var requestBody ReportCommentRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		return ReportComment(tx, id, core.MustGetAuthUserFromRequest(r).GetID(), &requestBody)
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentNotFound).Build())
+	case errors.Is(err, ErrAlreadyReported):
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentAlreadyReported).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when reporting a comment", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
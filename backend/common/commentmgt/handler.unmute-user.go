@@ -0,0 +1,80 @@
+package commentmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type unmuteUserHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type UnmuteUserHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*unmuteUserHandler)(nil)
+
+func NewUnmuteUserHandler(p UnmuteUserHandlerParams) *unmuteUserHandler {
+	return &unmuteUserHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *unmuteUserHandler) Pattern() string {
+	return "DELETE /api/v1/moderation/muted-users/{userId}"
+}
+
+func (h *unmuteUserHandler) IsPrivateRoute() bool {
+	return true
+}
+
+func (h *unmuteUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	if !CanModerate(core.MustGetAuthUserFromRequest(r)) {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgPermissionDenied).Build())
+
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("userId"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgMutedUserNotFound).Build())
+
+		return
+	}
+
+	result := h.db.WithContext(reqCtx).Delete(&MutedUserModel{}, "user_id = ?", userID)
+	if result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when unmuting a user", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgMutedUserNotFound).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+}
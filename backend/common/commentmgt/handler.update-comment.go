@@ -0,0 +1,127 @@
+package commentmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type updateCommentHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	config              core.AppConfig
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type UpdateCommentHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Config              core.AppConfig
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*updateCommentHandler)(nil)
+
+func NewUpdateCommentHandler(p UpdateCommentHandlerParams) *updateCommentHandler {
+	return &updateCommentHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		config:              p.Config,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *updateCommentHandler) Pattern() string {
+	return "PUT /api/v1/comments/{id}"
+}
+
+func (h *updateCommentHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP edits a comment of the user within its edit window (see UpdateComment). As for new
+// comments, 202 is returned when the edited comment is held for moderation.
+func (h *updateCommentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentNotFound).Build())
+
+		return
+	}
+
+	var requestBody UpdateCommentRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var commentModel CommentModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			First(&commentModel, "id = ?", id); result.Error != nil {
+			return result.Error
+		}
+
+		return UpdateComment(tx, &commentModel, core.MustGetAuthUserFromRequest(r).GetID(), &requestBody,
+			h.config.GetCommentConfig())
+	})
+
+	switch {
+	case err == nil && commentModel.Status == VisibleCommentStatus:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(ToModerationCommentDTO(&commentModel)).Build())
+	case err == nil:
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgCommentPendingModeration).
+			Data(ToModerationCommentDTO(&commentModel)).
+			Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentNotFound).Build())
+	case errors.Is(err, ErrCommentPermissionDenied):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentPermissionDenied).Build())
+	case errors.Is(err, ErrAuthorMuted):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentAuthorMuted).Build())
+	case errors.Is(err, ErrEditWindowExpired):
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgCommentEditWindowExpired).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when updating a comment", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,95 @@
+package commentmgt
+
+import (
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/common/usermgt"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a comment.
+const (
+	// VisibleCommentStatus comments are displayed to every user.
+	VisibleCommentStatus = "visible"
+
+	// HiddenCommentStatus comments are held for moderation or have been hidden by a moderator.
+	// Their content is not displayed, but their replies still are.
+	HiddenCommentStatus = "hidden"
+
+	// DeletedCommentStatus comments have been deleted by a moderator. They are kept so that the
+	// threads they belong to keep their structure.
+	DeletedCommentStatus = "deleted"
+)
+
+// CommentModel is a comment posted by a user on a show, or on one of its episodes when EpisodeID
+// is set. Replies reference their parent comment and the root comment of their thread, so that a
+// whole thread can be loaded at once.
+type CommentModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+
+	ShowID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	EpisodeID *uuid.UUID `gorm:"type:uuid;index"`
+	ParentID  *uuid.UUID `gorm:"type:uuid;index"`
+	RootID    *uuid.UUID `gorm:"type:uuid;index"`
+	AuthorID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Body      string     `gorm:"type:text;not null"`
+	IsSpoiler bool       `gorm:"type:boolean;not null"`
+	Status    string     `gorm:"type:string;size:16;not null;index"`
+
+	// Whether the comment has been flagged automatically because it contains a banned word.
+	IsFlagged   bool `gorm:"type:boolean;not null"`
+	ReportCount int  `gorm:"type:integer;not null"`
+
+	// The full timestamps of the comment, used for the edit window and to order the threads.
+	PostedAt time.Time  `gorm:"type:timestamptz;not null;index"`
+	EditedAt *time.Time `gorm:"type:timestamptz"`
+
+	// When and by whom the comment has last been moderated. It is reset when the comment is
+	// flagged or reported again, which puts it back in the moderation queue.
+	ModeratedAt *time.Time            `gorm:"type:timestamptz"`
+	ModeratedBy *string               `gorm:"type:string;size:256"`
+	Author      usermgt.UserModel     `gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE"`
+	Show        showmgt.ShowModel     `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+	Episode     *showmgt.EpisodeModel `gorm:"foreignKey:EpisodeID;constraint:OnDelete:CASCADE"`
+	Reports     []CommentReportModel  `gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE"`
+}
+
+// CommentReportModel is the report of a comment by a user. A user can report a comment once.
+type CommentReportModel struct {
+	CommentID  uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ReporterID uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	core.HasCreatedAtColumn
+
+	Reason   string            `gorm:"type:string;size:16;not null"`
+	Details  string            `gorm:"type:text;not null"`
+	Reporter usermgt.UserModel `gorm:"foreignKey:ReporterID;constraint:OnDelete:CASCADE"`
+}
+
+// MutedUserModel is a user who has been muted by a moderator and cannot post or edit comments,
+// until the given time or indefinitely.
+type MutedUserModel struct {
+	UserID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+	core.HasCreatedByColumn
+
+	Reason string            `gorm:"type:text;not null"`
+	Until  *time.Time        `gorm:"type:timestamptz"`
+	User   usermgt.UserModel `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (CommentModel) TableName() string {
+	return "public.comments"
+}
+
+func (CommentReportModel) TableName() string {
+	return "public.comment_reports"
+}
+
+func (MutedUserModel) TableName() string {
+	return "public.muted_users"
+}
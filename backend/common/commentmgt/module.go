@@ -0,0 +1,25 @@
+package commentmgt
+
+import (
+	"wano-island/common/core"
+
+	"go.uber.org/fx"
+)
+
+// NewCommentMgtModule returns a new Fx module for managing the comments on shows and their moderation.
+func NewCommentMgtModule() fx.Option {
+	return fx.Module(
+		"Comment management module",
+		fx.Provide(
+			core.AsRoute(NewGetCommentsHandler),
+			core.AsRoute(NewCreateCommentHandler),
+			core.AsRoute(NewUpdateCommentHandler),
+			core.AsRoute(NewReportCommentHandler),
+			core.AsRoute(NewGetModerationQueueHandler),
+			core.AsRoute(NewModerateCommentHandler),
+			core.AsRoute(NewGetMutedUsersHandler),
+			core.AsRoute(NewMuteUserHandler),
+			core.AsRoute(NewUnmuteUserHandler),
+		),
+	)
+}
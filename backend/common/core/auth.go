@@ -7,11 +7,21 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"golang.org/x/text/language"
 )
 
 type authUserCtx int
 
+// Roles of the users, carried by their access tokens.
+const (
+	// AdminRole users can use every feature, including the admin reports.
+	AdminRole = "admin"
+
+	// ModeratorRole users can moderate the comments and mute their authors.
+	ModeratorRole = "moderator"
+)
+
 type PrincipalUser interface {
 	GetID() uuid.UUID
 	GetUsername() string
@@ -114,9 +124,14 @@ func (u AuthenticatedUser) GetLocale() string {
 }
 
 func (u AuthenticatedUser) GetRoles() []string {
-	return []string{}
+	return u.Roles
 }
 
 func (u AuthenticatedUser) GetPermissions() []string {
-	return []string{}
+	return u.Permissions
+}
+
+// HasAnyRole checks whether the user has at least one of the given roles.
+func HasAnyRole(user PrincipalUser, roles ...string) bool {
+	return lo.Some(user.GetRoles(), roles)
 }
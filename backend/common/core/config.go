@@ -58,6 +58,18 @@ type AppConfig interface {
 
 	// GetSecretKey retrieves the secret key from "secret.key" file.
 	GetSecretKey() []byte
+
+	// GetVideoConfig retrieves the configuration of the video links attached to shows and episodes.
+	// It returns a pointer to a VideoConfig struct containing the video configuration details.
+	GetVideoConfig() *VideoConfig
+
+	// GetCommentConfig retrieves the configuration of the comments posted on shows and episodes.
+	// It returns a pointer to a CommentConfig struct containing the comment configuration details.
+	GetCommentConfig() *CommentConfig
+
+	// GetSearchConfig retrieves the configuration of the catalog search.
+	// It returns a pointer to a SearchConfig struct containing the search configuration details.
+	GetSearchConfig() *SearchConfig
 }
 
 // DatabaseConfig is a struct that holds the database configuration details.
@@ -117,12 +129,65 @@ type CorsConfig struct {
 	MaxAge int
 }
 
+// VideoConfig holds the configuration settings for the video links attached to shows and episodes.
+type VideoConfig struct {
+	// AllowedHosts: Specifies the hosts from which self-hosted videos can be linked,
+	// sourced from the environment variable "APP_VIDEO_ALLOWED_HOSTS".
+	// YouTube and Vimeo videos are always allowed.
+	//
+	// Default value: []
+	AllowedHosts []string
+}
+
+// CommentConfig holds the configuration settings for the comments posted on shows and episodes.
+type CommentConfig struct {
+	// BannedWords: Specifies the words which flag a comment for moderation,
+	// sourced from the environment variable "APP_COMMENT_BANNED_WORDS".
+	// Words are matched case-insensitively against the whole words of the comments.
+	//
+	// Default value: []
+	BannedWords []string
+
+	// EditWindow: Specifies how long after posting a comment its author can edit it,
+	// sourced from the environment variable "APP_COMMENT_EDIT_WINDOW".
+	//
+	// Default value: 15m
+	EditWindow time.Duration
+}
+
+// Backends of the catalog search.
+const (
+	// PostgresSearchBackend searches the catalog with the trigram indexes of PostgreSQL.
+	PostgresSearchBackend = "postgres"
+
+	// BleveSearchBackend searches the catalog with an embedded Bleve index, which supports CJK titles.
+	BleveSearchBackend = "bleve"
+)
+
+// SearchConfig holds the configuration settings for the catalog search.
+type SearchConfig struct {
+	// Backend: Specifies the search backend, either "postgres" or "bleve",
+	// sourced from the environment variable "APP_SEARCH_BACKEND".
+	//
+	// Default value: postgres
+	Backend string
+
+	// IndexPath: Specifies the directory of the Bleve index,
+	// sourced from the environment variable "APP_SEARCH_INDEX_PATH".
+	//
+	// Default value: data/search.bleve
+	IndexPath string
+}
+
 // appConfig is a struct that holds the application's configuration.
 type appConfig struct {
 	appMode        string
 	databaseConfig *DatabaseConfig
 	jwtConfig      *JWTConfig
 	corsConfig     *CorsConfig
+	videoConfig    *VideoConfig
+	commentConfig  *CommentConfig
+	searchConfig   *SearchConfig
 	secretKey      []byte
 }
 
@@ -194,6 +259,18 @@ func (appCfg *appConfig) GetSecretKey() []byte {
 	return appCfg.secretKey
 }
 
+func (appCfg *appConfig) GetVideoConfig() *VideoConfig {
+	return appCfg.videoConfig
+}
+
+func (appCfg *appConfig) GetCommentConfig() *CommentConfig {
+	return appCfg.commentConfig
+}
+
+func (appCfg *appConfig) GetSearchConfig() *SearchConfig {
+	return appCfg.searchConfig
+}
+
 // initAppMode retrieves the application mode from the provided viper configuration.
 // If the mode is not explicitly set in the configuration, it defaults to development mode.
 func initAppMode(v *viper.Viper) string {
@@ -314,6 +391,61 @@ func initCorsConfig(v *viper.Viper) *CorsConfig {
 	}
 }
 
+// initVideoConfig retrieves the video configuration details from the provided viper configuration.
+// Hosts are compared case-insensitively, so they are stored in lowercase.
+func initVideoConfig(v *viper.Viper) *VideoConfig {
+	v.SetDefault("video_allowed_hosts", "")
+
+	allowedHosts := make([]string, 0)
+
+	for _, host := range v.GetStringSlice("video_allowed_hosts") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			allowedHosts = append(allowedHosts, host)
+		}
+	}
+
+	return &VideoConfig{
+		AllowedHosts: allowedHosts,
+	}
+}
+
+// initCommentConfig retrieves the comment configuration details from the provided viper configuration.
+// Banned words are compared case-insensitively, so they are stored in lowercase.
+func initCommentConfig(v *viper.Viper) *CommentConfig {
+	v.SetDefault("comment_banned_words", "")
+	v.SetDefault("comment_edit_window", "15m")
+
+	bannedWords := make([]string, 0)
+
+	for _, word := range v.GetStringSlice("comment_banned_words") {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			bannedWords = append(bannedWords, word)
+		}
+	}
+
+	return &CommentConfig{
+		BannedWords: bannedWords,
+		EditWindow:  v.GetDuration("comment_edit_window"),
+	}
+}
+
+// initSearchConfig retrieves the search configuration details from the provided viper configuration.
+// Unknown backends fall back to PostgreSQL.
+func initSearchConfig(v *viper.Viper) *SearchConfig {
+	v.SetDefault("search_backend", PostgresSearchBackend)
+	v.SetDefault("search_index_path", "data/search.bleve")
+
+	backend := strings.ToLower(strings.TrimSpace(v.GetString("search_backend")))
+	if backend != BleveSearchBackend {
+		backend = PostgresSearchBackend
+	}
+
+	return &SearchConfig{
+		Backend:   backend,
+		IndexPath: v.GetString("search_index_path"),
+	}
+}
+
 // getSecretKey retrieves and validates the secret key from the provided
 // Viper configuration instance. The secret key is expected to be a string
 // that is trimmed of any leading or trailing whitespace and must meet the
@@ -369,6 +501,9 @@ func NewAppConfig() (*appConfig, error) {
 		databaseConfig: initDatabaseConfig(viperInstance),
 		jwtConfig:      jwtConfig,
 		corsConfig:     initCorsConfig(viperInstance),
+		videoConfig:    initVideoConfig(viperInstance),
+		commentConfig:  initCommentConfig(viperInstance),
+		searchConfig:   initSearchConfig(viperInstance),
 	}, nil
 }
 
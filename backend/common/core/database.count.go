@@ -0,0 +1,97 @@
+package core
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// CountMode is how the total number of rows of a paginated list is computed. Clients choose it
+// with the "count" query parameter.
+type CountMode string
+
+const (
+	// ExactCountMode counts every row matching the query.
+	ExactCountMode CountMode = "exact"
+
+	// EstimatedCountMode reads the total of unfiltered lists from the planner statistics, and
+	// counts filtered lists exactly up to MaxExactCount rows.
+	EstimatedCountMode CountMode = "estimated"
+
+	// NoCountMode skips counting, for clients which only page forward.
+	NoCountMode CountMode = "none"
+)
+
+// MaxExactCount is the number of rows up to which estimated counts are exact.
+const MaxExactCount = 10000
+
+// RowCount is the total number of rows of a paginated list. Total is a lower bound when a
+// filtered count reached MaxExactCount, and zero when the rows were not counted.
+type RowCount struct {
+	Total   int64
+	IsExact bool
+}
+
+// GetCountMode retrieves the count mode from the "count" query parameter of the given HTTP request.
+// If the parameter is not provided or is unknown, it returns EstimatedCountMode.
+func GetCountMode(r *http.Request) CountMode {
+	switch mode := CountMode(r.URL.Query().Get("count")); mode {
+	case ExactCountMode, NoCountMode:
+		return mode
+	default:
+		return EstimatedCountMode
+	}
+}
+
+// CountRows counts the rows matched by the query, which must have a model, according to the mode.
+//
+// With EstimatedCountMode, the total of a query without conditions is the row estimate of its
+// table in pg_class, which VACUUM and ANALYZE keep up to date. Tables estimated below
+// MaxExactCount rows, or never analyzed, are counted like filtered queries: exactly, stopping
+// at MaxExactCount rows.
+func CountRows(db *gorm.DB, mode CountMode) (RowCount, error) {
+	db = db.Session(&gorm.Session{})
+
+	switch mode {
+	case NoCountMode:
+		return RowCount{}, nil
+	case ExactCountMode:
+		var total int64
+		if result := db.Count(&total); result.Error != nil {
+			return RowCount{}, result.Error
+		}
+
+		return RowCount{Total: total, IsExact: true}, nil
+	}
+
+	if _, filtered := db.Statement.Clauses["WHERE"]; !filtered {
+		if err := db.Statement.Parse(db.Statement.Model); err != nil {
+			return RowCount{}, err
+		}
+
+		// The schema keeps the table name qualified, unlike the statement.
+		var estimate int64
+		if result := db.Session(&gorm.Session{NewDB: true}).
+			Raw("SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass(?)", db.Statement.Schema.Table).
+			Scan(&estimate); result.Error != nil {
+			return RowCount{}, result.Error
+		}
+
+		if estimate >= MaxExactCount {
+			return RowCount{Total: estimate}, nil
+		}
+	}
+
+	var total int64
+	if result := db.Session(&gorm.Session{NewDB: true}).
+		Table("(?) AS capped_rows", db.Select("1").Limit(MaxExactCount+1)).
+		Count(&total); result.Error != nil {
+		return RowCount{}, result.Error
+	}
+
+	if total > MaxExactCount {
+		return RowCount{Total: MaxExactCount}, nil
+	}
+
+	return RowCount{Total: total, IsExact: true}, nil
+}
@@ -21,8 +21,11 @@ type I18nBundleParams struct {
 
 const localizerCtxID LocalizerCtxKey = "LocalizerCtxID"
 
+const languagesCtxID LocalizerCtxKey = "LanguagesCtxID"
+
 const (
 	MsgSuccess                              = "S-0000"
+	MsgCommentPendingModeration             = "S-0001"
 	MsgInvalidEmailOrPassword               = "E-0000"
 	MsgFailedToDecodeRequestBody            = "E-0001"
 	MsgNeedToLogin                          = "E-0002"
@@ -31,6 +34,38 @@ const (
 	MsgValidationFailed                     = "E-0005"
 	MsgInvalidCurrentPassword               = "E-0006"
 	MsgCannotCreateTheShow                  = "E-0007"
+	MsgShowNotFound                         = "E-0008"
+	MsgKeywordNotFound                      = "E-0009"
+	MsgKeywordAlreadyExists                 = "E-0010"
+	MsgInvalidVideoLink                     = "E-0011"
+	MsgVideoNotFound                        = "E-0012"
+	MsgEpisodeNotFound                      = "E-0013"
+	MsgListNotFound                         = "E-0014"
+	MsgListPermissionDenied                 = "E-0015"
+	MsgShowAlreadyInList                    = "E-0016"
+	MsgListItemNotFound                     = "E-0017"
+	MsgInvalidListOrder                     = "E-0018"
+	MsgUserNotFound                         = "E-0019"
+	MsgAlreadyListMember                    = "E-0020"
+	MsgBatchJobNotFound                     = "E-0021"
+	MsgBatchJobFinished                     = "E-0022"
+	MsgTooManyBatchJobShows                 = "E-0023"
+	MsgCommentNotFound                      = "E-0024"
+	MsgCommentPermissionDenied              = "E-0025"
+	MsgCommentEditWindowExpired             = "E-0026"
+	MsgCommentAuthorMuted                   = "E-0027"
+	MsgCommentAlreadyReported               = "E-0028"
+	MsgMutedUserNotFound                    = "E-0029"
+	MsgShowNotFollowed                      = "E-0030"
+	MsgInvalidSubtitleFile                  = "E-0031"
+	MsgSubtitleNotFound                     = "E-0032"
+	MsgSubtitleFileTooLarge                 = "E-0033"
+	MsgSeasonNotFound                       = "E-0034"
+	MsgShowRelationAlreadyExists            = "E-0035"
+	MsgShowRelationCycle                    = "E-0036"
+	MsgShowRelationNotFound                 = "E-0037"
+	MsgShowRelatedToItself                  = "E-0038"
+	MsgPermissionDenied                     = "E-0040"
 	MsgRouteNotFound                        = "E-R404"
 	MsgInternalServerError                  = "U-0000"
 
@@ -50,6 +85,22 @@ func WithLocalizer(r *http.Request, localizer *i18n.Localizer) *http.Request {
 	return r.WithContext(context.WithValue(r.Context(), localizerCtxID, localizer))
 }
 
+// GetLanguages returns the languages requested by the client, ordered by priority.
+// If no language has been attached to the request, it falls back to English.
+func GetLanguages(r *http.Request) []string {
+	if languages, ok := r.Context().Value(languagesCtxID).([]string); ok && len(languages) > 0 {
+		return languages
+	}
+
+	return []string{language.English.String()}
+}
+
+// WithLanguages attaches the languages requested by the client to the given HTTP request's context.
+// The same languages should be used to build the localizer of the request.
+func WithLanguages(r *http.Request, languages []string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), languagesCtxID, languages))
+}
+
 // NewI18nBundle creates a new i18n.Bundle instance with support for YAML files from a given file system (fs.FS).
 // It loads translation messages from files matching the pattern "resources/trans/locale.*.yaml" and registers
 // a YAML unmarshal function for the bundle.
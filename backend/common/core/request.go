@@ -3,6 +3,8 @@ package core
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/schema"
 	"go.uber.org/fx"
@@ -83,3 +85,47 @@ func GetOffset(r *http.Request) int {
 
 	return (page - 1) * pageSize
 }
+
+// IsNotModified reports whether the client already has the current version of a resource,
+// according to the If-None-Match and If-Modified-Since request headers.
+// If-None-Match takes precedence over If-Modified-Since, as required by RFC 9110.
+func IsNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
+
+// GetBaseURL returns the scheme and host the client used to reach the server (e.g. "https://example.com").
+// The X-Forwarded-Proto header is honoured so that the URL is correct behind a reverse proxy.
+func GetBaseURL(r *http.Request) string {
+	scheme := "http"
+
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if forwardedProto := r.Header.Get("X-Forwarded-Proto"); forwardedProto != "" {
+		scheme = forwardedProto
+	}
+
+	return scheme + "://" + r.Host
+}
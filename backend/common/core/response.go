@@ -13,6 +13,9 @@ type Pagination struct {
 	PageSize   int   `json:"pageSize"`
 	TotalRows  int64 `json:"totalRows"`
 	TotalPages int   `json:"totalPages"`
+
+	// IsExact is false when TotalRows is an estimate, a lower bound or was not counted (see CountRows).
+	IsExact bool `json:"isExact"`
 }
 
 type Response[T any] struct {
@@ -49,11 +52,20 @@ func (r *ResponseBuilder) Pagination(totalRows int64) *ResponseBuilder {
 		PageSize:   pageSize,
 		TotalRows:  totalRows,
 		TotalPages: int(math.Ceil(float64(totalRows) / float64(pageSize))),
+		IsExact:    true,
 	}
 
 	return r
 }
 
+// CountedPagination sets the pagination of a list counted with CountRows.
+func (r *ResponseBuilder) CountedPagination(count RowCount) *ResponseBuilder {
+	r.Pagination(count.Total)
+	r.response.Pagination.IsExact = count.IsExact
+
+	return r
+}
+
 func (r *ResponseBuilder) Build() *Response[any] {
 	localizer := GetLocalizer(r.request)
 
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// ScheduledJob is an interface that defines a background task which is executed periodically.
+type ScheduledJob interface {
+	// Name returns a human-readable name of the job. It is used for logging purposes.
+	Name() string
+
+	// Interval returns the duration between two consecutive runs of the job.
+	Interval() time.Duration
+
+	// Run executes the job once. The given context is cancelled when the application stops.
+	Run(ctx context.Context) error
+}
+
+// Scheduler runs all registered scheduled jobs in the background for the whole application lifetime.
+type Scheduler struct {
+	logger *slog.Logger
+	jobs   []ScheduledJob
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type newSchedulerParams struct {
+	fx.In
+	Logger       *slog.Logger
+	AppLifeCycle fx.Lifecycle
+	Jobs         []ScheduledJob `group:"scheduled_jobs"`
+}
+
+// AsScheduledJob annotates the given constructor so that its result is registered as a ScheduledJob.
+func AsScheduledJob(function any) any {
+	return fx.Annotate(
+		function,
+		fx.As(new(ScheduledJob)),
+		fx.ResultTags(`group:"scheduled_jobs"`),
+	)
+}
+
+// newScheduler initializes a new Scheduler and hooks it into the application lifecycle.
+// Every job runs once right after the application starts and then once per its interval.
+func newScheduler(p newSchedulerParams) *Scheduler {
+	scheduler := &Scheduler{
+		logger: p.Logger,
+		jobs:   p.Jobs,
+	}
+
+	p.AppLifeCycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			scheduler.cancel = cancel
+
+			for _, job := range scheduler.jobs {
+				scheduler.wg.Add(1)
+
+				go scheduler.loop(ctx, job)
+			}
+
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			if scheduler.cancel != nil {
+				scheduler.cancel()
+			}
+
+			scheduler.wg.Wait()
+
+			return nil
+		},
+	})
+
+	return scheduler
+}
+
+// loop runs the given job until the context is cancelled.
+func (s *Scheduler) loop(ctx context.Context, job ScheduledJob) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+
+	for {
+		s.RunOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce executes the given job a single time and logs its outcome.
+func (s *Scheduler) RunOnce(ctx context.Context, job ScheduledJob) {
+	startedAt := time.Now()
+
+	if err := job.Run(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "The scheduled job failed",
+			slog.String("job", job.Name()),
+			DetailsLogAttr(err))
+
+		return
+	}
+
+	s.logger.DebugContext(ctx, "The scheduled job finished",
+		slog.String("job", job.Name()),
+		slog.Duration("elapsed", time.Since(startedAt)))
+}
+
+// NewSchedulerModule provides an fx.Option that runs every job registered through AsScheduledJob.
+func NewSchedulerModule() fx.Option {
+	return fx.Module(
+		"Scheduler Module",
+		fx.Provide(newScheduler),
+		fx.Invoke(func(*Scheduler) {}),
+	)
+}
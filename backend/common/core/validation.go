@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
@@ -19,6 +20,15 @@ func NewValidator(uni *ut.UniversalTranslator) *validator.Validate {
 
 	_ = en_translations.RegisterDefaultTranslations(v, trans)
 
+	_ = v.RegisterValidation("gtedatefield", isGteDateField)
+	_ = v.RegisterTranslation("gtedatefield", trans, func(ut ut.Translator) error {
+		return ut.Add("gtedatefield", "{0} must be on or after {1}", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T(fe.Tag(), fe.Field(), fe.Param())
+
+		return t
+	})
+
 	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
 		//nolint:mnd // No need to fix
 		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
@@ -34,6 +44,28 @@ func NewValidator(uni *ut.UniversalTranslator) *validator.Validate {
 	return v
 }
 
+// isGteDateField checks that a "2006-01-02" date is on or after the date of the field named by the
+// parameter, e.g. `validate:"omitempty,datetime=2006-01-02,gtedatefield=StartDate"`.
+// It passes when either date is missing or invalid, which the tags of the fields report.
+func isGteDateField(fl validator.FieldLevel) bool {
+	date, err := time.Parse(time.DateOnly, fl.Field().String())
+	if err != nil {
+		return true
+	}
+
+	otherField, _, _, ok := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !ok {
+		return true
+	}
+
+	otherDate, err := time.Parse(time.DateOnly, otherField.String())
+	if err != nil {
+		return true
+	}
+
+	return !date.Before(otherDate)
+}
+
 func TranslateValidationErrors(
 	r *http.Request,
 	uni *ut.UniversalTranslator,
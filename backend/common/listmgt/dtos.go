@@ -0,0 +1,89 @@
+package listmgt
+
+import (
+	"time"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type ListDTO struct {
+	ID          uuid.UUID `json:"id"`
+	OwnerID     uuid.UUID `json:"ownerId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Visibility  string    `json:"visibility"`
+
+	// The secret token of the share link, only returned to the members of the list.
+	ShareToken    *string                `json:"shareToken,omitempty"`
+	Items         []*ListItemDTO         `json:"items,omitempty"`
+	Collaborators []*ListCollaboratorDTO `json:"collaborators,omitempty"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	UpdatedAt     time.Time              `json:"updatedAt"`
+}
+
+type ListItemDTO struct {
+	Position int              `json:"position"`
+	Note     string           `json:"note"`
+	Show     *showmgt.ShowDTO `json:"show"`
+	AddedAt  time.Time        `json:"addedAt"`
+}
+
+type ListCollaboratorDTO struct {
+	UserID   uuid.UUID `json:"userId"`
+	Username string    `json:"username"`
+	AddedAt  time.Time `json:"addedAt"`
+}
+
+// ToListDTO converts a ListModel to a ListDTO. The share token is only included for the members
+// of the list; items and collaborators are included when they have been loaded.
+func ToListDTO(listModel *ListModel, role ListRole) *ListDTO {
+	if listModel == nil {
+		return nil
+	}
+
+	return &ListDTO{
+		ID:          listModel.ID,
+		OwnerID:     listModel.OwnerID,
+		Name:        listModel.Name,
+		Description: listModel.Description,
+		Visibility:  listModel.Visibility,
+		ShareToken:  lo.Ternary(role != NoListRole, &listModel.ShareToken, nil),
+		Items: lo.Map(listModel.Items, func(itemModel ListItemModel, _ int) *ListItemDTO {
+			return ToListItemDTO(&itemModel)
+		}),
+		Collaborators: lo.Map(listModel.Collaborators, func(collaboratorModel ListCollaboratorModel, _ int) *ListCollaboratorDTO {
+			return ToListCollaboratorDTO(&collaboratorModel)
+		}),
+		CreatedAt: listModel.CreatedAt,
+		UpdatedAt: listModel.UpdatedAt,
+	}
+}
+
+// ToListItemDTO converts a ListItemModel to a ListItemDTO.
+func ToListItemDTO(itemModel *ListItemModel) *ListItemDTO {
+	if itemModel == nil {
+		return nil
+	}
+
+	return &ListItemDTO{
+		Position: itemModel.Position,
+		Note:     itemModel.Note,
+		Show:     showmgt.ToShowDTO(&itemModel.Show),
+		AddedAt:  itemModel.CreatedAt,
+	}
+}
+
+// ToListCollaboratorDTO converts a ListCollaboratorModel to a ListCollaboratorDTO.
+func ToListCollaboratorDTO(collaboratorModel *ListCollaboratorModel) *ListCollaboratorDTO {
+	if collaboratorModel == nil {
+		return nil
+	}
+
+	return &ListCollaboratorDTO{
+		UserID:   collaboratorModel.UserID,
+		Username: collaboratorModel.User.Username,
+		AddedAt:  collaboratorModel.CreatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package listmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+	"wano-island/common/usermgt"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type addListCollaboratorHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	userRepository      usermgt.UserRepository
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type AddListCollaboratorHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	UserRepository      usermgt.UserRepository
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*addListCollaboratorHandler)(nil)
+
+func NewAddListCollaboratorHandler(p AddListCollaboratorHandlerParams) *addListCollaboratorHandler {
+	return &addListCollaboratorHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		userRepository:      p.UserRepository,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *addListCollaboratorHandler) Pattern() string {
+	return "POST /api/v1/lists/{id}/collaborators"
+}
+
+func (h *addListCollaboratorHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP allows a user, found by username, to edit the items of a list. Only the owner of the
+// list can do it.
+func (h *addListCollaboratorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+
+		return
+	}
+
+	var requestBody ListCollaboratorRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var collaboratorModel ListCollaboratorModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		listModel, _, err := FindUserList(tx, id, core.MustGetAuthUserFromRequest(r).GetID(), OwnerListRole)
+		if err != nil {
+			return err
+		}
+
+		user, err := h.userRepository.FindUserByUsername(reqCtx, tx, requestBody.Username)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+
+			return err
+		}
+
+		var count int64
+		if result := tx.Model(&ListCollaboratorModel{}).
+			Where("list_id = ? AND user_id = ?", listModel.ID, user.ID).
+			Count(&count); result.Error != nil {
+			return result.Error
+		}
+
+		if user.ID == listModel.OwnerID || count > 0 {
+			return ErrAlreadyListMember
+		}
+
+		collaboratorModel = ListCollaboratorModel{ListID: listModel.ID, UserID: user.ID, User: *user}
+
+		return tx.Omit("User").Create(&collaboratorModel).Error
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, responseBuilder.Data(ToListCollaboratorDTO(&collaboratorModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+	case errors.Is(err, ErrListPermissionDenied):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListPermissionDenied).Build())
+	case errors.Is(err, ErrUserNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgUserNotFound).Build())
+	case errors.Is(err, ErrAlreadyListMember):
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgAlreadyListMember).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when adding a list collaborator", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,123 @@
+package listmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type addListItemHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	activityRecorder    *showmgt.ShowActivityRecorder
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type AddListItemHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	ActivityRecorder    *showmgt.ShowActivityRecorder
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*addListItemHandler)(nil)
+
+func NewAddListItemHandler(p AddListItemHandlerParams) *addListItemHandler {
+	return &addListItemHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		activityRecorder:    p.ActivityRecorder,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *addListItemHandler) Pattern() string {
+	return "POST /api/v1/lists/{id}/items"
+}
+
+func (h *addListItemHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP appends a show at the end of a list. The owner and the collaborators can do it.
+// Adding a show to a list counts towards its popularity.
+func (h *addListItemHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+
+		return
+	}
+
+	var requestBody ListItemRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var itemModel *ListItemModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if _, _, err := FindUserList(tx, id, core.MustGetAuthUserFromRequest(r).GetID(), CollaboratorListRole); err != nil {
+			return err
+		}
+
+		var err error
+		itemModel, err = AddListItem(tx, id, &requestBody)
+
+		return err
+	})
+
+	switch {
+	case err == nil:
+		h.activityRecorder.Record(itemModel.ShowID, showmgt.ListAddShowActivity)
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, responseBuilder.Data(ToListItemDTO(itemModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+	case errors.Is(err, ErrListPermissionDenied):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListPermissionDenied).Build())
+	case errors.Is(err, ErrShowNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+	case errors.Is(err, ErrShowAlreadyInList):
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowAlreadyInList).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when adding a show to a list", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,90 @@
+package listmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type createListHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type CreateListHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*createListHandler)(nil)
+
+func NewCreateListHandler(p CreateListHandlerParams) *createListHandler {
+	return &createListHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *createListHandler) Pattern() string {
+	return "POST /api/v1/lists"
+}
+
+func (h *createListHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP creates an empty list owned by the user.
+func (h *createListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	var requestBody ListRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	listModel := ListModel{OwnerID: core.MustGetAuthUserFromRequest(r).GetID()}
+
+	err := requestBody.applyTo(&listModel)
+	if err == nil {
+		err = h.db.WithContext(reqCtx).Create(&listModel).Error
+	}
+
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when creating a list", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, responseBuilder.Data(ToListDTO(&listModel, OwnerListRole)).Build())
+}
@@ -0,0 +1,108 @@
+package listmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type deleteListCollaboratorHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type DeleteListCollaboratorHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*deleteListCollaboratorHandler)(nil)
+
+func NewDeleteListCollaboratorHandler(p DeleteListCollaboratorHandlerParams) *deleteListCollaboratorHandler {
+	return &deleteListCollaboratorHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *deleteListCollaboratorHandler) Pattern() string {
+	return "DELETE /api/v1/lists/{id}/collaborators/{userId}"
+}
+
+func (h *deleteListCollaboratorHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP removes a collaborator from a list. The owner can remove anyone, and collaborators
+// can remove themselves to leave the list.
+func (h *deleteListCollaboratorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+
+		return
+	}
+
+	collaboratorID, err := uuid.Parse(r.PathValue("userId"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgUserNotFound).Build())
+
+		return
+	}
+
+	userID := core.MustGetAuthUserFromRequest(r).GetID()
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		_, role, err := FindUserList(tx, id, userID, CollaboratorListRole)
+		if err != nil {
+			return err
+		}
+
+		if role != OwnerListRole && collaboratorID != userID {
+			return ErrListPermissionDenied
+		}
+
+		result := tx.Delete(&ListCollaboratorModel{}, "list_id = ? AND user_id = ?", id, collaboratorID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+	case errors.Is(err, ErrListPermissionDenied):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListPermissionDenied).Build())
+	case errors.Is(err, ErrUserNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgUserNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when removing a list collaborator", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,91 @@
+package listmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type deleteListItemHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type DeleteListItemHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*deleteListItemHandler)(nil)
+
+func NewDeleteListItemHandler(p DeleteListItemHandlerParams) *deleteListItemHandler {
+	return &deleteListItemHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *deleteListItemHandler) Pattern() string {
+	return "DELETE /api/v1/lists/{id}/items/{showId}"
+}
+
+func (h *deleteListItemHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP removes a show from a list. The owner and the collaborators can do it.
+func (h *deleteListItemHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+
+		return
+	}
+
+	showID, err := uuid.Parse(r.PathValue("showId"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListItemNotFound).Build())
+
+		return
+	}
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if _, _, err := FindUserList(tx, id, core.MustGetAuthUserFromRequest(r).GetID(), CollaboratorListRole); err != nil {
+			return err
+		}
+
+		return RemoveListItem(tx, id, showID)
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+	case errors.Is(err, ErrListPermissionDenied):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListPermissionDenied).Build())
+	case errors.Is(err, ErrListItemNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListItemNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when removing a show from a list", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
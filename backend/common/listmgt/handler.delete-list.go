@@ -0,0 +1,81 @@
+package listmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type deleteListHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type DeleteListHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*deleteListHandler)(nil)
+
+func NewDeleteListHandler(p DeleteListHandlerParams) *deleteListHandler {
+	return &deleteListHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *deleteListHandler) Pattern() string {
+	return "DELETE /api/v1/lists/{id}"
+}
+
+func (h *deleteListHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP deletes a list with its items and collaborators. Only its owner can do it.
+func (h *deleteListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+
+		return
+	}
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		listModel, _, err := FindUserList(tx, id, core.MustGetAuthUserFromRequest(r).GetID(), OwnerListRole)
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(listModel).Error
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+	case errors.Is(err, ErrListPermissionDenied):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListPermissionDenied).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when deleting a list", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
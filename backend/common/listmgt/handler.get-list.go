@@ -0,0 +1,82 @@
+package listmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getListHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetListHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getListHandler)(nil)
+
+func NewGetListHandler(p GetListHandlerParams) *getListHandler {
+	return &getListHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getListHandler) Pattern() string {
+	return "GET /api/v1/lists/{id}"
+}
+
+func (h *getListHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a list with its items. Members of the list also get its collaborators and
+// share token; other users can only get public lists.
+func (h *getListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+
+	listModel, role, err := FindUserList(db, id, core.MustGetAuthUserFromRequest(r).GetID(), NoListRole)
+	if err == nil {
+		query := PreloadListItems(db)
+		if role != NoListRole {
+			query = query.Preload("Collaborators.User")
+		}
+
+		err = query.First(listModel).Error
+	}
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(ToListDTO(listModel, role)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a list", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,84 @@
+package listmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getListsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetListsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getListsHandler)(nil)
+
+func NewGetListsHandler(p GetListsHandlerParams) *getListsHandler {
+	return &getListsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getListsHandler) Pattern() string {
+	return "GET /api/v1/lists"
+}
+
+func (h *getListsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a page of the lists owned by the user or shared with them as a collaborator,
+// the most recently updated first. Items are not included.
+func (h *getListsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+	userID := core.MustGetAuthUserFromRequest(r).GetID()
+
+	db := h.db.WithContext(reqCtx)
+	userLists := func() *gorm.DB {
+		return db.Model(&ListModel{}).Where("owner_id = ? OR id IN (?)", userID,
+			db.Model(&ListCollaboratorModel{}).Select("list_id").Where("user_id = ?", userID))
+	}
+
+	var totalRows int64
+	if result := userLists().Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var listModels []ListModel
+	if result := userLists().
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Order("updated_at DESC").
+		Find(&listModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting lists", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	listDTOs := lo.Map(listModels, func(listModel ListModel, _ int) *ListDTO {
+		return ToListDTO(&listModel, lo.Ternary(listModel.OwnerID == userID, OwnerListRole, CollaboratorListRole))
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(listDTOs).Pagination(totalRows).Build())
+}
@@ -0,0 +1,77 @@
+package listmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getPublicListHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetPublicListHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getPublicListHandler)(nil)
+
+func NewGetPublicListHandler(p GetPublicListHandlerParams) *getPublicListHandler {
+	return &getPublicListHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getPublicListHandler) Pattern() string {
+	return "GET /api/v1/public/lists/{key}"
+}
+
+func (h *getPublicListHandler) IsPrivateRoute() bool {
+	return false
+}
+
+// ServeHTTP returns a list with its items without requiring authentication. The key is either
+// the ID of a public list, or the share token of a public or unlisted list.
+func (h *getPublicListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	key := r.PathValue("key")
+	query := PreloadListItems(h.db.WithContext(reqCtx))
+
+	if id, err := uuid.Parse(key); err == nil {
+		query = query.Where("id = ? AND visibility = ?", id, PublicListVisibility)
+	} else {
+		query = query.Where("share_token = ? AND visibility IN ?", key, []string{PublicListVisibility, UnlistedListVisibility})
+	}
+
+	var listModel ListModel
+	if result := query.First(&listModel); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a shared list", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(ToListDTO(&listModel, NoListRole)).Build())
+}
@@ -0,0 +1,79 @@
+package listmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getPublicListsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetPublicListsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getPublicListsHandler)(nil)
+
+func NewGetPublicListsHandler(p GetPublicListsHandlerParams) *getPublicListsHandler {
+	return &getPublicListsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getPublicListsHandler) Pattern() string {
+	return "GET /api/v1/public/lists"
+}
+
+func (h *getPublicListsHandler) IsPrivateRoute() bool {
+	return false
+}
+
+// ServeHTTP returns a page of the public lists, the most recently updated first. Items are not
+// included. Unlisted lists are never returned here.
+func (h *getPublicListsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	db := h.db.WithContext(reqCtx)
+
+	var totalRows int64
+	if result := db.Model(&ListModel{}).Where("visibility = ?", PublicListVisibility).Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var listModels []ListModel
+	if result := db.Where("visibility = ?", PublicListVisibility).
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Order("updated_at DESC").
+		Find(&listModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting public lists", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	listDTOs := lo.Map(listModels, func(listModel ListModel, _ int) *ListDTO {
+		return ToListDTO(&listModel, NoListRole)
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(listDTOs).Pagination(totalRows).Build())
+}
@@ -0,0 +1,120 @@
+package listmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type reorderListItemsHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type ReorderListItemsHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*reorderListItemsHandler)(nil)
+
+func NewReorderListItemsHandler(p ReorderListItemsHandlerParams) *reorderListItemsHandler {
+	return &reorderListItemsHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *reorderListItemsHandler) Pattern() string {
+	return "PUT /api/v1/lists/{id}/items/order"
+}
+
+func (h *reorderListItemsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP reorders the shows of a list and returns the list with its items in the new order.
+// The owner and the collaborators can do it.
+func (h *reorderListItemsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+
+		return
+	}
+
+	var requestBody ReorderListItemsRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var (
+		listModel *ListModel
+		role      ListRole
+	)
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if listModel, role, err = FindUserList(tx, id, core.MustGetAuthUserFromRequest(r).GetID(), CollaboratorListRole); err != nil {
+			return err
+		}
+
+		if err = ReorderListItems(tx, id, requestBody.ShowIDs); err != nil {
+			return err
+		}
+
+		return PreloadListItems(tx).First(listModel).Error
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(ToListDTO(listModel, role)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListNotFound).Build())
+	case errors.Is(err, ErrListPermissionDenied):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgListPermissionDenied).Build())
+	case errors.Is(err, ErrInvalidListOrder):
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInvalidListOrder).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when reordering a list", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,262 @@
+package listmgt
+
+import (
+	"errors"
+	"strings"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// shareTokenLength is the length of the secret token of share links. It has to be long enough
+// to not be guessable, as it is the only thing protecting unlisted lists.
+const shareTokenLength = 32
+
+// ListRole is the role of a user on a list. Roles are ordered: an owner can do everything a
+// collaborator can do.
+type ListRole int
+
+const (
+	// NoListRole users can only view public lists.
+	NoListRole ListRole = iota
+
+	// CollaboratorListRole users can view the list and edit its items.
+	CollaboratorListRole
+
+	// OwnerListRole users can also edit the list itself, its collaborators, and delete it.
+	OwnerListRole
+)
+
+var (
+	// ErrListPermissionDenied is returned when a user can see a list but does not have the role
+	// required to change it.
+	ErrListPermissionDenied = errors.New("the user does not have the role required on the list")
+
+	// ErrShowNotFound is returned when adding a show which does not exist.
+	ErrShowNotFound = errors.New("the show cannot be found")
+
+	// ErrListItemNotFound is returned when a show is not in the list.
+	ErrListItemNotFound = errors.New("the show is not in the list")
+
+	// ErrUserNotFound is returned when adding a collaborator who does not exist, or removing a
+	// user who is not a collaborator.
+	ErrUserNotFound = errors.New("the user cannot be found")
+
+	// ErrShowAlreadyInList is returned when adding a show which is already in the list.
+	ErrShowAlreadyInList = errors.New("the show is already in the list")
+
+	// ErrInvalidListOrder is returned when a new order does not contain every show of the list exactly once.
+	ErrInvalidListOrder = errors.New("the order does not contain every show of the list exactly once")
+
+	// ErrAlreadyListMember is returned when adding the owner or an existing collaborator as a collaborator.
+	ErrAlreadyListMember = errors.New("the user is already the owner or a collaborator of the list")
+)
+
+// ListRequestBody holds the request body for creating or updating a list.
+type ListRequestBody struct {
+	Name        string `json:"name" validate:"required,max=256"`
+	Description string `json:"description" validate:"max=4096"`
+	Visibility  string `json:"visibility" validate:"required,oneof=private unlisted public"`
+
+	// Generates a new share link, so that the previous one stops working.
+	ResetShareLink bool `json:"resetShareLink"`
+}
+
+// ListItemRequestBody holds the request body for adding a show to a list.
+type ListItemRequestBody struct {
+	ShowID uuid.UUID `json:"showId" validate:"required"`
+	Note   string    `json:"note" validate:"max=4096"`
+}
+
+// ListItemNoteRequestBody holds the request body for changing the note of a show in a list.
+type ListItemNoteRequestBody struct {
+	Note string `json:"note" validate:"max=4096"`
+}
+
+// ReorderListItemsRequestBody holds the request body for reordering the shows of a list.
+type ReorderListItemsRequestBody struct {
+	// Every show of the list, in the new order.
+	ShowIDs []uuid.UUID `json:"showIds" validate:"required"`
+}
+
+// ListCollaboratorRequestBody holds the request body for adding a collaborator to a list.
+type ListCollaboratorRequestBody struct {
+	Username string `json:"username" validate:"required"`
+}
+
+// applyTo copies the fields of the request body to the list. The body must have been validated.
+// A share token is generated for new lists and when resetting the share link.
+func (b *ListRequestBody) applyTo(list *ListModel) error {
+	list.Name = strings.TrimSpace(b.Name)
+	list.Description = strings.TrimSpace(b.Description)
+	list.Visibility = b.Visibility
+
+	if list.ShareToken == "" || b.ResetShareLink {
+		shareToken, err := core.RandomString(shareTokenLength)
+		if err != nil {
+			return err
+		}
+
+		list.ShareToken = *shareToken
+	}
+
+	return nil
+}
+
+// FindUserList finds a list and checks that the user has at least the given role on it.
+// Lists the user cannot see are reported as not found (gorm.ErrRecordNotFound), so that their
+// existence is not leaked. Unlisted lists can only be seen by non-members with their share link.
+func FindUserList(tx *gorm.DB, listID uuid.UUID, userID uuid.UUID, requiredRole ListRole) (*ListModel, ListRole, error) {
+	var listModel ListModel
+	if result := tx.First(&listModel, "id = ?", listID); result.Error != nil {
+		return nil, NoListRole, result.Error
+	}
+
+	role := NoListRole
+
+	if listModel.OwnerID == userID {
+		role = OwnerListRole
+	} else {
+		var count int64
+		if result := tx.Model(&ListCollaboratorModel{}).
+			Where("list_id = ? AND user_id = ?", listID, userID).
+			Count(&count); result.Error != nil {
+			return nil, NoListRole, result.Error
+		}
+
+		if count > 0 {
+			role = CollaboratorListRole
+		}
+	}
+
+	if role == NoListRole && listModel.Visibility != PublicListVisibility {
+		return nil, NoListRole, gorm.ErrRecordNotFound
+	}
+
+	if role < requiredRole {
+		return nil, role, ErrListPermissionDenied
+	}
+
+	return &listModel, role, nil
+}
+
+// PreloadListItems loads the items of the list with their shows, ordered by position.
+func PreloadListItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Items.Show")
+}
+
+// AddListItem appends a show at the end of a list.
+func AddListItem(tx *gorm.DB, listID uuid.UUID, body *ListItemRequestBody) (*ListItemModel, error) {
+	var count int64
+	if result := tx.Model(&ListItemModel{}).
+		Where("list_id = ? AND show_id = ?", listID, body.ShowID).
+		Count(&count); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if count > 0 {
+		return nil, ErrShowAlreadyInList
+	}
+
+	var showModel showmgt.ShowModel
+	if result := tx.First(&showModel, "id = ?", body.ShowID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrShowNotFound
+		}
+
+		return nil, result.Error
+	}
+
+	var position int
+	if result := tx.Model(&ListItemModel{}).
+		Select("COALESCE(MAX(position) + 1, 0)").
+		Where("list_id = ?", listID).
+		Scan(&position); result.Error != nil {
+		return nil, result.Error
+	}
+
+	itemModel := ListItemModel{
+		ListID:   listID,
+		ShowID:   body.ShowID,
+		Position: position,
+		Note:     strings.TrimSpace(body.Note),
+	}
+
+	if result := tx.Omit("Show").Create(&itemModel); result.Error != nil {
+		return nil, result.Error
+	}
+
+	itemModel.Show = showModel
+
+	return &itemModel, nil
+}
+
+// UpdateListItemNote changes the note of a show in a list.
+func UpdateListItemNote(tx *gorm.DB, listID uuid.UUID, showID uuid.UUID, note string) (*ListItemModel, error) {
+	var itemModel ListItemModel
+	if result := tx.Preload("Show").First(&itemModel, "list_id = ? AND show_id = ?", listID, showID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrListItemNotFound
+		}
+
+		return nil, result.Error
+	}
+
+	itemModel.Note = strings.TrimSpace(note)
+
+	if result := tx.Omit("Show").Save(&itemModel); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &itemModel, nil
+}
+
+// RemoveListItem removes a show from a list and closes the gap it leaves in the positions.
+func RemoveListItem(tx *gorm.DB, listID uuid.UUID, showID uuid.UUID) error {
+	var itemModel ListItemModel
+	if result := tx.First(&itemModel, "list_id = ? AND show_id = ?", listID, showID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return ErrListItemNotFound
+		}
+
+		return result.Error
+	}
+
+	if result := tx.Delete(&itemModel); result.Error != nil {
+		return result.Error
+	}
+
+	return tx.Model(&ListItemModel{}).
+		Where("list_id = ? AND position > ?", listID, itemModel.Position).
+		Update("position", gorm.Expr("position - 1")).Error
+}
+
+// ReorderListItems gives the shows of a list the positions of their IDs in showIDs, which must
+// contain every show of the list exactly once.
+func ReorderListItems(tx *gorm.DB, listID uuid.UUID, showIDs []uuid.UUID) error {
+	var currentShowIDs []uuid.UUID
+	if result := tx.Model(&ListItemModel{}).Where("list_id = ?", listID).Pluck("show_id", &currentShowIDs); result.Error != nil {
+		return result.Error
+	}
+
+	if len(showIDs) != len(currentShowIDs) || len(lo.Uniq(showIDs)) != len(showIDs) || !lo.Every(currentShowIDs, showIDs) {
+		return ErrInvalidListOrder
+	}
+
+	if len(showIDs) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`UPDATE public.list_items SET position = new_order.position - 1, updated_at = NOW()
+		FROM unnest(?::uuid[]) WITH ORDINALITY AS new_order(show_id, position)
+		WHERE list_items.list_id = ? AND list_items.show_id = new_order.show_id`,
+		pq.StringArray(lo.Map(showIDs, func(showID uuid.UUID, _ int) string {
+			return showID.String()
+		})), listID).Error
+}
@@ -0,0 +1,70 @@
+package listmgt
+
+import (
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/common/usermgt"
+
+	"github.com/google/uuid"
+)
+
+// Visibilities of a list.
+const (
+	// PrivateListVisibility lists can only be seen by their owner and collaborators.
+	PrivateListVisibility = "private"
+
+	// UnlistedListVisibility lists can be seen by anyone having their share link.
+	UnlistedListVisibility = "unlisted"
+
+	// PublicListVisibility lists can be seen by anyone and are listed publicly.
+	PublicListVisibility = "public"
+)
+
+// ListModel is a named list of shows curated by a user, such as "Best of 2024 anime".
+// The share token is the secret part of the share link of unlisted lists.
+type ListModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+
+	OwnerID       uuid.UUID               `gorm:"type:uuid;not null;index"`
+	Name          string                  `gorm:"type:string;size:256;not null"`
+	Description   string                  `gorm:"type:text;not null"`
+	Visibility    string                  `gorm:"type:string;size:16;not null;index"`
+	ShareToken    string                  `gorm:"type:string;size:64;not null;uniqueIndex"`
+	Items         []ListItemModel         `gorm:"foreignKey:ListID;constraint:OnDelete:CASCADE"`
+	Collaborators []ListCollaboratorModel `gorm:"foreignKey:ListID;constraint:OnDelete:CASCADE"`
+}
+
+// ListItemModel is a show in a list. Items are ordered by position, starting at 0.
+type ListItemModel struct {
+	ListID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ShowID uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+
+	Position int               `gorm:"type:integer;not null"`
+	Note     string            `gorm:"type:text;not null"`
+	Show     showmgt.ShowModel `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+}
+
+// ListCollaboratorModel is a user allowed by the owner of a list to edit its items.
+type ListCollaboratorModel struct {
+	ListID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	core.HasCreatedAtColumn
+
+	User usermgt.UserModel `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (ListModel) TableName() string {
+	return "public.lists"
+}
+
+func (ListItemModel) TableName() string {
+	return "public.list_items"
+}
+
+func (ListCollaboratorModel) TableName() string {
+	return "public.list_collaborators"
+}
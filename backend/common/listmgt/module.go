@@ -0,0 +1,29 @@
+package listmgt
+
+import (
+	"wano-island/common/core"
+
+	"go.uber.org/fx"
+)
+
+// NewListMgtModule returns a new Fx module for managing the lists of shows curated by users.
+func NewListMgtModule() fx.Option {
+	return fx.Module(
+		"List management module",
+		fx.Provide(
+			core.AsRoute(NewGetListsHandler),
+			core.AsRoute(NewCreateListHandler),
+			core.AsRoute(NewGetListHandler),
+			core.AsRoute(NewUpdateListHandler),
+			core.AsRoute(NewDeleteListHandler),
+			core.AsRoute(NewAddListItemHandler),
+			core.AsRoute(NewReorderListItemsHandler),
+			core.AsRoute(NewUpdateListItemHandler),
+			core.AsRoute(NewDeleteListItemHandler),
+			core.AsRoute(NewAddListCollaboratorHandler),
+			core.AsRoute(NewDeleteListCollaboratorHandler),
+			core.AsRoute(NewGetPublicListsHandler),
+			core.AsRoute(NewGetPublicListHandler),
+		),
+	)
+}
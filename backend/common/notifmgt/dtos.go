@@ -0,0 +1,79 @@
+package notifmgt
+
+import (
+	"time"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type FollowedShowDTO struct {
+	Show       *showmgt.ShowDTO `json:"show"`
+	FollowedAt time.Time        `json:"followedAt"`
+}
+
+type NotificationDTO struct {
+	ID         uuid.UUID        `json:"id"`
+	Type       string           `json:"type"`
+	Show       *showmgt.ShowDTO `json:"show"`
+	AirDate    *string          `json:"airDate"`
+	OccurredAt time.Time        `json:"occurredAt"`
+	ReadAt     *time.Time       `json:"readAt"`
+}
+
+type UnreadNotificationCountDTO struct {
+	Count int64 `json:"count"`
+}
+
+type NotificationPreferenceDTO struct {
+	Type  string `json:"type"`
+	InApp bool   `json:"inApp"`
+	Email bool   `json:"email"`
+}
+
+// ToFollowedShowDTO converts a ShowFollowModel to a FollowedShowDTO.
+func ToFollowedShowDTO(followModel *ShowFollowModel) *FollowedShowDTO {
+	if followModel == nil {
+		return nil
+	}
+
+	return &FollowedShowDTO{
+		Show:       showmgt.ToShowDTO(&followModel.Show),
+		FollowedAt: followModel.FollowedAt,
+	}
+}
+
+// ToNotificationDTO converts a NotificationModel to a NotificationDTO.
+func ToNotificationDTO(notificationModel *NotificationModel) *NotificationDTO {
+	if notificationModel == nil {
+		return nil
+	}
+
+	var airDate *string
+	if notificationModel.AirDate != nil {
+		airDate = lo.ToPtr(notificationModel.AirDate.Format(time.DateOnly))
+	}
+
+	return &NotificationDTO{
+		ID:         notificationModel.ID,
+		Type:       notificationModel.Type,
+		Show:       showmgt.ToShowDTO(&notificationModel.Show),
+		AirDate:    airDate,
+		OccurredAt: notificationModel.OccurredAt,
+		ReadAt:     notificationModel.ReadAt,
+	}
+}
+
+// ToNotificationPreferenceDTO converts a NotificationPreferenceModel to a NotificationPreferenceDTO.
+func ToNotificationPreferenceDTO(preferenceModel *NotificationPreferenceModel) *NotificationPreferenceDTO {
+	if preferenceModel == nil {
+		return nil
+	}
+
+	return &NotificationPreferenceDTO{
+		Type:  preferenceModel.Type,
+		InApp: preferenceModel.InApp,
+		Email: preferenceModel.Email,
+	}
+}
@@ -0,0 +1,74 @@
+package notifmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type followShowHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type FollowShowHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*followShowHandler)(nil)
+
+func NewFollowShowHandler(p FollowShowHandlerParams) *followShowHandler {
+	return &followShowHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *followShowHandler) Pattern() string {
+	return "PUT /api/v1/shows/{id}/follow"
+}
+
+func (h *followShowHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP makes the current user follow the show, so that they are notified of its new episodes
+// and release.
+func (h *followShowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		return FollowShow(tx, core.MustGetAuthUserFromRequest(r).GetID(), showID)
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+	case errors.Is(err, ErrShowNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when following a show", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
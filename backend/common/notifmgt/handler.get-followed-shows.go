@@ -0,0 +1,80 @@
+package notifmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getFollowedShowsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetFollowedShowsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getFollowedShowsHandler)(nil)
+
+func NewGetFollowedShowsHandler(p GetFollowedShowsHandlerParams) *getFollowedShowsHandler {
+	return &getFollowedShowsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getFollowedShowsHandler) Pattern() string {
+	return "GET /api/v1/follows"
+}
+
+func (h *getFollowedShowsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a page of the shows followed by the current user, the last followed first.
+func (h *getFollowedShowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	db := h.db.WithContext(reqCtx)
+	userID := core.MustGetAuthUserFromRequest(r).GetID()
+
+	var totalRows int64
+	if result := db.Model(&ShowFollowModel{}).Where("user_id = ?", userID).Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var followModels []ShowFollowModel
+	if result := db.Preload("Show").
+		Where("user_id = ?", userID).
+		Order("followed_at DESC").
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Find(&followModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting followed shows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	followedShowDTOs := lo.Map(followModels, func(followModel ShowFollowModel, _ int) *FollowedShowDTO {
+		return ToFollowedShowDTO(&followModel)
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(followedShowDTOs).Pagination(totalRows).Build())
+}
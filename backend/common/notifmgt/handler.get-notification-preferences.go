@@ -0,0 +1,66 @@
+package notifmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getNotificationPreferencesHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetNotificationPreferencesHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getNotificationPreferencesHandler)(nil)
+
+func NewGetNotificationPreferencesHandler(p GetNotificationPreferencesHandlerParams) *getNotificationPreferencesHandler {
+	return &getNotificationPreferencesHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getNotificationPreferencesHandler) Pattern() string {
+	return "GET /api/v1/notifications/preferences"
+}
+
+func (h *getNotificationPreferencesHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the preferences of the current user for every type of notification.
+func (h *getNotificationPreferencesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	preferenceModels, err := GetNotificationPreferences(h.db.WithContext(reqCtx),
+		core.MustGetAuthUserFromRequest(r).GetID())
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting notification preferences",
+			core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	preferenceDTOs := lo.Map(preferenceModels,
+		func(preferenceModel NotificationPreferenceModel, _ int) *NotificationPreferenceDTO {
+			return ToNotificationPreferenceDTO(&preferenceModel)
+		})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(preferenceDTOs).Build())
+}
@@ -0,0 +1,91 @@
+package notifmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getNotificationsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetNotificationsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getNotificationsHandler)(nil)
+
+func NewGetNotificationsHandler(p GetNotificationsHandlerParams) *getNotificationsHandler {
+	return &getNotificationsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getNotificationsHandler) Pattern() string {
+	return "GET /api/v1/notifications"
+}
+
+func (h *getNotificationsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a page of the notifications of the current user, the most recent first.
+// Only the unread ones are returned when the "unread" query parameter is true.
+func (h *getNotificationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	db := h.db.WithContext(reqCtx)
+	userID := core.MustGetAuthUserFromRequest(r).GetID()
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+	notifications := func() *gorm.DB {
+		query := db.Model(&NotificationModel{}).Where("user_id = ?", userID)
+		if unreadOnly {
+			query = query.Where("read_at IS NULL")
+		}
+
+		return query
+	}
+
+	var totalRows int64
+	if result := notifications().Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var notificationModels []NotificationModel
+	if result := notifications().
+		Preload("Show").
+		Order("occurred_at DESC, id DESC").
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Find(&notificationModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting notifications", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	notificationDTOs := lo.Map(notificationModels, func(notificationModel NotificationModel, _ int) *NotificationDTO {
+		return ToNotificationDTO(&notificationModel)
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(notificationDTOs).Pagination(totalRows).Build())
+}
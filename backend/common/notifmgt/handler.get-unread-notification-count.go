@@ -0,0 +1,62 @@
+package notifmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getUnreadNotificationCountHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetUnreadNotificationCountHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getUnreadNotificationCountHandler)(nil)
+
+func NewGetUnreadNotificationCountHandler(p GetUnreadNotificationCountHandlerParams) *getUnreadNotificationCountHandler {
+	return &getUnreadNotificationCountHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getUnreadNotificationCountHandler) Pattern() string {
+	return "GET /api/v1/notifications/unread-count"
+}
+
+func (h *getUnreadNotificationCountHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the number of unread notifications of the current user, e.g. for a badge.
+func (h *getUnreadNotificationCountHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	var count int64
+	if result := h.db.WithContext(reqCtx).
+		Model(&NotificationModel{}).
+		Where("user_id = ? AND read_at IS NULL", core.MustGetAuthUserFromRequest(r).GetID()).
+		Count(&count); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when counting unread notifications",
+			core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(&UnreadNotificationCountDTO{Count: count}).Build())
+}
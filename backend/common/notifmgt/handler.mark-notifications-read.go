@@ -0,0 +1,96 @@
+package notifmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type markNotificationsReadHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type MarkNotificationsReadHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*markNotificationsReadHandler)(nil)
+
+func NewMarkNotificationsReadHandler(p MarkNotificationsReadHandlerParams) *markNotificationsReadHandler {
+	return &markNotificationsReadHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *markNotificationsReadHandler) Pattern() string {
+	return "POST /api/v1/notifications/read"
+}
+
+func (h *markNotificationsReadHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP marks the given notifications of the current user as read, or all of them when none is
+// given, and returns the number of notifications left unread.
+func (h *markNotificationsReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	var requestBody MarkNotificationsReadRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	userID := core.MustGetAuthUserFromRequest(r).GetID()
+
+	var count int64
+
+	err := h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if _, err := MarkNotificationsRead(tx, userID, requestBody.NotificationIDs, time.Now()); err != nil {
+			return err
+		}
+
+		return tx.Model(&NotificationModel{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	})
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when marking notifications as read", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(&UnreadNotificationCountDTO{Count: count}).Build())
+}
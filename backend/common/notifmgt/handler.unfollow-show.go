@@ -0,0 +1,76 @@
+package notifmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type unfollowShowHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type UnfollowShowHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*unfollowShowHandler)(nil)
+
+func NewUnfollowShowHandler(p UnfollowShowHandlerParams) *unfollowShowHandler {
+	return &unfollowShowHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *unfollowShowHandler) Pattern() string {
+	return "DELETE /api/v1/shows/{id}/follow"
+}
+
+func (h *unfollowShowHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP makes the current user stop following the show. The notifications they have already
+// received are kept.
+func (h *unfollowShowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFollowed).Build())
+
+		return
+	}
+
+	result := h.db.WithContext(reqCtx).Delete(&ShowFollowModel{},
+		"user_id = ? AND show_id = ?", core.MustGetAuthUserFromRequest(r).GetID(), showID)
+	if result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when unfollowing a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFollowed).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+}
@@ -0,0 +1,107 @@
+package notifmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type updateNotificationPreferencesHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type UpdateNotificationPreferencesHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*updateNotificationPreferencesHandler)(nil)
+
+func NewUpdateNotificationPreferencesHandler(
+	p UpdateNotificationPreferencesHandlerParams,
+) *updateNotificationPreferencesHandler {
+	return &updateNotificationPreferencesHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *updateNotificationPreferencesHandler) Pattern() string {
+	return "PUT /api/v1/notifications/preferences"
+}
+
+func (h *updateNotificationPreferencesHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP updates the preferences of the current user for the given types of notification, and
+// returns the preferences for every type.
+func (h *updateNotificationPreferencesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	var requestBody NotificationPreferencesRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	userID := core.MustGetAuthUserFromRequest(r).GetID()
+
+	var preferenceModels []NotificationPreferenceModel
+
+	err := h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if err := SaveNotificationPreferences(tx, userID, &requestBody); err != nil {
+			return err
+		}
+
+		var err error
+		preferenceModels, err = GetNotificationPreferences(tx, userID)
+
+		return err
+	})
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when updating notification preferences",
+			core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	preferenceDTOs := lo.Map(preferenceModels,
+		func(preferenceModel NotificationPreferenceModel, _ int) *NotificationPreferenceDTO {
+			return ToNotificationPreferenceDTO(&preferenceModel)
+		})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(preferenceDTOs).Build())
+}
@@ -0,0 +1,90 @@
+package notifmgt
+
+import (
+	"context"
+	"log/slog"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// notificationsInsertSize is the number of notifications inserted per statement.
+const notificationsInsertSize = 500
+
+// notificationListener turns the catalog events into notifications for the followers of the shows.
+type notificationListener struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type NotificationListenerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ showmgt.CatalogEventListener = (*notificationListener)(nil)
+
+func NewNotificationListener(p NotificationListenerParams) *notificationListener {
+	return &notificationListener{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (l *notificationListener) Name() string {
+	return "notifications"
+}
+
+// OnCatalogEvents creates a notification of each event for every follower of the show, unless they
+// have turned off the in-app notifications of its type. Events users cannot be notified of are
+// skipped.
+func (l *notificationListener) OnCatalogEvents(ctx context.Context, events []showmgt.CatalogEvent) error {
+	return l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, event := range events {
+			if !lo.Contains(showmgt.CatalogEventTypes, event.Type) {
+				continue
+			}
+
+			var userIDs []uuid.UUID
+			if result := tx.Model(&ShowFollowModel{}).
+				Joins("LEFT JOIN public.notification_preferences AS preferences "+
+					"ON preferences.user_id = show_follows.user_id AND preferences.type = ?", event.Type).
+				Where("show_follows.show_id = ? AND COALESCE(preferences.in_app, TRUE)", event.ShowID).
+				Pluck("show_follows.user_id", &userIDs); result.Error != nil {
+				return result.Error
+			}
+
+			if len(userIDs) == 0 {
+				continue
+			}
+
+			notifications := lo.Map(userIDs, func(userID uuid.UUID, _ int) NotificationModel {
+				return NotificationModel{
+					UserID:     userID,
+					ShowID:     event.ShowID,
+					Type:       event.Type,
+					AirDate:    event.AirDate,
+					OccurredAt: event.OccurredAt,
+				}
+			})
+
+			l.logger.DebugContext(ctx, "Notifying the followers of a show",
+				slog.String("type", event.Type),
+				slog.String("showId", event.ShowID.String()),
+				slog.Int("followers", len(userIDs)))
+
+			if result := tx.Omit(clause.Associations).
+				CreateInBatches(notifications, notificationsInsertSize); result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return nil
+	})
+}
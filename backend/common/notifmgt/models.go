@@ -0,0 +1,64 @@
+package notifmgt
+
+import (
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/common/usermgt"
+
+	"github.com/google/uuid"
+)
+
+// ShowFollowModel is a show followed by a user, who is notified of its catalog events.
+type ShowFollowModel struct {
+	UserID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ShowID uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	core.HasCreatedAtColumn
+
+	FollowedAt time.Time         `gorm:"type:timestamptz;not null"`
+	User       usermgt.UserModel `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Show       showmgt.ShowModel `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+}
+
+// NotificationModel is an in-app notification of a catalog event of a show followed by the user.
+type NotificationModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index:idx_notifications_user_read"`
+	ShowID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Type   string    `gorm:"type:string;size:32;not null"`
+
+	// The new last air date of the show, for the new episode notifications.
+	AirDate    *time.Time `gorm:"type:date"`
+	OccurredAt time.Time  `gorm:"type:timestamptz;not null;index"`
+
+	// When the user has read the notification. Unread notifications have no read time.
+	ReadAt *time.Time        `gorm:"type:timestamptz;index:idx_notifications_user_read"`
+	User   usermgt.UserModel `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Show   showmgt.ShowModel `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+}
+
+// NotificationPreferenceModel is the choice of a user for a type of catalog event. Users without
+// a preference for a type get the in-app notifications but no email.
+type NotificationPreferenceModel struct {
+	UserID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Type   string    `gorm:"primaryKey;type:string;size:32"`
+	core.HasUpdatedAtColumn
+
+	InApp bool              `gorm:"type:boolean;not null"`
+	Email bool              `gorm:"type:boolean;not null"`
+	User  usermgt.UserModel `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (ShowFollowModel) TableName() string {
+	return "public.show_follows"
+}
+
+func (NotificationModel) TableName() string {
+	return "public.notifications"
+}
+
+func (NotificationPreferenceModel) TableName() string {
+	return "public.notification_preferences"
+}
@@ -0,0 +1,27 @@
+package notifmgt
+
+import (
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+
+	"go.uber.org/fx"
+)
+
+// NewNotifMgtModule returns a new Fx module for managing the followed shows and the notifications
+// of their catalog events.
+func NewNotifMgtModule() fx.Option {
+	return fx.Module(
+		"Notification management module",
+		fx.Provide(
+			showmgt.AsCatalogEventListener(NewNotificationListener),
+			core.AsRoute(NewFollowShowHandler),
+			core.AsRoute(NewUnfollowShowHandler),
+			core.AsRoute(NewGetFollowedShowsHandler),
+			core.AsRoute(NewGetNotificationsHandler),
+			core.AsRoute(NewGetUnreadNotificationCountHandler),
+			core.AsRoute(NewMarkNotificationsReadHandler),
+			core.AsRoute(NewGetNotificationPreferencesHandler),
+			core.AsRoute(NewUpdateNotificationPreferencesHandler),
+		),
+	)
+}
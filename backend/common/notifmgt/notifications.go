@@ -0,0 +1,116 @@
+package notifmgt
+
+import (
+	"errors"
+	"slices"
+	"time"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrShowNotFound is returned when following a show which does not exist.
+var ErrShowNotFound = errors.New("the show cannot be found")
+
+// MarkNotificationsReadRequestBody holds the request body for marking notifications as read.
+type MarkNotificationsReadRequestBody struct {
+	// The notifications to mark as read. Every unread notification is marked when it is empty.
+	NotificationIDs []uuid.UUID `json:"notificationIds" validate:"max=1000"`
+}
+
+// NotificationPreferenceRequestBody holds the preference of the user for a type of catalog event.
+type NotificationPreferenceRequestBody struct {
+	Type  string `json:"type" validate:"required,oneof=new_episode show_released"`
+	InApp bool   `json:"inApp"`
+	Email bool   `json:"email"`
+}
+
+// NotificationPreferencesRequestBody holds the request body for updating the notification
+// preferences. The types which are not given are left unchanged.
+type NotificationPreferencesRequestBody struct {
+	Preferences []NotificationPreferenceRequestBody `json:"preferences" validate:"required,min=1,dive"`
+}
+
+// FollowShow makes the user follow the show. Following a show twice does nothing.
+func FollowShow(tx *gorm.DB, userID uuid.UUID, showID uuid.UUID) error {
+	var showCount int64
+	if result := tx.Model(&showmgt.ShowModel{}).Where("id = ?", showID).Count(&showCount); result.Error != nil {
+		return result.Error
+	}
+
+	if showCount == 0 {
+		return ErrShowNotFound
+	}
+
+	follow := ShowFollowModel{
+		UserID:     userID,
+		ShowID:     showID,
+		FollowedAt: time.Now(),
+	}
+
+	return tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&follow).Error
+}
+
+// MarkNotificationsRead marks the given unread notifications of the user as read, or all of them
+// when none is given, and returns how many have been marked.
+func MarkNotificationsRead(tx *gorm.DB, userID uuid.UUID, notificationIDs []uuid.UUID, now time.Time) (int64, error) {
+	query := tx.Model(&NotificationModel{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(notificationIDs) > 0 {
+		query = query.Where("id IN ?", notificationIDs)
+	}
+
+	result := query.Update("read_at", now)
+
+	return result.RowsAffected, result.Error
+}
+
+// GetNotificationPreferences returns the preferences of the user for every type of catalog event,
+// in the order of showmgt.CatalogEventTypes. The default preference is returned for the types the
+// user has not chosen yet.
+func GetNotificationPreferences(db *gorm.DB, userID uuid.UUID) ([]NotificationPreferenceModel, error) {
+	var preferences []NotificationPreferenceModel
+	if result := db.Where("user_id = ?", userID).Find(&preferences); result.Error != nil {
+		return nil, result.Error
+	}
+
+	preferencesByType := lo.KeyBy(preferences, func(preference NotificationPreferenceModel) string {
+		return preference.Type
+	})
+
+	return lo.Map(showmgt.CatalogEventTypes, func(eventType string, _ int) NotificationPreferenceModel {
+		if preference, ok := preferencesByType[eventType]; ok {
+			return preference
+		}
+
+		return NotificationPreferenceModel{UserID: userID, Type: eventType, InApp: true}
+	}), nil
+}
+
+// SaveNotificationPreferences creates or replaces the preferences of the user for the given types.
+// When a type is given more than once, the last preference wins.
+func SaveNotificationPreferences(tx *gorm.DB, userID uuid.UUID, body *NotificationPreferencesRequestBody) error {
+	preferences := []NotificationPreferenceModel{}
+
+	for _, preferenceBody := range slices.Backward(body.Preferences) {
+		if lo.ContainsBy(preferences, func(preference NotificationPreferenceModel) bool {
+			return preference.Type == preferenceBody.Type
+		}) {
+			continue
+		}
+
+		preferences = append(preferences, NotificationPreferenceModel{
+			UserID: userID,
+			Type:   preferenceBody.Type,
+			InApp:  preferenceBody.InApp,
+			Email:  preferenceBody.Email,
+		})
+	}
+
+	return tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "in_app", "email"}),
+	}).Create(&preferences).Error
+}
@@ -0,0 +1,489 @@
+package showmgt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Operations of a batch job, applied to every selected show.
+const (
+	// AddKeywordBatchOperation links the keyword of the job to the shows.
+	AddKeywordBatchOperation = "add_keyword"
+
+	// RemoveKeywordBatchOperation unlinks the keyword of the job from the shows.
+	RemoveKeywordBatchOperation = "remove_keyword"
+
+	// SetReleasedBatchOperation sets the IsReleased flag of the shows.
+	SetReleasedBatchOperation = "set_released"
+)
+
+// Statuses of a batch job.
+const (
+	PendingBatchJobStatus   = "pending"
+	RunningBatchJobStatus   = "running"
+	CompletedBatchJobStatus = "completed"
+	CancelledBatchJobStatus = "cancelled"
+)
+
+// Statuses of a show of a batch job.
+const (
+	PendingBatchItemStatus = "pending"
+
+	// SucceededBatchItemStatus items have been changed by the operation.
+	SucceededBatchItemStatus = "succeeded"
+
+	// SkippedBatchItemStatus items already matched the result of the operation.
+	SkippedBatchItemStatus = "skipped"
+
+	// FailedBatchItemStatus items could not be changed, the reason is kept in the item.
+	FailedBatchItemStatus = "failed"
+
+	// CancelledBatchItemStatus items were not processed because the job has been cancelled.
+	CancelledBatchItemStatus = "cancelled"
+)
+
+const (
+	// MaxBatchJobShows is the maximum number of shows a batch job can change.
+	MaxBatchJobShows = 10000
+
+	// batchJobChunkSize is the number of shows processed between two checks of the cancellation.
+	batchJobChunkSize = 25
+
+	// batchJobItemsInsertSize is the number of items inserted per statement when creating a job.
+	batchJobItemsInsertSize = 500
+
+	// batchJobLease is how long a running job stays claimed by its worker without a heartbeat.
+	// The worker renews it before every chunk of shows, so another worker only takes a job over
+	// once its worker has stopped, e.g. after a restart.
+	batchJobLease = time.Minute
+
+	// maxBatchItemAttempts is the number of times a show is tried before its item fails, so a show
+	// which always fails cannot block its job.
+	maxBatchItemAttempts = 3
+)
+
+var (
+	// ErrBatchJobFinished is returned when cancelling a batch job which is already finished.
+	ErrBatchJobFinished = errors.New("the batch job is already finished")
+
+	// ErrTooManyBatchJobShows is returned when the selection of a batch job has too many shows.
+	ErrTooManyBatchJobShows = errors.New("the batch job selects too many shows")
+
+	// ErrBatchJobLost is returned when the job has been claimed by another worker while running.
+	ErrBatchJobLost = errors.New("the batch job has been claimed by another worker")
+
+	// ErrShowNotFound is the reason of the failed items whose show does not exist anymore.
+	ErrShowNotFound = errors.New("the show cannot be found")
+
+	// ErrUnsupportedBatchOperation is the reason of the failed items of a job with an unknown operation.
+	ErrUnsupportedBatchOperation = errors.New("unsupported batch operation")
+)
+
+// BatchShowFilterRequestBody selects the shows matching the filters of the show list.
+type BatchShowFilterRequestBody struct {
+	Kind       string `json:"kind"`
+	Language   string `json:"language"`
+	Keyword    string `json:"keyword"`
+	IsReleased *bool  `json:"released"`
+}
+
+// BatchJobRequestBody holds the request body for creating a batch job. The shows are selected
+// either with their IDs or with a filter.
+type BatchJobRequestBody struct {
+	ShowIDs   []uuid.UUID                 `json:"showIds" validate:"required_without=Filter,excluded_with=Filter,max=10000"`
+	Filter    *BatchShowFilterRequestBody `json:"filter" validate:"required_without=ShowIDs"`
+	Operation string                      `json:"operation" validate:"required,oneof=add_keyword remove_keyword set_released"`
+
+	// The keyword of the keyword operations.
+	Keyword string `json:"keyword" validate:"required_if=Operation add_keyword,required_if=Operation remove_keyword"`
+
+	// The value of the set_released operation.
+	IsReleased *bool `json:"isReleased" validate:"required_if=Operation set_released"`
+}
+
+// ToShowFilters converts the filter of the request body to the filters of the show list.
+func (b *BatchShowFilterRequestBody) ToShowFilters() ShowFilters {
+	return ShowFilters{
+		Kind:       b.Kind,
+		Language:   b.Language,
+		Keyword:    NormalizeKeyword(b.Keyword),
+		IsReleased: b.IsReleased,
+	}
+}
+
+// FindKeyword returns the canonical keyword which the given value resolves to, through its name
+// or one of its synonyms.
+func FindKeyword(tx *gorm.DB, value string) (*KeywordModel, error) {
+	name := NormalizeKeyword(value)
+
+	var keyword KeywordModel
+	if result := tx.
+		Where("name = ? OR id IN (?)", name,
+			tx.Model(&KeywordSynonymModel{}).Select("keyword_id").Where("synonym = ?", name)).
+		First(&keyword); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &keyword, nil
+}
+
+// CreateBatchJob creates a pending batch job from the request body, with an item per selected
+// show. The keyword of the keyword operations must exist.
+func CreateBatchJob(tx *gorm.DB, body *BatchJobRequestBody, createdBy string) (*ShowBatchJobModel, error) {
+	job := ShowBatchJobModel{
+		HasCreatedByColumn: core.HasCreatedByColumn{CreatedBy: createdBy},
+		Operation:          body.Operation,
+		Status:             PendingBatchJobStatus,
+	}
+
+	switch body.Operation {
+	case AddKeywordBatchOperation, RemoveKeywordBatchOperation:
+		keyword, err := FindKeyword(tx, body.Keyword)
+		if err != nil {
+			return nil, err
+		}
+
+		job.Keyword = &keyword.Name
+	case SetReleasedBatchOperation:
+		job.IsReleased = body.IsReleased
+	}
+
+	showIDs := lo.Uniq(body.ShowIDs)
+	if body.Filter != nil {
+		if result := body.Filter.ToShowFilters().Apply(tx.Model(&ShowModel{}), "").
+			Order("id").
+			Limit(MaxBatchJobShows+1).
+			Pluck("id", &showIDs); result.Error != nil {
+			return nil, result.Error
+		}
+	}
+
+	if len(showIDs) > MaxBatchJobShows {
+		return nil, ErrTooManyBatchJobShows
+	}
+
+	job.Total = len(showIDs)
+	if result := tx.Omit("Items").Create(&job); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if len(showIDs) == 0 {
+		return &job, nil
+	}
+
+	items := lo.Map(showIDs, func(showID uuid.UUID, position int) ShowBatchJobItemModel {
+		return ShowBatchJobItemModel{JobID: job.ID, ShowID: showID, Position: position, Status: PendingBatchItemStatus}
+	})
+
+	return &job, tx.CreateInBatches(items, batchJobItemsInsertSize).Error
+}
+
+// CancelBatchJob cancels a batch job. A pending job is cancelled right away, while a running job
+// is flagged and stopped by the worker before its next chunk of shows.
+func CancelBatchJob(tx *gorm.DB, job *ShowBatchJobModel) error {
+	switch job.Status {
+	case CompletedBatchJobStatus, CancelledBatchJobStatus:
+		return ErrBatchJobFinished
+	case RunningBatchJobStatus:
+		job.CancelRequested = true
+
+		return tx.Model(job).Update("cancel_requested", true).Error
+	default:
+		return finishBatchJob(tx, job, CancelledBatchJobStatus)
+	}
+}
+
+// finishBatchJob sets the final status of the job, and marks its remaining shows as cancelled.
+func finishBatchJob(tx *gorm.DB, job *ShowBatchJobModel, status string) error {
+	if status == CancelledBatchJobStatus {
+		if result := tx.Model(&ShowBatchJobItemModel{}).
+			Where("job_id = ? AND status = ?", job.ID, PendingBatchItemStatus).
+			Update("status", CancelledBatchItemStatus); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	finishedAt := time.Now()
+	job.Status = status
+	job.FinishedAt = &finishedAt
+
+	return tx.Model(job).Select("status", "finished_at").Updates(job).Error
+}
+
+// ClaimBatchJob claims the oldest job which is pending, or running with an expired lease, for the
+// worker, and marks it as running. The job is selected with FOR UPDATE SKIP LOCKED and updated in
+// the same statement, so concurrent workers never claim the same job. It returns nil when there is
+// no job to run.
+func ClaimBatchJob(db *gorm.DB, worker string) (*ShowBatchJobModel, error) {
+	now := time.Now()
+	claimableJobIDs := db.Model(&ShowBatchJobModel{}).
+		Select("id").
+		Where("status = ? OR (status = ? AND (heartbeat_at IS NULL OR heartbeat_at < ?))",
+			PendingBatchJobStatus, RunningBatchJobStatus, now.Add(-batchJobLease)).
+		Order("id").
+		Limit(1).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked})
+
+	var jobs []ShowBatchJobModel
+	if result := db.Model(&jobs).
+		Clauses(clause.Returning{}).
+		Where("id = (?)", claimableJobIDs).
+		Updates(map[string]any{
+			"status":       RunningBatchJobStatus,
+			"worker":       worker,
+			"heartbeat_at": now,
+			"started_at":   gorm.Expr("COALESCE(started_at, ?)", now),
+		}); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return &jobs[0], nil
+}
+
+// RunBatchJob processes the pending shows of a job claimed with ClaimBatchJob, chunk by chunk,
+// until they are all processed or the job is cancelled. Every show is changed in its own
+// transaction, so a failed show does not affect the others, and the progress of the job is saved
+// with the change. A job which has been interrupted, e.g. by a restart or a database error, resumes
+// from its first pending show.
+// The catalog events of the changes are published with each show.
+// The claim is renewed before every chunk, and ErrBatchJobLost is returned when another worker has
+// claimed the job in the meantime.
+func RunBatchJob(ctx context.Context, db *gorm.DB, job *ShowBatchJobModel) error {
+	for {
+		if result := db.Model(job).
+			Where("worker = ?", job.Worker).
+			Update("heartbeat_at", time.Now()); result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return ErrBatchJobLost
+		}
+
+		if result := db.Model(job).Select("cancel_requested").First(job); result.Error != nil {
+			return result.Error
+		}
+
+		if job.CancelRequested {
+			return finishBatchJob(db, job, CancelledBatchJobStatus)
+		}
+
+		var items []ShowBatchJobItemModel
+		if result := db.
+			Where("job_id = ? AND status = ?", job.ID, PendingBatchItemStatus).
+			Order("position").
+			Limit(batchJobChunkSize).
+			Find(&items); result.Error != nil {
+			return result.Error
+		}
+
+		if len(items) == 0 {
+			return finishBatchJob(db, job, CompletedBatchJobStatus)
+		}
+
+		for i := range items {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if err := runBatchJobItem(db, job, &items[i]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// runBatchJobItem applies the operation of the job to the show of the item, and records its result.
+// An error which prevents the operation on this show fails the item (see batchItemFailureReason).
+// Other errors, e.g. of the database, are returned and leave the item pending, so it is retried
+// when the job is resumed, until the item has been tried maxBatchItemAttempts times. The item then
+// fails with the last error as its reason.
+func runBatchJobItem(db *gorm.DB, job *ShowBatchJobModel, item *ShowBatchJobItemModel) error {
+	if result := db.Model(item).
+		Where("status = ?", PendingBatchItemStatus).
+		UpdateColumn("attempts", gorm.Expr("attempts + 1")); result.Error != nil {
+		return result.Error
+	}
+
+	item.Attempts++
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		status, err := applyBatchOperation(tx, job, item.ShowID)
+		if err != nil {
+			return err
+		}
+
+		if err = recordBatchJobItem(tx, job, item, status, nil); err != nil {
+			return err
+		}
+
+		if status == SucceededBatchItemStatus && job.Operation == SetReleasedBatchOperation && *job.IsReleased {
+			return PublishCatalogEvents(tx,
+				CatalogEvent{Type: ShowReleasedCatalogEvent, ShowID: item.ShowID, OccurredAt: time.Now()})
+		}
+
+		return nil
+	})
+	if err == nil || errors.Is(err, ErrBatchJobLost) {
+		return err
+	}
+
+	reason, isItemFailure := batchItemFailureReason(err)
+	if !isItemFailure {
+		if item.Attempts < maxBatchItemAttempts {
+			return err
+		}
+
+		reason = err.Error()
+	}
+
+	return recordBatchJobItem(db, job, item, FailedBatchItemStatus, &reason)
+}
+
+// batchItemFailureReason returns the reason kept in a failed item when the error prevents the
+// operation on its show: the show has been deleted, its keywords are not in the vocabulary anymore
+// or the operation is not supported.
+func batchItemFailureReason(err error) (string, bool) {
+	var unknownKeywordsErr *UnknownKeywordsError
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrShowNotFound.Error(), true
+	case errors.As(err, &unknownKeywordsErr), errors.Is(err, ErrUnsupportedBatchOperation):
+		return err.Error(), true
+	default:
+		return "", false
+	}
+}
+
+// recordBatchJobItem saves the result of the item and counts it in the progress of the job.
+// It returns ErrBatchJobLost when the item has already been processed by another worker.
+func recordBatchJobItem(tx *gorm.DB, job *ShowBatchJobModel, item *ShowBatchJobItemModel, status string,
+	reason *string) error {
+	processedAt := time.Now()
+	item.Status = status
+	item.Error = reason
+	item.ProcessedAt = &processedAt
+
+	if result := tx.Model(item).
+		Where("status = ?", PendingBatchItemStatus).
+		Select("status", "error", "processed_at").
+		Updates(item); result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrBatchJobLost
+	}
+
+	counter := map[string]string{
+		SucceededBatchItemStatus: "succeeded",
+		SkippedBatchItemStatus:   "skipped",
+		FailedBatchItemStatus:    "failed",
+	}[status]
+
+	return tx.Model(job).Updates(map[string]any{
+		"processed": gorm.Expr("processed + 1"),
+		counter:     gorm.Expr(counter + " + 1"),
+	}).Error
+}
+
+// applyBatchOperation applies the operation of the job to the show, and returns whether it has
+// been changed (SucceededBatchItemStatus) or already matched the result (SkippedBatchItemStatus).
+// Every change is recorded as a revision of the show.
+func applyBatchOperation(tx *gorm.DB, job *ShowBatchJobModel, showID uuid.UUID) (string, error) {
+	var show ShowModel
+	if result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		First(&show, "id = ?", showID); result.Error != nil {
+		return "", result.Error
+	}
+
+	switch job.Operation {
+	case SetReleasedBatchOperation:
+		if job.IsReleased == nil || show.IsReleased == *job.IsReleased {
+			return SkippedBatchItemStatus, nil
+		}
+
+		if result := tx.Model(&show).Update("is_released", *job.IsReleased); result.Error != nil {
+			return "", result.Error
+		}
+
+		if err := recordShowRevision(tx, job, showID, "isReleased", !*job.IsReleased, *job.IsReleased); err != nil {
+			return "", err
+		}
+
+		return SucceededBatchItemStatus, nil
+	case AddKeywordBatchOperation, RemoveKeywordBatchOperation:
+		if job.Keyword == nil {
+			return SkippedBatchItemStatus, nil
+		}
+
+		hasKeyword := slices.Contains(show.Keywords, *job.Keyword)
+		if hasKeyword == (job.Operation == AddKeywordBatchOperation) {
+			return SkippedBatchItemStatus, nil
+		}
+
+		values := lo.Without(show.Keywords, *job.Keyword)
+		if job.Operation == AddKeywordBatchOperation {
+			values = append(slices.Clone([]string(show.Keywords)), *job.Keyword)
+		}
+
+		keywords, err := ResolveKeywords(tx, values)
+		if err != nil {
+			return "", err
+		}
+
+		oldKeywords := show.Keywords
+		show.Keywords = KeywordNames(keywords)
+		if result := tx.Omit(clause.Associations).Save(&show); result.Error != nil {
+			return "", result.Error
+		}
+
+		if err := LinkShowKeywords(tx, &show, keywords); err != nil {
+			return "", err
+		}
+
+		if err := recordShowRevision(tx, job, showID, "keywords", oldKeywords, show.Keywords); err != nil {
+			return "", err
+		}
+
+		return SucceededBatchItemStatus, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedBatchOperation, job.Operation)
+	}
+}
+
+// recordShowRevision records the change of a field of the show made by the job, on behalf of the
+// user who created the job. The field is named as in the show DTO.
+func recordShowRevision(tx *gorm.DB, job *ShowBatchJobModel, showID uuid.UUID, field string,
+	oldValue, newValue any) error {
+	oldJSON, err := json.Marshal(oldValue)
+	if err != nil {
+		return err
+	}
+
+	newJSON, err := json.Marshal(newValue)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&ShowRevisionModel{
+		HasCreatedByColumn: core.HasCreatedByColumn{CreatedBy: job.CreatedBy},
+		ShowID:             showID,
+		BatchJobID:         &job.ID,
+		Field:              field,
+		OldValue:           string(oldJSON),
+		NewValue:           string(newJSON),
+	}).Error
+}
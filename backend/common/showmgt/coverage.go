@@ -0,0 +1,267 @@
+package showmgt
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// TranslatableKind is the kind of content covered by the translation coverage report.
+type TranslatableKind string
+
+const (
+	ShowTranslatable    TranslatableKind = "show"
+	SeasonTranslatable  TranslatableKind = "season"
+	EpisodeTranslatable TranslatableKind = "episode"
+)
+
+// TranslatableItem is a show, season or episode which should be translated in every locale
+// other than the original language of its show.
+type TranslatableItem struct {
+	Kind             TranslatableKind
+	ID               uuid.UUID
+	ShowID           uuid.UUID
+	ShowTitle        string
+	Order            int
+	OriginalLanguage string
+	UpdatedAt        time.Time
+}
+
+// TranslationStamp tells when the translation of an item in a locale was last updated.
+type TranslationStamp struct {
+	ItemID    uuid.UUID
+	Locale    string
+	UpdatedAt time.Time
+}
+
+// CoverageEntry is an item which is either not translated or whose translation is outdated.
+type CoverageEntry struct {
+	Kind                 TranslatableKind `json:"kind"`
+	ID                   uuid.UUID        `json:"id"`
+	ShowID               uuid.UUID        `json:"showId"`
+	ShowTitle            string           `json:"showTitle"`
+	Order                int              `json:"order"`
+	UpdatedAt            time.Time        `json:"updatedAt"`
+	TranslationUpdatedAt *time.Time       `json:"translationUpdatedAt,omitempty"`
+}
+
+// KindCoverage sums up the translations of one kind of content in a locale.
+type KindCoverage struct {
+	Total      int     `json:"total"`
+	Translated int     `json:"translated"`
+	UpToDate   int     `json:"upToDate"`
+	Coverage   float64 `json:"coverage"`
+}
+
+// LocaleCoverage is the translation coverage of a single locale. Coverage percentages only
+// count up-to-date translations.
+type LocaleCoverage struct {
+	Locale   string          `json:"locale"`
+	Coverage float64         `json:"coverage"`
+	Shows    KindCoverage    `json:"shows"`
+	Seasons  KindCoverage    `json:"seasons"`
+	Episodes KindCoverage    `json:"episodes"`
+	Missing  []CoverageEntry `json:"missing"`
+	Outdated []CoverageEntry `json:"outdated"`
+}
+
+// TranslationCoverageReport lists, for each locale, the content that still needs to be translated.
+type TranslationCoverageReport struct {
+	Locales []LocaleCoverage `json:"locales"`
+}
+
+// BuildTranslationCoverage computes the coverage of the given locales. Items are not expected to
+// be translated in the original language of their show. A translation is outdated when the item
+// has been updated after the translation.
+func BuildTranslationCoverage(
+	locales []string,
+	items []TranslatableItem,
+	translations []TranslationStamp,
+) *TranslationCoverageReport {
+	type translationKey struct {
+		itemID uuid.UUID
+		locale string
+	}
+
+	stamps := make(map[translationKey]time.Time, len(translations))
+	for _, translation := range translations {
+		stamps[translationKey{translation.ItemID, translation.Locale}] = translation.UpdatedAt
+	}
+
+	report := &TranslationCoverageReport{
+		Locales: make([]LocaleCoverage, 0, len(locales)),
+	}
+
+	for _, locale := range locales {
+		localeCoverage := LocaleCoverage{
+			Locale:   locale,
+			Missing:  []CoverageEntry{},
+			Outdated: []CoverageEntry{},
+		}
+
+		for _, item := range items {
+			if baseLanguage(item.OriginalLanguage) == baseLanguage(locale) {
+				continue
+			}
+
+			kindCoverage := localeCoverage.kindCoverage(item.Kind)
+			kindCoverage.Total++
+
+			entry := CoverageEntry{
+				Kind:      item.Kind,
+				ID:        item.ID,
+				ShowID:    item.ShowID,
+				ShowTitle: item.ShowTitle,
+				Order:     item.Order,
+				UpdatedAt: item.UpdatedAt,
+			}
+
+			translationUpdatedAt, found := stamps[translationKey{item.ID, locale}]
+			switch {
+			case !found:
+				localeCoverage.Missing = append(localeCoverage.Missing, entry)
+			case item.UpdatedAt.After(translationUpdatedAt):
+				kindCoverage.Translated++
+				entry.TranslationUpdatedAt = &translationUpdatedAt
+				localeCoverage.Outdated = append(localeCoverage.Outdated, entry)
+			default:
+				kindCoverage.Translated++
+				kindCoverage.UpToDate++
+			}
+		}
+
+		total, upToDate := 0, 0
+		for _, kindCoverage := range []*KindCoverage{&localeCoverage.Shows, &localeCoverage.Seasons, &localeCoverage.Episodes} {
+			kindCoverage.Coverage = coveragePercentage(kindCoverage.UpToDate, kindCoverage.Total)
+			total += kindCoverage.Total
+			upToDate += kindCoverage.UpToDate
+		}
+
+		localeCoverage.Coverage = coveragePercentage(upToDate, total)
+		report.Locales = append(report.Locales, localeCoverage)
+	}
+
+	return report
+}
+
+// kindCoverage returns the coverage counters of the given kind of content.
+func (c *LocaleCoverage) kindCoverage(kind TranslatableKind) *KindCoverage {
+	switch kind {
+	case SeasonTranslatable:
+		return &c.Seasons
+	case EpisodeTranslatable:
+		return &c.Episodes
+	default:
+		return &c.Shows
+	}
+}
+
+// coveragePercentage returns the percentage of translated items, rounded to two decimals.
+// A locale with nothing to translate is fully covered.
+func coveragePercentage(translated int, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+
+	return math.Round(float64(translated)*10000/float64(total)) / 100
+}
+
+// WriteCSV writes one line per missing or outdated translation, so that the report can be
+// shared with translators in a spreadsheet.
+func (r *TranslationCoverageReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{
+		"locale", "locale_coverage", "status", "kind", "id", "show_id", "show_title", "order",
+		"updated_at", "translation_updated_at",
+	}); err != nil {
+		return err
+	}
+
+	for _, localeCoverage := range r.Locales {
+		coverage := strconv.FormatFloat(localeCoverage.Coverage, 'f', 2, 64)
+
+		for _, group := range []struct {
+			status  string
+			entries []CoverageEntry
+		}{
+			{"missing", localeCoverage.Missing},
+			{"outdated", localeCoverage.Outdated},
+		} {
+			for _, entry := range group.entries {
+				translationUpdatedAt := ""
+				if entry.TranslationUpdatedAt != nil {
+					translationUpdatedAt = entry.TranslationUpdatedAt.UTC().Format(time.RFC3339)
+				}
+
+				if err := writer.Write([]string{
+					localeCoverage.Locale, coverage, group.status, string(entry.Kind), entry.ID.String(),
+					entry.ShowID.String(), entry.ShowTitle, strconv.Itoa(entry.Order),
+					entry.UpdatedAt.UTC().Format(time.RFC3339), translationUpdatedAt,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// LoadTranslationCoverage loads the shows, seasons, episodes and their translations from the
+// database and builds the coverage report of the given locales. When no locale is given,
+// every locale having at least one translation is reported.
+func LoadTranslationCoverage(db *gorm.DB, locales []string) (*TranslationCoverageReport, error) {
+	var items []TranslatableItem
+
+	for _, query := range []*gorm.DB{
+		db.Model(&ShowModel{}).
+			Select("? AS kind, id, id AS show_id, original_title AS show_title, 0 AS \"order\", original_language, updated_at", ShowTranslatable),
+		db.Model(&SeasonModel{}).
+			Select("? AS kind, seasons.id, seasons.show_id, shows.original_title AS show_title, seasons.\"order\", shows.original_language, seasons.updated_at", SeasonTranslatable).
+			Joins("JOIN public.shows ON shows.id = seasons.show_id"),
+		db.Model(&EpisodeModel{}).
+			Select("? AS kind, episodes.id, episodes.show_id, shows.original_title AS show_title, episodes.\"order\", shows.original_language, episodes.updated_at", EpisodeTranslatable).
+			Joins("JOIN public.shows ON shows.id = episodes.show_id"),
+	} {
+		var kindItems []TranslatableItem
+		if result := query.Order("show_title, \"order\"").Scan(&kindItems); result.Error != nil {
+			return nil, result.Error
+		}
+
+		items = append(items, kindItems...)
+	}
+
+	var translations []TranslationStamp
+
+	for _, query := range []*gorm.DB{
+		db.Model(&ShowTranslationModel{}).Select("show_id AS item_id, locale, updated_at"),
+		db.Model(&SeasonTranslationModel{}).Select("season_id AS item_id, locale, updated_at"),
+		db.Model(&EpisodeTranslationModel{}).Select("episode_id AS item_id, locale, updated_at"),
+	} {
+		var kindTranslations []TranslationStamp
+		if result := query.Scan(&kindTranslations); result.Error != nil {
+			return nil, result.Error
+		}
+
+		translations = append(translations, kindTranslations...)
+	}
+
+	if len(locales) == 0 {
+		locales = lo.Uniq(lo.Map(translations, func(translation TranslationStamp, _ int) string {
+			return translation.Locale
+		}))
+		slices.Sort(locales)
+	}
+
+	return BuildTranslationCoverage(locales, items, translations), nil
+}
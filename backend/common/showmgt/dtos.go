@@ -1,9 +1,11 @@
 package showmgt
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 type ShowDTO struct {
@@ -11,11 +13,62 @@ type ShowDTO struct {
 	Kind             string    `json:"kind"`
 	OriginalLanguage string    `json:"originalLanguage"`
 	OriginalTitle    string    `json:"originalTitle"`
+	Slug             string    `json:"slug"`
 	OriginalOverview *string   `json:"originalOverview"`
+	Tagline          *string   `json:"tagline"`
 	Keywords         []string  `json:"keywords"`
 	IsReleased       bool      `json:"isReleased"`
+	Status           *string   `json:"status"`
+	Runtime          *int      `json:"runtime"`
+	FirstAirDate     *string   `json:"firstAirDate"`
+	LastAirDate      *string   `json:"lastAirDate"`
+	ReleaseYear      *int      `json:"releaseYear"`
+	Homepage         *string   `json:"homepage"`
+	OriginCountries  []string  `json:"originCountries"`
 	CreatedAt        time.Time `json:"createdAt"`
 	UpdatedAt        time.Time `json:"updatedAt"`
+
+	// Relations expanded with the "include" query parameter (see ExpandShowDTO). They are omitted
+	// when not included, and empty when included but missing.
+	Translations *[]*TranslationDTO `json:"translations,omitempty"`
+	Seasons      *[]*SeasonDTO      `json:"seasons,omitempty"`
+	Episodes     *[]*EpisodeDTO     `json:"episodes,omitempty"`
+}
+
+// TranslationDTO is the translation of a show, a season or an episode in a locale.
+type TranslationDTO struct {
+	Locale   string  `json:"locale"`
+	Title    string  `json:"title"`
+	Overview string  `json:"overview"`
+	Slug     *string `json:"slug,omitempty"`
+}
+
+// SeasonDTO is a season of a show.
+type SeasonDTO struct {
+	ID           uuid.UUID          `json:"id"`
+	Order        int                `json:"order"`
+	Translations *[]*TranslationDTO `json:"translations,omitempty"`
+}
+
+// ToSeasonDTO converts a SeasonModel to a SeasonDTO, without its translations.
+func ToSeasonDTO(seasonModel *SeasonModel) *SeasonDTO {
+	if seasonModel == nil {
+		return nil
+	}
+
+	return &SeasonDTO{
+		ID:    seasonModel.ID,
+		Order: seasonModel.Order,
+	}
+}
+
+// formatDate formats a date column with the "2006-01-02" layout.
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+
+	return lo.ToPtr(date.Format(time.DateOnly))
 }
 
 // ToShowDTO converts a ShowModel to a ShowDTO.
@@ -24,15 +77,318 @@ func ToShowDTO(showModel *ShowModel) *ShowDTO {
 		return nil
 	}
 
+	var releaseYear *int
+	if showModel.FirstAirDate != nil {
+		releaseYear = lo.ToPtr(showModel.FirstAirDate.Year())
+	}
+
 	return &ShowDTO{
 		ID:               showModel.ID,
 		Kind:             showModel.Kind,
 		OriginalLanguage: showModel.OriginalLanguage,
 		OriginalTitle:    showModel.OriginalTitle,
+		Slug:             lo.FromPtr(showModel.Slug),
 		OriginalOverview: showModel.OriginalOverview,
+		Tagline:          showModel.Tagline,
 		Keywords:         []string(showModel.Keywords),
 		IsReleased:       showModel.IsReleased,
+		Status:           showModel.Status,
+		Runtime:          showModel.Runtime,
+		FirstAirDate:     formatDate(showModel.FirstAirDate),
+		LastAirDate:      formatDate(showModel.LastAirDate),
+		ReleaseYear:      releaseYear,
+		Homepage:         showModel.Homepage,
+		OriginCountries:  []string(showModel.OriginCountries),
 		CreatedAt:        showModel.CreatedAt,
 		UpdatedAt:        showModel.UpdatedAt,
 	}
 }
+
+type KeywordDTO struct {
+	ID       uuid.UUID         `json:"id"`
+	Name     string            `json:"name"`
+	Label    string            `json:"label"`
+	Labels   map[string]string `json:"labels"`
+	Synonyms []string          `json:"synonyms"`
+}
+
+// ToKeywordDTO converts a KeywordModel to a KeywordDTO. The label is the one which best matches
+// the given languages, or the canonical name when the keyword has no matching label.
+func ToKeywordDTO(keywordModel *KeywordModel, languages []string) *KeywordDTO {
+	if keywordModel == nil {
+		return nil
+	}
+
+	labels := lo.SliceToMap(keywordModel.Labels, func(label KeywordLabelModel) (string, string) {
+		return label.Locale, label.Label
+	})
+
+	return &KeywordDTO{
+		ID:     keywordModel.ID,
+		Name:   keywordModel.Name,
+		Label:  localizeKeyword(keywordModel, languages),
+		Labels: labels,
+		Synonyms: lo.Map(keywordModel.Synonyms, func(synonym KeywordSynonymModel, _ int) string {
+			return synonym.Synonym
+		}),
+	}
+}
+
+type ShowSuggestionDTO struct {
+	ID            uuid.UUID `json:"id"`
+	Slug          string    `json:"slug"`
+	Kind          string    `json:"kind"`
+	OriginalTitle string    `json:"originalTitle"`
+	MatchedLocale string    `json:"matchedLocale"`
+	MatchedTitle  string    `json:"matchedTitle"`
+	Score         float64   `json:"score"`
+}
+
+type VideoDTO struct {
+	ID         uuid.UUID  `json:"id"`
+	ShowID     uuid.UUID  `json:"showId"`
+	EpisodeID  *uuid.UUID `json:"episodeId"`
+	Site       string     `json:"site"`
+	Key        string     `json:"key"`
+	URL        string     `json:"url"`
+	Name       string     `json:"name"`
+	Language   string     `json:"language"`
+	Type       string     `json:"type"`
+	IsOfficial bool       `json:"isOfficial"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// ToVideoDTO converts a VideoModel to a VideoDTO.
+func ToVideoDTO(videoModel *VideoModel) *VideoDTO {
+	if videoModel == nil {
+		return nil
+	}
+
+	return &VideoDTO{
+		ID:         videoModel.ID,
+		ShowID:     videoModel.ShowID,
+		EpisodeID:  videoModel.EpisodeID,
+		Site:       videoModel.Site,
+		Key:        videoModel.Key,
+		URL:        VideoURL(videoModel),
+		Name:       videoModel.Name,
+		Language:   videoModel.Language,
+		Type:       videoModel.Type,
+		IsOfficial: videoModel.IsOfficial,
+		CreatedAt:  videoModel.CreatedAt,
+		UpdatedAt:  videoModel.UpdatedAt,
+	}
+}
+
+// ToVideoDTOs converts the videos to DTOs, sorted by preference for the given languages.
+func ToVideoDTOs(videoModels []VideoModel, languages []string) []*VideoDTO {
+	SortVideos(videoModels, languages)
+
+	return lo.Map(videoModels, func(videoModel VideoModel, _ int) *VideoDTO {
+		return ToVideoDTO(&videoModel)
+	})
+}
+
+// ShowDetailDTO is the show returned by the show detail endpoint, with its videos and related shows.
+type ShowDetailDTO struct {
+	*ShowDTO
+
+	Videos  []*VideoDTO       `json:"videos"`
+	Related []*RelatedShowDTO `json:"related"`
+}
+
+// RelatedShowDTO is a show related to another show. The type is the relation seen from the other
+// show, e.g. "prequel_of" when the other show is the prequel of this one.
+type RelatedShowDTO struct {
+	RelationID uuid.UUID `json:"relationId"`
+	Type       string    `json:"type"`
+	Show       *ShowDTO  `json:"show"`
+}
+
+// ToRelatedShowDTOs converts related shows to RelatedShowDTOs.
+func ToRelatedShowDTOs(relatedShows []RelatedShow) []*RelatedShowDTO {
+	return lo.Map(relatedShows, func(relatedShow RelatedShow, _ int) *RelatedShowDTO {
+		return &RelatedShowDTO{
+			RelationID: relatedShow.RelationID,
+			Type:       relatedShow.Type,
+			Show:       ToShowDTO(relatedShow.Show),
+		}
+	})
+}
+
+// FranchiseShowDTO is a show of a franchise graph, with the number of relations between it and
+// the show the graph was built from.
+type FranchiseShowDTO struct {
+	*ShowDTO
+
+	Depth int `json:"depth"`
+}
+
+// FranchiseEdgeDTO is a relation of a franchise graph, read as "<show> <type> <related show>".
+type FranchiseEdgeDTO struct {
+	RelationID    uuid.UUID `json:"relationId"`
+	ShowID        uuid.UUID `json:"showId"`
+	RelatedShowID uuid.UUID `json:"relatedShowId"`
+	Type          string    `json:"type"`
+}
+
+// FranchiseGraphDTO is the graph of the shows connected to a show.
+type FranchiseGraphDTO struct {
+	Shows []*FranchiseShowDTO `json:"shows"`
+	Edges []*FranchiseEdgeDTO `json:"edges"`
+}
+
+// ToFranchiseGraphDTO converts a FranchiseGraph to a FranchiseGraphDTO.
+func ToFranchiseGraphDTO(graph *FranchiseGraph) *FranchiseGraphDTO {
+	return &FranchiseGraphDTO{
+		Shows: lo.Map(graph.Shows, func(showModel ShowModel, _ int) *FranchiseShowDTO {
+			return &FranchiseShowDTO{ShowDTO: ToShowDTO(&showModel), Depth: graph.Depths[showModel.ID]}
+		}),
+		Edges: lo.Map(graph.Edges, func(edge FranchiseEdge, _ int) *FranchiseEdgeDTO {
+			return &FranchiseEdgeDTO{
+				RelationID:    edge.RelationID,
+				ShowID:        edge.ShowID,
+				RelatedShowID: edge.RelatedShowID,
+				Type:          edge.Type,
+			}
+		}),
+	}
+}
+
+// ShowBatchJobDTO is a batch job with its progress.
+type ShowBatchJobDTO struct {
+	ID              uuid.UUID  `json:"id"`
+	Operation       string     `json:"operation"`
+	Keyword         *string    `json:"keyword,omitempty"`
+	IsReleased      *bool      `json:"isReleased,omitempty"`
+	Status          string     `json:"status"`
+	Total           int        `json:"total"`
+	Processed       int        `json:"processed"`
+	Succeeded       int        `json:"succeeded"`
+	Skipped         int        `json:"skipped"`
+	Failed          int        `json:"failed"`
+	Progress        float64    `json:"progress"`
+	CancelRequested bool       `json:"cancelRequested"`
+	CreatedBy       string     `json:"createdBy"`
+	StartedAt       *time.Time `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt"`
+}
+
+// ToShowBatchJobDTO converts a ShowBatchJobModel to a ShowBatchJobDTO. The progress is the ratio
+// of processed shows, between 0 and 1.
+func ToShowBatchJobDTO(jobModel *ShowBatchJobModel) *ShowBatchJobDTO {
+	if jobModel == nil {
+		return nil
+	}
+
+	progress := 1.0
+	if jobModel.Total > 0 {
+		progress = float64(jobModel.Processed) / float64(jobModel.Total)
+	}
+
+	return &ShowBatchJobDTO{
+		ID:              jobModel.ID,
+		Operation:       jobModel.Operation,
+		Keyword:         jobModel.Keyword,
+		IsReleased:      jobModel.IsReleased,
+		Status:          jobModel.Status,
+		Total:           jobModel.Total,
+		Processed:       jobModel.Processed,
+		Succeeded:       jobModel.Succeeded,
+		Skipped:         jobModel.Skipped,
+		Failed:          jobModel.Failed,
+		Progress:        progress,
+		CancelRequested: jobModel.CancelRequested,
+		CreatedBy:       jobModel.CreatedBy,
+		StartedAt:       jobModel.StartedAt,
+		FinishedAt:      jobModel.FinishedAt,
+	}
+}
+
+// ShowBatchJobItemDTO is the result of a batch job on a show.
+type ShowBatchJobItemDTO struct {
+	ShowID      uuid.UUID  `json:"showId"`
+	Status      string     `json:"status"`
+	Error       *string    `json:"error"`
+	ProcessedAt *time.Time `json:"processedAt"`
+}
+
+// ToShowBatchJobItemDTO converts a ShowBatchJobItemModel to a ShowBatchJobItemDTO.
+func ToShowBatchJobItemDTO(itemModel *ShowBatchJobItemModel) *ShowBatchJobItemDTO {
+	if itemModel == nil {
+		return nil
+	}
+
+	return &ShowBatchJobItemDTO{
+		ShowID:      itemModel.ShowID,
+		Status:      itemModel.Status,
+		Error:       itemModel.Error,
+		ProcessedAt: itemModel.ProcessedAt,
+	}
+}
+
+type SubtitleDTO struct {
+	ID        uuid.UUID  `json:"id"`
+	ShowID    uuid.UUID  `json:"showId"`
+	EpisodeID *uuid.UUID `json:"episodeId"`
+	Language  string     `json:"language"`
+	CueCount  int        `json:"cueCount"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// ToSubtitleDTO converts a SubtitleModel to a SubtitleDTO, without its cues.
+func ToSubtitleDTO(subtitleModel *SubtitleModel) *SubtitleDTO {
+	if subtitleModel == nil {
+		return nil
+	}
+
+	return &SubtitleDTO{
+		ID:        subtitleModel.ID,
+		ShowID:    subtitleModel.ShowID,
+		EpisodeID: subtitleModel.EpisodeID,
+		Language:  subtitleModel.Language,
+		CueCount:  subtitleModel.CueCount,
+		CreatedAt: subtitleModel.CreatedAt,
+		UpdatedAt: subtitleModel.UpdatedAt,
+	}
+}
+
+type EpisodeDTO struct {
+	ID                uuid.UUID `json:"id"`
+	ShowID            uuid.UUID `json:"showId"`
+	Order             int       `json:"order"`
+	Title             string    `json:"title"`
+	Overview          string    `json:"overview"`
+	SubtitleLanguages []string  `json:"subtitleLanguages"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+
+	// Expanded with the "include" query parameter of the show endpoints.
+	Translations *[]*TranslationDTO `json:"translations,omitempty"`
+}
+
+// ToEpisodeDTO converts an EpisodeModel to an EpisodeDTO. The subtitle languages are the
+// languages of the loaded subtitle tracks of the episode, in alphabetical order.
+func ToEpisodeDTO(episodeModel *EpisodeModel) *EpisodeDTO {
+	if episodeModel == nil {
+		return nil
+	}
+
+	subtitleLanguages := lo.Map(episodeModel.Subtitles, func(subtitle SubtitleModel, _ int) string {
+		return subtitle.Language
+	})
+	slices.Sort(subtitleLanguages)
+
+	return &EpisodeDTO{
+		ID:                episodeModel.ID,
+		ShowID:            episodeModel.ShowID,
+		Order:             episodeModel.Order,
+		Title:             episodeModel.Title,
+		Overview:          episodeModel.Overview,
+		SubtitleLanguages: subtitleLanguages,
+		CreatedAt:         episodeModel.CreatedAt,
+		UpdatedAt:         episodeModel.UpdatedAt,
+	}
+}
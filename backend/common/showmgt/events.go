@@ -0,0 +1,164 @@
+package showmgt
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Types of the catalog events.
+const (
+	// NewEpisodeCatalogEvent is published when the last air date of a show moves forward and has
+	// been reached, i.e. when a new episode has aired. A last air date in the future is published by
+	// the aired episodes job once it is reached.
+	NewEpisodeCatalogEvent = "new_episode"
+
+	// ShowReleasedCatalogEvent is published when a show becomes released.
+	ShowReleasedCatalogEvent = "show_released"
+
+	// ShowChangedCatalogEvent is published whenever a show is created or updated. Users are not
+	// notified of it; it keeps derived data, such as the search index, up to date.
+	ShowChangedCatalogEvent = "show_changed"
+)
+
+// CatalogEventTypes lists the types of the catalog events users can be notified of, in the order
+// they are displayed.
+var CatalogEventTypes = []string{NewEpisodeCatalogEvent, ShowReleasedCatalogEvent}
+
+// CatalogEvent is a change of the catalog which users following the show may be interested in.
+type CatalogEvent struct {
+	Type       string
+	ShowID     uuid.UUID
+	OccurredAt time.Time
+
+	// The new last air date of the show, for NewEpisodeCatalogEvent.
+	AirDate *time.Time
+}
+
+// CatalogEventListener is notified of the catalog events by the catalog events job, after the
+// changes which published them have been committed.
+type CatalogEventListener interface {
+	// Name returns a human-readable name of the listener. It is used for logging purposes.
+	Name() string
+
+	// OnCatalogEvents handles the given events. A failure is logged and does not affect the change
+	// which published the events.
+	OnCatalogEvents(ctx context.Context, events []CatalogEvent) error
+}
+
+// AsCatalogEventListener annotates the given constructor so that its result is registered as a
+// CatalogEventListener.
+func AsCatalogEventListener(function any) any {
+	return fx.Annotate(
+		function,
+		fx.As(new(CatalogEventListener)),
+		fx.ResultTags(`group:"catalog_event_listeners"`),
+	)
+}
+
+// CatalogEvents dispatches the catalog events to every registered listener.
+type CatalogEvents struct {
+	logger    *slog.Logger
+	listeners []CatalogEventListener
+}
+
+type CatalogEventsParams struct {
+	fx.In
+
+	Logger    *slog.Logger
+	Listeners []CatalogEventListener `group:"catalog_event_listeners"`
+}
+
+func NewCatalogEvents(p CatalogEventsParams) *CatalogEvents {
+	return &CatalogEvents{
+		logger:    p.Logger,
+		listeners: p.Listeners,
+	}
+}
+
+// Dispatch delivers the events to the listeners, one after the other. It is called by the catalog
+// events job. It does nothing when the dispatcher is nil, e.g. in tests.
+func (e *CatalogEvents) Dispatch(ctx context.Context, events ...CatalogEvent) {
+	if e == nil || len(events) == 0 {
+		return
+	}
+
+	for _, listener := range e.listeners {
+		if err := listener.OnCatalogEvents(ctx, events); err != nil {
+			e.logger.ErrorContext(ctx, "The catalog event listener failed",
+				slog.String("listener", listener.Name()),
+				core.DetailsLogAttr(err))
+		}
+	}
+}
+
+// PublishCatalogEvents stores the events with the transaction of the change which publishes them.
+// They are delivered to the listeners by the catalog events job once the change is committed, and
+// are never delivered when it is rolled back.
+func PublishCatalogEvents(tx *gorm.DB, events ...CatalogEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventModels := lo.Map(events, func(event CatalogEvent, _ int) CatalogEventModel {
+		return CatalogEventModel{
+			Type:       event.Type,
+			ShowID:     event.ShowID,
+			OccurredAt: event.OccurredAt,
+			AirDate:    event.AirDate,
+		}
+	})
+
+	return tx.Create(&eventModels).Error
+}
+
+// ToCatalogEvent converts a CatalogEventModel to a CatalogEvent.
+func ToCatalogEvent(eventModel *CatalogEventModel) CatalogEvent {
+	return CatalogEvent{
+		Type:       eventModel.Type,
+		ShowID:     eventModel.ShowID,
+		OccurredAt: eventModel.OccurredAt,
+		AirDate:    eventModel.AirDate,
+	}
+}
+
+// DetectCatalogEvents compares the show before and after a change, and returns the catalog events
+// of the change. The announced air date of the show after the change is updated (see
+// AnnounceAiredEpisode).
+func DetectCatalogEvents(before *ShowModel, after *ShowModel, now time.Time) []CatalogEvent {
+	events := []CatalogEvent{}
+
+	if !before.IsReleased && after.IsReleased {
+		events = append(events, CatalogEvent{Type: ShowReleasedCatalogEvent, ShowID: after.ID, OccurredAt: now})
+	}
+
+	if event, ok := AnnounceAiredEpisode(after, now); ok {
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// AnnounceAiredEpisode returns the NewEpisodeCatalogEvent of the last air date of the show when it
+// has been reached and is later than the last announced one, and records it as announced.
+func AnnounceAiredEpisode(show *ShowModel, now time.Time) (CatalogEvent, bool) {
+	if show.LastAirDate == nil || show.LastAirDate.After(now) ||
+		(show.AnnouncedAirDate != nil && !show.LastAirDate.After(*show.AnnouncedAirDate)) {
+		return CatalogEvent{}, false
+	}
+
+	show.AnnouncedAirDate = show.LastAirDate
+
+	return CatalogEvent{
+		Type:       NewEpisodeCatalogEvent,
+		ShowID:     show.ID,
+		OccurredAt: now,
+		AirDate:    show.LastAirDate,
+	}, true
+}
@@ -0,0 +1,442 @@
+package showmgt
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// ExportFormat is the metadata format shows, seasons and episodes are exported in, so that media
+// centers can use the catalog as a metadata source.
+type ExportFormat string
+
+const (
+	// NFOExportFormat renders the entities as Kodi NFO documents.
+	NFOExportFormat ExportFormat = "nfo"
+
+	// JSONLDExportFormat renders the entities as schema.org JSON-LD documents.
+	JSONLDExportFormat ExportFormat = "jsonld"
+)
+
+// exportIDType is the type of the unique ID of the exported entities, which is their ID in the catalog.
+const exportIDType = "wano-island"
+
+// ParseExportFormat returns the export format of the given name.
+func ParseExportFormat(value string) (ExportFormat, bool) {
+	format := ExportFormat(value)
+
+	return format, format == NFOExportFormat || format == JSONLDExportFormat
+}
+
+// ContentType returns the media type of the documents rendered in the format.
+func (f ExportFormat) ContentType() string {
+	if f == JSONLDExportFormat {
+		return "application/ld+json; charset=utf-8"
+	}
+
+	return "application/xml; charset=utf-8"
+}
+
+// CatalogExporter renders shows, seasons and episodes in a format, using their translations
+// which best match the given languages. The links of the documents point to the API at BaseURL.
+type CatalogExporter struct {
+	Format    ExportFormat
+	Languages []string
+	BaseURL   string
+}
+
+// localizedText is a title and an overview in a locale.
+type localizedText struct {
+	Locale   string
+	Title    string
+	Overview string
+}
+
+// localizeText returns the text in the locale which best matches the given languages, checking
+// them in order and preferring an exact locale match over a match on the base language. The
+// fallback is returned when no locale matches.
+func localizeText(texts []localizedText, languages []string, fallback localizedText) localizedText {
+	for _, lang := range languages {
+		if text, ok := lo.Find(texts, func(text localizedText) bool {
+			return strings.EqualFold(text.Locale, lang)
+		}); ok {
+			return text
+		}
+
+		if text, ok := lo.Find(texts, func(text localizedText) bool {
+			return baseLanguage(text.Locale) == baseLanguage(lang)
+		}); ok {
+			return text
+		}
+	}
+
+	return fallback
+}
+
+// localizeSeason returns the title and overview of the season. Seasons without a matching
+// translation are named after their order.
+func (e *CatalogExporter) localizeSeason(season *SeasonModel) localizedText {
+	return localizeText(lo.Map(season.Translations, func(translation SeasonTranslationModel, _ int) localizedText {
+		return localizedText{Locale: translation.Locale, Title: translation.Title, Overview: translation.Overview}
+	}), e.Languages, localizedText{Title: fmt.Sprintf("Season %d", season.Order)})
+}
+
+// localizeEpisode returns the title and overview of the episode, or the original ones when no
+// translation matches.
+func (e *CatalogExporter) localizeEpisode(show *ShowModel, episode *EpisodeModel) localizedText {
+	return localizeText(lo.Map(episode.Translations, func(translation EpisodeTranslationModel, _ int) localizedText {
+		return localizedText{Locale: translation.Locale, Title: translation.Title, Overview: translation.Overview}
+	}), e.Languages, localizedText{Locale: show.OriginalLanguage, Title: episode.Title, Overview: episode.Overview})
+}
+
+// showURL returns the API URL of the show.
+func (e *CatalogExporter) showURL(show *ShowModel) string {
+	return e.BaseURL + "/api/v1/shows/" + show.ID.String()
+}
+
+// trailer returns the show trailer which best matches the languages, if any.
+func (e *CatalogExporter) trailer(show *ShowModel) *VideoModel {
+	trailers := lo.Filter(show.Videos, func(video VideoModel, _ int) bool {
+		return video.EpisodeID == nil && video.Type == TrailerVideoType
+	})
+	SortVideos(trailers, e.Languages)
+
+	if len(trailers) == 0 {
+		return nil
+	}
+
+	return &trailers[0]
+}
+
+// ExportShow renders the show, whose translations, seasons with their translations, and videos
+// must be loaded. Movies are rendered as movies, other kinds as TV series.
+func (e *CatalogExporter) ExportShow(show *ShowModel, episodeCount int) ([]byte, error) {
+	if e.Format == NFOExportFormat {
+		return e.showNFO(show).render()
+	}
+
+	return renderJSONLD(e.showJSONLD(show, episodeCount))
+}
+
+// ExportSeason renders the season of the show. The translations of both must be loaded.
+func (e *CatalogExporter) ExportSeason(show *ShowModel, season *SeasonModel) ([]byte, error) {
+	localizedSeason := e.localizeSeason(season)
+
+	if e.Format == NFOExportFormat {
+		return (&nfoDocument{
+			XMLName:      xml.Name{Local: "season"},
+			Title:        localizedSeason.Title,
+			ShowTitle:    LocalizeShow(show, e.Languages).Title,
+			SeasonNumber: lo.ToPtr(season.Order),
+			Plot:         localizedSeason.Overview,
+			UniqueIDs:    []nfoUniqueID{{Type: exportIDType, Default: true, Value: season.ID.String()}},
+		}).render()
+	}
+
+	document := e.seasonJSONLD(show, season)
+	document.Context = "https://schema.org"
+	document.PartOfSeries = e.seriesReference(show)
+
+	return renderJSONLD(document)
+}
+
+// ExportEpisode renders the episode of the show. The translations of both must be loaded.
+func (e *CatalogExporter) ExportEpisode(show *ShowModel, episode *EpisodeModel) ([]byte, error) {
+	localizedEpisode := e.localizeEpisode(show, episode)
+
+	if e.Format == NFOExportFormat {
+		return (&nfoDocument{
+			XMLName:       xml.Name{Local: "episodedetails"},
+			Title:         localizedEpisode.Title,
+			ShowTitle:     LocalizeShow(show, e.Languages).Title,
+			EpisodeNumber: lo.ToPtr(episode.Order),
+			Plot:          localizedEpisode.Overview,
+			UniqueIDs:     []nfoUniqueID{{Type: exportIDType, Default: true, Value: episode.ID.String()}},
+		}).render()
+	}
+
+	return renderJSONLD(&jsonLDThing{
+		Context:       "https://schema.org",
+		Type:          "TVEpisode",
+		Name:          localizedEpisode.Title,
+		Description:   localizedEpisode.Overview,
+		InLanguage:    localizedEpisode.Locale,
+		EpisodeNumber: lo.ToPtr(episode.Order),
+		Identifier:    &jsonLDIdentifier{Type: "PropertyValue", PropertyID: exportIDType, Value: episode.ID.String()},
+		PartOfSeries:  e.seriesReference(show),
+	})
+}
+
+// WriteArchive adds the documents of the show, its seasons and the given episodes to the zip
+// archive, in a folder named after the slug or the ID of the show. NFO documents follow the Kodi
+// naming: "tvshow.nfo" or "movie.nfo", and "Season NN/season.nfo".
+func (e *CatalogExporter) WriteArchive(archive *zip.Writer, show *ShowModel, episodes []EpisodeModel) error {
+	folder := lo.FromPtrOr(show.Slug, show.ID.String()) + "/"
+	extension := "." + string(e.Format)
+
+	showFile := "show" + extension
+	seasonFile := "season-%02d" + extension
+	if e.Format == NFOExportFormat {
+		showFile = lo.Ternary(show.Kind == "movie", "movie.nfo", "tvshow.nfo")
+		seasonFile = "Season %02d/season.nfo"
+	}
+
+	body, err := e.ExportShow(show, len(episodes))
+	if err != nil {
+		return err
+	}
+
+	files := []lo.Tuple2[string, []byte]{lo.T2(folder+showFile, body)}
+
+	for i := range show.Seasons {
+		if body, err = e.ExportSeason(show, &show.Seasons[i]); err != nil {
+			return err
+		}
+
+		files = append(files, lo.T2(folder+fmt.Sprintf(seasonFile, show.Seasons[i].Order), body))
+	}
+
+	for i := range episodes {
+		if body, err = e.ExportEpisode(show, &episodes[i]); err != nil {
+			return err
+		}
+
+		files = append(files, lo.T2(folder+fmt.Sprintf("episode-%04d", episodes[i].Order)+extension, body))
+	}
+
+	for _, file := range files {
+		writer, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.A,
+			Method:   zip.Deflate,
+			Modified: exportFileTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err = writer.Write(file.B); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nfoUniqueID is an ID of the entity in a metadata source.
+type nfoUniqueID struct {
+	Type    string `xml:"type,attr"`
+	Default bool   `xml:"default,attr"`
+	Value   string `xml:",chardata"`
+}
+
+// nfoDocument is a Kodi NFO document. Its root element is "movie", "tvshow", "season" or
+// "episodedetails" and only the fields of that kind of entity are set.
+type nfoDocument struct {
+	XMLName       xml.Name
+	Title         string        `xml:"title"`
+	OriginalTitle string        `xml:"originaltitle,omitempty"`
+	ShowTitle     string        `xml:"showtitle,omitempty"`
+	SeasonNumber  *int          `xml:"seasonnumber,omitempty"`
+	EpisodeNumber *int          `xml:"episode,omitempty"`
+	Plot          string        `xml:"plot,omitempty"`
+	Tagline       string        `xml:"tagline,omitempty"`
+	Runtime       *int          `xml:"runtime,omitempty"`
+	Premiered     string        `xml:"premiered,omitempty"`
+	Year          *int          `xml:"year,omitempty"`
+	Status        string        `xml:"status,omitempty"`
+	Countries     []string      `xml:"country"`
+	Tags          []string      `xml:"tag"`
+	Trailer       string        `xml:"trailer,omitempty"`
+	UniqueIDs     []nfoUniqueID `xml:"uniqueid"`
+}
+
+// render encodes the document as a standalone XML document, as expected by Kodi.
+func (d *nfoDocument) render() ([]byte, error) {
+	body, err := xml.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+"\n"), body...), nil
+}
+
+// nfoStatuses maps the production statuses to the statuses Kodi displays for TV shows.
+var nfoStatuses = map[string]string{
+	RumoredShowStatus:      "Continuing",
+	InProductionShowStatus: "Continuing",
+	ReturningShowStatus:    "Continuing",
+	EndedShowStatus:        "Ended",
+}
+
+func (e *CatalogExporter) showNFO(show *ShowModel) *nfoDocument {
+	localizedShow := LocalizeShow(show, e.Languages)
+	isMovie := show.Kind == "movie"
+
+	document := &nfoDocument{
+		XMLName:       xml.Name{Local: lo.Ternary(isMovie, "movie", "tvshow")},
+		Title:         localizedShow.Title,
+		OriginalTitle: show.OriginalTitle,
+		Plot:          lo.FromPtr(localizedShow.Overview),
+		Tagline:       lo.FromPtr(show.Tagline),
+		Runtime:       show.Runtime,
+		Premiered:     lo.FromPtr(formatDate(show.FirstAirDate)),
+		Countries:     show.OriginCountries,
+		Tags:          show.Keywords,
+		UniqueIDs:     []nfoUniqueID{{Type: exportIDType, Default: true, Value: show.ID.String()}},
+	}
+
+	if show.FirstAirDate != nil {
+		document.Year = lo.ToPtr(show.FirstAirDate.Year())
+	}
+
+	if !isMovie && show.Status != nil {
+		document.Status = nfoStatuses[*show.Status]
+	}
+
+	// Kodi plays YouTube trailers through its YouTube add-on.
+	if trailer := e.trailer(show); trailer != nil {
+		document.Trailer = lo.Ternary(trailer.Site == YouTubeVideoSite,
+			"plugin://plugin.video.youtube/play/?video_id="+trailer.Key, VideoURL(trailer))
+	}
+
+	return document
+}
+
+// jsonLDIdentifier is an ID of the entity in a metadata source.
+type jsonLDIdentifier struct {
+	Type       string `json:"@type"`
+	PropertyID string `json:"propertyID"`
+	Value      string `json:"value"`
+}
+
+// jsonLDCountry is a country of origin, identified by its ISO 3166-1 code.
+type jsonLDCountry struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// jsonLDVideo is a trailer.
+type jsonLDVideo struct {
+	Type       string `json:"@type"`
+	Name       string `json:"name,omitempty"`
+	EmbedURL   string `json:"embedUrl"`
+	InLanguage string `json:"inLanguage"`
+}
+
+// jsonLDThing is a schema.org Movie, TVSeries, TVSeason or TVEpisode. Only the properties of
+// its type are set.
+type jsonLDThing struct {
+	Context          string            `json:"@context,omitempty"`
+	Type             string            `json:"@type"`
+	ID               string            `json:"@id,omitempty"`
+	URL              string            `json:"url,omitempty"`
+	Name             string            `json:"name"`
+	AlternateName    string            `json:"alternateName,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	InLanguage       string            `json:"inLanguage,omitempty"`
+	SeasonNumber     *int              `json:"seasonNumber,omitempty"`
+	EpisodeNumber    *int              `json:"episodeNumber,omitempty"`
+	NumberOfSeasons  *int              `json:"numberOfSeasons,omitempty"`
+	NumberOfEpisodes *int              `json:"numberOfEpisodes,omitempty"`
+	DatePublished    string            `json:"datePublished,omitempty"`
+	StartDate        string            `json:"startDate,omitempty"`
+	EndDate          string            `json:"endDate,omitempty"`
+	Duration         string            `json:"duration,omitempty"`
+	CountryOfOrigin  []jsonLDCountry   `json:"countryOfOrigin,omitempty"`
+	Keywords         string            `json:"keywords,omitempty"`
+	Identifier       *jsonLDIdentifier `json:"identifier,omitempty"`
+	Trailer          *jsonLDVideo      `json:"trailer,omitempty"`
+	PartOfSeries     *jsonLDThing      `json:"partOfSeries,omitempty"`
+	ContainsSeason   []jsonLDThing     `json:"containsSeason,omitempty"`
+}
+
+// renderJSONLD encodes the document as indented JSON.
+func renderJSONLD(document *jsonLDThing) ([]byte, error) {
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func (e *CatalogExporter) showJSONLD(show *ShowModel, episodeCount int) *jsonLDThing {
+	localizedShow := LocalizeShow(show, e.Languages)
+
+	document := &jsonLDThing{
+		Context:       "https://schema.org",
+		Type:          "TVSeries",
+		ID:            e.showURL(show),
+		URL:           e.showURL(show),
+		Name:          localizedShow.Title,
+		AlternateName: lo.Ternary(localizedShow.Title == show.OriginalTitle, "", show.OriginalTitle),
+		Description:   lo.FromPtr(localizedShow.Overview),
+		InLanguage:    localizedShow.Locale,
+		Keywords:      strings.Join(show.Keywords, ", "),
+		Identifier:    &jsonLDIdentifier{Type: "PropertyValue", PropertyID: exportIDType, Value: show.ID.String()},
+		CountryOfOrigin: lo.Map(show.OriginCountries, func(country string, _ int) jsonLDCountry {
+			return jsonLDCountry{Type: "Country", Name: country}
+		}),
+	}
+
+	if trailer := e.trailer(show); trailer != nil {
+		document.Trailer = &jsonLDVideo{
+			Type:       "VideoObject",
+			Name:       trailer.Name,
+			EmbedURL:   VideoURL(trailer),
+			InLanguage: trailer.Language,
+		}
+	}
+
+	if show.Kind == "movie" {
+		document.Type = "Movie"
+		document.DatePublished = lo.FromPtr(formatDate(show.FirstAirDate))
+
+		if show.Runtime != nil {
+			document.Duration = fmt.Sprintf("PT%dM", *show.Runtime)
+		}
+
+		return document
+	}
+
+	document.StartDate = lo.FromPtr(formatDate(show.FirstAirDate))
+	document.EndDate = lo.FromPtr(formatDate(show.LastAirDate))
+	document.NumberOfSeasons = lo.ToPtr(len(show.Seasons))
+	document.NumberOfEpisodes = lo.ToPtr(episodeCount)
+
+	seasons := slices.Clone(show.Seasons)
+	slices.SortStableFunc(seasons, func(a, b SeasonModel) int { return a.Order - b.Order })
+
+	for i := range seasons {
+		document.ContainsSeason = append(document.ContainsSeason, *e.seasonJSONLD(show, &seasons[i]))
+	}
+
+	return document
+}
+
+func (e *CatalogExporter) seasonJSONLD(show *ShowModel, season *SeasonModel) *jsonLDThing {
+	localizedSeason := e.localizeSeason(season)
+
+	return &jsonLDThing{
+		Type:         "TVSeason",
+		Name:         localizedSeason.Title,
+		Description:  localizedSeason.Overview,
+		InLanguage:   localizedSeason.Locale,
+		SeasonNumber: lo.ToPtr(season.Order),
+		Identifier:   &jsonLDIdentifier{Type: "PropertyValue", PropertyID: exportIDType, Value: season.ID.String()},
+	}
+}
+
+// seriesReference returns the reference to the show of a season or an episode.
+func (e *CatalogExporter) seriesReference(show *ShowModel) *jsonLDThing {
+	return &jsonLDThing{
+		Type: "TVSeries",
+		ID:   e.showURL(show),
+		Name: LocalizeShow(show, e.Languages).Title,
+	}
+}
+
+// exportFileTime is the modification time of the files of the archives, so that exporting the
+// same catalog twice gives the same archive.
+var exportFileTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -0,0 +1,153 @@
+package showmgt
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"wano-island/common/core"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// ShowFacet is the name of a facet which can be requested with the "facets" query parameter.
+type ShowFacet string
+
+const (
+	KindFacet     ShowFacet = "kind"
+	LanguageFacet ShowFacet = "language"
+	KeywordFacet  ShowFacet = "keyword"
+	ReleasedFacet ShowFacet = "released"
+	DecadeFacet   ShowFacet = "decade"
+)
+
+// maxFacetBuckets is the maximum number of buckets returned for a facet, the largest counts first.
+// It keeps facets with many distinct values (languages, keywords) cheap to transfer and render.
+const maxFacetBuckets = 20
+
+// ShowListResponse is the response of the show list. The facet buckets are returned next to the
+// data, which stays a list of shows whether facets are requested or not.
+type ShowListResponse struct {
+	*core.Response[any]
+	Facets map[ShowFacet][]FacetBucket `json:"facets,omitempty"`
+}
+
+// FacetBucket is a value of a facet with the number of shows having it.
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// ShowFilters holds the filters of the show list, read from the query parameters.
+type ShowFilters struct {
+	Kind       string
+	Language   string
+	Keyword    string
+	IsReleased *bool
+
+	// Decade is the first year of the decade of the first air date, e.g. 1990 for the 1990s.
+	Decade *int
+}
+
+// GetShowFilters reads the "kind", "language", "keyword", "released" and "decade" query parameters.
+// Invalid values of "released" and "decade" are ignored, and a year of "decade" is truncated to its decade.
+func GetShowFilters(r *http.Request) ShowFilters {
+	query := r.URL.Query()
+	filters := ShowFilters{
+		Kind:     query.Get("kind"),
+		Language: query.Get("language"),
+		Keyword:  NormalizeKeyword(query.Get("keyword")),
+	}
+
+	if isReleased, err := strconv.ParseBool(query.Get("released")); err == nil {
+		filters.IsReleased = &isReleased
+	}
+
+	if year, err := strconv.Atoi(query.Get("decade")); err == nil {
+		decade := year - year%10
+		filters.Decade = &decade
+	}
+
+	return filters
+}
+
+// GetShowFacets reads the facets requested with the "facets" query parameter
+// (e.g. "?facets=kind,language"). Unknown facets are ignored.
+func GetShowFacets(r *http.Request) []ShowFacet {
+	supportedFacets := []ShowFacet{KindFacet, LanguageFacet, KeywordFacet, ReleasedFacet, DecadeFacet}
+
+	return lo.Uniq(lo.FilterMap(strings.Split(r.URL.Query().Get("facets"), ","), func(name string, _ int) (ShowFacet, bool) {
+		facet := ShowFacet(strings.TrimSpace(name))
+
+		return facet, lo.Contains(supportedFacets, facet)
+	}))
+}
+
+// Apply adds the filters to the query, except the filter of the given facet, so that the counts
+// of a facet show what the user would get by changing its own filter.
+func (f ShowFilters) Apply(db *gorm.DB, except ShowFacet) *gorm.DB {
+	if f.Kind != "" && except != KindFacet {
+		db = db.Where("kind = ?", f.Kind)
+	}
+
+	if f.Language != "" && except != LanguageFacet {
+		db = db.Where("original_language = ?", f.Language)
+	}
+
+	if f.Keyword != "" && except != KeywordFacet {
+		db = db.Where("? = ANY(keywords)", f.Keyword)
+	}
+
+	if f.IsReleased != nil && except != ReleasedFacet {
+		db = db.Where("is_released = ?", *f.IsReleased)
+	}
+
+	if f.Decade != nil && except != DecadeFacet {
+		db = db.Where("first_air_date >= make_date(?, 1, 1) AND first_air_date < make_date(?, 1, 1)", *f.Decade, *f.Decade+10)
+	}
+
+	return db
+}
+
+// ComputeShowFacets counts the shows matching the filters for each value of the given facets.
+// It runs one grouped query per facet, bounded to maxFacetBuckets buckets.
+// Shows without a first air date are not counted in the decade facet.
+func ComputeShowFacets(db *gorm.DB, filters ShowFilters, facets []ShowFacet) (map[ShowFacet][]FacetBucket, error) {
+	results := make(map[ShowFacet][]FacetBucket, len(facets))
+
+	for _, facet := range facets {
+		var valueColumn string
+
+		values := filters.Apply(db.Model(&ShowModel{}), facet)
+
+		switch facet {
+		case KindFacet:
+			valueColumn = "kind"
+		case LanguageFacet:
+			valueColumn = "original_language"
+		case KeywordFacet:
+			valueColumn = "unnest(keywords)"
+		case ReleasedFacet:
+			valueColumn = "CASE WHEN is_released THEN 'released' ELSE 'unreleased' END"
+		case DecadeFacet:
+			valueColumn = "to_char(date_trunc('decade', first_air_date), 'YYYY')"
+			values = values.Where("first_air_date IS NOT NULL")
+		}
+
+		values = values.Select(valueColumn + " AS value")
+
+		buckets := []FacetBucket{}
+		if result := db.Table("(?) AS facet_values", values).
+			Select("value, COUNT(*) AS count").
+			Group("value").
+			Order("count DESC, value").
+			Limit(maxFacetBuckets).
+			Scan(&buckets); result.Error != nil {
+			return nil, result.Error
+		}
+
+		results[facet] = buckets
+	}
+
+	return results, nil
+}
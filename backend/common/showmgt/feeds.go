@@ -0,0 +1,190 @@
+package showmgt
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// FeedFormat is the syndication format of a shows feed.
+type FeedFormat string
+
+const (
+	// AtomFeedFormat renders the feed as an Atom 1.0 document.
+	AtomFeedFormat FeedFormat = "atom"
+
+	// RSSFeedFormat renders the feed as an RSS 2.0 document.
+	RSSFeedFormat FeedFormat = "rss"
+)
+
+// ShowsFeed is a format-agnostic representation of a feed of shows.
+type ShowsFeed struct {
+	Title       string
+	Description string
+	Author      string
+	SelfURL     string
+	Language    string
+	UpdatedAt   time.Time
+	Items       []ShowsFeedItem
+}
+
+// ShowsFeedItem is a single show in a feed.
+type ShowsFeedItem struct {
+	ID          string
+	Title       string
+	Summary     *string
+	URL         string
+	Categories  []string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomAuthor is the author of the feed, which Atom requires on the feed or on every entry.
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Summary    *string        `xml:"summary,omitempty"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description *string  `xml:"description,omitempty"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+}
+
+// ContentType returns the media type of the documents rendered in the given format.
+func (f FeedFormat) ContentType() string {
+	if f == RSSFeedFormat {
+		return "application/rss+xml; charset=utf-8"
+	}
+
+	return "application/atom+xml; charset=utf-8"
+}
+
+// Render encodes the feed as an XML document in the given format.
+func (f *ShowsFeed) Render(format FeedFormat) ([]byte, error) {
+	var document any
+
+	if format == RSSFeedFormat {
+		document = f.toRSS()
+	} else {
+		document = f.toAtom()
+	}
+
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func (f *ShowsFeed) toAtom() *atomFeed {
+	feed := &atomFeed{
+		ID:      f.SelfURL,
+		Title:   f.Title,
+		Updated: f.UpdatedAt.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: f.Author},
+		Links:   []atomLink{{Rel: "self", Href: f.SelfURL}},
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			ID:         "urn:uuid:" + item.ID,
+			Title:      item.Title,
+			Updated:    item.UpdatedAt.UTC().Format(time.RFC3339),
+			Published:  item.PublishedAt.UTC().Format(time.RFC3339),
+			Summary:    item.Summary,
+			Links:      []atomLink{{Rel: "alternate", Href: item.URL}},
+			Categories: make([]atomCategory, 0, len(item.Categories)),
+		}
+
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+func (f *ShowsFeed) toRSS() *rssFeed {
+	channel := rssChannel{
+		Title:       f.Title,
+		Link:        f.SelfURL,
+		Description: f.Description,
+		Language:    f.Language,
+		Items:       make([]rssItem, 0, len(f.Items)),
+	}
+
+	if !f.UpdatedAt.IsZero() {
+		channel.LastBuildDate = f.UpdatedAt.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range f.Items {
+		channel.Items = append(channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.URL,
+			Description: item.Summary,
+			GUID:        rssGUID{IsPermaLink: false, Value: item.ID},
+			PubDate:     item.PublishedAt.UTC().Format(time.RFC1123Z),
+			Categories:  item.Categories,
+		})
+	}
+
+	return &rssFeed{
+		Version: "2.0",
+		Channel: channel,
+	}
+}
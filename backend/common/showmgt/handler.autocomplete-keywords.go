@@ -0,0 +1,102 @@
+package showmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultKeywordSuggestions is the number of suggestions returned when no limit is given.
+	defaultKeywordSuggestions = 10
+
+	// maxKeywordSuggestions is the maximum number of suggestions returned at once.
+	maxKeywordSuggestions = 50
+)
+
+type autocompleteKeywordsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type AutocompleteKeywordsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*autocompleteKeywordsHandler)(nil)
+
+func NewAutocompleteKeywordsHandler(p AutocompleteKeywordsHandlerParams) *autocompleteKeywordsHandler {
+	return &autocompleteKeywordsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *autocompleteKeywordsHandler) Pattern() string {
+	return "GET /api/v1/keywords/autocomplete"
+}
+
+func (h *autocompleteKeywordsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP suggests the keywords whose name or one of whose synonyms (localized labels included)
+// starts with the "q" query parameter. The number of suggestions is set by the "limit" query parameter.
+func (h *autocompleteKeywordsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	prefix := NormalizeKeyword(r.URL.Query().Get("q"))
+	if prefix == "" {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data([]*KeywordDTO{}).Build())
+
+		return
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = defaultKeywordSuggestions
+	}
+
+	pattern := escapeLikePattern(prefix) + "%"
+
+	var keywordModels []KeywordModel
+	if result := h.db.WithContext(reqCtx).
+		Preload("Labels").
+		Preload("Synonyms").
+		Where("name LIKE ? OR id IN (?)", pattern,
+			h.db.Model(&KeywordSynonymModel{}).Select("keyword_id").Where("synonym LIKE ?", pattern)).
+		Order("name").
+		Limit(min(limit, maxKeywordSuggestions)).
+		Find(&keywordModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when autocompleting keywords", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	languages := core.GetLanguages(r)
+	keywordDTOs := lo.Map(keywordModels, func(keywordModel KeywordModel, _ int) *KeywordDTO {
+		return ToKeywordDTO(&keywordModel, languages)
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(keywordDTOs).Build())
+}
+
+// escapeLikePattern escapes the characters which have a special meaning in a LIKE pattern.
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
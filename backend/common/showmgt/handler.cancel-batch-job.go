@@ -0,0 +1,85 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type cancelBatchJobHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type CancelBatchJobHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*cancelBatchJobHandler)(nil)
+
+func NewCancelBatchJobHandler(p CancelBatchJobHandlerParams) *cancelBatchJobHandler {
+	return &cancelBatchJobHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *cancelBatchJobHandler) Pattern() string {
+	return "POST /api/v1/shows/batch/{id}/cancel"
+}
+
+func (h *cancelBatchJobHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP cancels a batch job (see CancelBatchJob). The shows which have already been changed
+// keep their changes.
+func (h *cancelBatchJobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgBatchJobNotFound).Build())
+
+		return
+	}
+
+	var jobModel ShowBatchJobModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			First(&jobModel, "id = ?", id); result.Error != nil {
+			return result.Error
+		}
+
+		return CancelBatchJob(tx, &jobModel)
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(ToShowBatchJobDTO(&jobModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgBatchJobNotFound).Build())
+	case errors.Is(err, ErrBatchJobFinished):
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgBatchJobFinished).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when cancelling a batch job", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,99 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type createBatchJobHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type CreateBatchJobHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*createBatchJobHandler)(nil)
+
+func NewCreateBatchJobHandler(p CreateBatchJobHandlerParams) *createBatchJobHandler {
+	return &createBatchJobHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *createBatchJobHandler) Pattern() string {
+	return "POST /api/v1/shows/batch"
+}
+
+func (h *createBatchJobHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP creates a batch job changing the selected shows. The job is run in the background by
+// the batch job worker; its progress can be followed with the batch job endpoint.
+func (h *createBatchJobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	var requestBody BatchJobRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var jobModel *ShowBatchJobModel
+
+	err := h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		jobModel, err = CreateBatchJob(tx, &requestBody, core.MustGetAuthUserFromRequest(r).GetUsername())
+
+		return err
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, responseBuilder.Data(ToShowBatchJobDTO(jobModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgKeywordNotFound).Build())
+	case errors.Is(err, ErrTooManyBatchJobShows):
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgTooManyBatchJobShows).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when creating a batch job", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,97 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type createKeywordHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type CreateKeywordHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*createKeywordHandler)(nil)
+
+func NewCreateKeywordHandler(p CreateKeywordHandlerParams) *createKeywordHandler {
+	return &createKeywordHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *createKeywordHandler) Pattern() string {
+	return "POST /api/v1/keywords"
+}
+
+func (h *createKeywordHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP creates a canonical keyword with its labels and synonyms.
+// It answers with 409 when the name or a synonym is already used by another keyword.
+func (h *createKeywordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	var requestBody KeywordRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var keywordModel KeywordModel
+
+	err := h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		return SaveKeyword(tx, &keywordModel, &requestBody)
+	})
+	if errors.Is(err, ErrKeywordConflict) {
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgKeywordAlreadyExists).Build())
+
+		return
+	}
+
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when creating a keyword", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, responseBuilder.Data(ToKeywordDTO(&keywordModel, core.GetLanguages(r))).Build())
+}
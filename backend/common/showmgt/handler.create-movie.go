@@ -3,41 +3,86 @@ package showmgt
 import (
 	"log/slog"
 	"net/http"
+	"time"
 	"wano-island/common/core"
 
 	"github.com/go-chi/render"
-	"github.com/lib/pq"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
 
 type createMovieHandler struct {
-	logger *slog.Logger
-	db     *gorm.DB
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
 }
 
 type CreateShowHandlerParams struct {
 	fx.In
 
-	Logger *slog.Logger
-	DB     *gorm.DB
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
 }
 
+// CreateShowRequestBody holds the request body for creating a show, and for updating it,
+// in which case every field is replaced. Dates use the "2006-01-02" layout, and the last air date
+// can't be before the first air date.
 type CreateShowRequestBody struct {
-	Kind             string   `json:"kind"`
-	OriginalLanguage string   `json:"originalLanguage"`
-	OriginalTitle    string   `json:"originalTitle"`
+	Kind             string   `json:"kind" validate:"required,oneof=movie tv"`
+	OriginalLanguage string   `json:"originalLanguage" validate:"required,bcp47_language_tag,max=256"`
+	OriginalTitle    string   `json:"originalTitle" validate:"required,max=256"`
 	OriginalOverview *string  `json:"originalOverview"`
+	Tagline          *string  `json:"tagline" validate:"omitempty,max=256"`
 	Keywords         []string `json:"keywords"`
 	IsReleased       bool     `json:"isReleased"`
+	Status           *string  `json:"status" validate:"omitempty,oneof=rumored in_production ended returning"`
+	Runtime          *int     `json:"runtime" validate:"omitempty,min=1"`
+	FirstAirDate     *string  `json:"firstAirDate" validate:"omitempty,datetime=2006-01-02"`
+	LastAirDate      *string  `json:"lastAirDate" validate:"omitempty,datetime=2006-01-02,gtedatefield=FirstAirDate"`
+	Homepage         *string  `json:"homepage" validate:"omitempty,http_url,max=2048"`
+	OriginCountries  []string `json:"originCountries" validate:"omitempty,dive,iso3166_1_alpha2"`
+}
+
+// applyTo copies the fields of the request body, except the keywords, to the show.
+// The body must have been validated.
+func (b *CreateShowRequestBody) applyTo(showModel *ShowModel) {
+	parseDate := func(value *string) *time.Time {
+		if value == nil {
+			return nil
+		}
+
+		date, _ := time.Parse(time.DateOnly, *value)
+
+		return &date
+	}
+
+	showModel.Kind = b.Kind
+	showModel.OriginalLanguage = b.OriginalLanguage
+	showModel.OriginalTitle = b.OriginalTitle
+	showModel.OriginalOverview = b.OriginalOverview
+	showModel.Tagline = b.Tagline
+	showModel.IsReleased = b.IsReleased
+	showModel.Status = b.Status
+	showModel.Runtime = b.Runtime
+	showModel.FirstAirDate = parseDate(b.FirstAirDate)
+	showModel.LastAirDate = parseDate(b.LastAirDate)
+	showModel.Homepage = b.Homepage
+	showModel.OriginCountries = b.OriginCountries
 }
 
 var _ core.HTTPRoute = (*createMovieHandler)(nil)
 
 func NewCreateMovieHandler(p CreateShowHandlerParams) *createMovieHandler {
 	return &createMovieHandler{
-		logger: p.Logger,
-		db:     p.DB,
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
 	}
 }
 
@@ -62,17 +107,46 @@ func (h *createMovieHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	showModel := ShowModel{
-		Kind:             requestBody.Kind,
-		OriginalLanguage: requestBody.OriginalLanguage,
-		OriginalTitle:    requestBody.OriginalTitle,
-		OriginalOverview: requestBody.OriginalOverview,
-		Keywords:         pq.StringArray(requestBody.Keywords),
-		IsReleased:       requestBody.IsReleased,
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
 	}
 
-	if result := h.db.Create(&showModel); result.Error != nil {
-		h.logger.ErrorContext(reqCtx, "Something went wrong when creating a show", core.DetailsLogAttr(result.Error))
+	var showModel ShowModel
+	requestBody.applyTo(&showModel)
+
+	// Nobody follows a show which does not exist yet, so the episodes it has aired are not announced.
+	_, _ = AnnounceAiredEpisode(&showModel, time.Now())
+
+	if err := h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		keywords, err := ResolveOrCreateKeywords(tx, requestBody.Keywords)
+		if err != nil {
+			return err
+		}
+
+		showModel.Keywords = KeywordNames(keywords)
+
+		if result := tx.Create(&showModel); result.Error != nil {
+			return result.Error
+		}
+
+		if err = LinkShowKeywords(tx, &showModel, keywords); err != nil {
+			return err
+		}
+
+		if err = AssignShowSlugs(tx, &showModel); err != nil {
+			return err
+		}
+
+		return PublishCatalogEvents(tx,
+			CatalogEvent{Type: ShowChangedCatalogEvent, ShowID: showModel.ID, OccurredAt: time.Now()})
+	}); err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when creating a show", core.DetailsLogAttr(err))
 		render.Status(r, http.StatusBadRequest)
 		render.JSON(w, r, responseBuilder.MessageID(core.MsgCannotCreateTheShow).Build())
 
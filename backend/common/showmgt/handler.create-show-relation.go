@@ -0,0 +1,121 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type createShowRelationHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type CreateShowRelationHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*createShowRelationHandler)(nil)
+
+func NewCreateShowRelationHandler(p CreateShowRelationHandlerParams) *createShowRelationHandler {
+	return &createShowRelationHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *createShowRelationHandler) Pattern() string {
+	return "POST /api/v1/shows/{id}/relations"
+}
+
+func (h *createShowRelationHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP relates the show to another show. The inverse relation, e.g. "sequel_of" for
+// "prequel_of", is visible from the other show without being created.
+func (h *createShowRelationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	var requestBody ShowRelationRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	relationModel := NewShowRelation(showID, requestBody.RelatedShowID, requestBody.Type)
+
+	var relatedShowModel ShowModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if err := CreateShowRelation(tx, relationModel); err != nil {
+			return err
+		}
+
+		return tx.First(&relatedShowModel, "id = ?", requestBody.RelatedShowID).Error
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, responseBuilder.Data(&RelatedShowDTO{
+			RelationID: relationModel.ID,
+			Type:       relationModel.TypeFrom(showID),
+			Show:       ToShowDTO(&relatedShowModel),
+		}).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+	case errors.Is(err, ErrShowRelatedToItself):
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowRelatedToItself).Build())
+	case errors.Is(err, ErrShowRelationExists):
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowRelationAlreadyExists).Build())
+	case errors.Is(err, ErrShowRelationCycle):
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowRelationCycle).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when relating shows", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,123 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type createVideoHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	config              core.AppConfig
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type CreateVideoHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Config              core.AppConfig
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*createVideoHandler)(nil)
+
+func NewCreateVideoHandler(p CreateVideoHandlerParams) *createVideoHandler {
+	return &createVideoHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		config:              p.Config,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *createVideoHandler) Pattern() string {
+	return "POST /api/v1/shows/{id}/videos"
+}
+
+func (h *createVideoHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP adds a video to a show, or to one of its episodes when an episode ID is given.
+func (h *createVideoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	var requestBody VideoRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	if err := ValidateVideoKey(requestBody.Site, requestBody.Key, h.config.GetVideoConfig().AllowedHosts); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInvalidVideoLink).Build())
+
+		return
+	}
+
+	videoModel := VideoModel{ShowID: showID}
+	requestBody.applyTo(&videoModel)
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Select("id").First(&ShowModel{}, "id = ?", showID); result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkShowEpisode(tx, showID, videoModel.EpisodeID); err != nil {
+			return err
+		}
+
+		return tx.Create(&videoModel).Error
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, responseBuilder.Data(ToVideoDTO(&videoModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+	case errors.Is(err, ErrEpisodeNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgEpisodeNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when creating a video", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,77 @@
+package showmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type deleteShowRelationHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type DeleteShowRelationHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*deleteShowRelationHandler)(nil)
+
+func NewDeleteShowRelationHandler(p DeleteShowRelationHandlerParams) *deleteShowRelationHandler {
+	return &deleteShowRelationHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *deleteShowRelationHandler) Pattern() string {
+	return "DELETE /api/v1/shows/{id}/relations/{relationId}"
+}
+
+func (h *deleteShowRelationHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP removes a relation of the show, whichever side of the relation the show is on.
+func (h *deleteShowRelationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, showErr := uuid.Parse(r.PathValue("id"))
+	relationID, relationErr := uuid.Parse(r.PathValue("relationId"))
+	if showErr != nil || relationErr != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowRelationNotFound).Build())
+
+		return
+	}
+
+	result := h.db.WithContext(reqCtx).
+		Where("show_id = ? OR related_show_id = ?", showID, showID).
+		Delete(&ShowRelationModel{}, "id = ?", relationID)
+	if result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when deleting a show relation", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowRelationNotFound).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+}
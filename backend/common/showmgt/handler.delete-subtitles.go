@@ -0,0 +1,73 @@
+package showmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type deleteSubtitlesHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type DeleteSubtitlesHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*deleteSubtitlesHandler)(nil)
+
+func NewDeleteSubtitlesHandler(p DeleteSubtitlesHandlerParams) *deleteSubtitlesHandler {
+	return &deleteSubtitlesHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *deleteSubtitlesHandler) Pattern() string {
+	return "DELETE /api/v1/subtitles/{id}"
+}
+
+func (h *deleteSubtitlesHandler) IsPrivateRoute() bool {
+	return true
+}
+
+func (h *deleteSubtitlesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSubtitleNotFound).Build())
+
+		return
+	}
+
+	result := h.db.WithContext(reqCtx).Delete(&SubtitleModel{}, "id = ?", id)
+	if result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when deleting a subtitle track", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSubtitleNotFound).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+}
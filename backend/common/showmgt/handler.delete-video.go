@@ -0,0 +1,73 @@
+package showmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type deleteVideoHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type DeleteVideoHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*deleteVideoHandler)(nil)
+
+func NewDeleteVideoHandler(p DeleteVideoHandlerParams) *deleteVideoHandler {
+	return &deleteVideoHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *deleteVideoHandler) Pattern() string {
+	return "DELETE /api/v1/videos/{id}"
+}
+
+func (h *deleteVideoHandler) IsPrivateRoute() bool {
+	return true
+}
+
+func (h *deleteVideoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgVideoNotFound).Build())
+
+		return
+	}
+
+	result := h.db.WithContext(reqCtx).Delete(&VideoModel{}, "id = ?", id)
+	if result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when deleting a video", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgVideoNotFound).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.MessageID(core.MsgSuccess).Build())
+}
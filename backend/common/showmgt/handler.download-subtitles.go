@@ -0,0 +1,113 @@
+package showmgt
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type downloadSubtitlesHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type DownloadSubtitlesHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*downloadSubtitlesHandler)(nil)
+
+func NewDownloadSubtitlesHandler(p DownloadSubtitlesHandlerParams) *downloadSubtitlesHandler {
+	return &downloadSubtitlesHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *downloadSubtitlesHandler) Pattern() string {
+	return "GET /api/v1/subtitles/{id}/file"
+}
+
+func (h *downloadSubtitlesHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the subtitle track as a WebVTT file, or as an SRT file when the "format"
+// query parameter is "srt". The "offset" query parameter shifts every cue by the given number
+// of milliseconds, which can be negative.
+func (h *downloadSubtitlesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSubtitleNotFound).Build())
+
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = WebVTTSubtitleFormat
+	}
+
+	offset, err := ParseSubtitleOffset(r.URL.Query().Get("offset"))
+	if err != nil || (format != WebVTTSubtitleFormat && format != SRTSubtitleFormat) {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgValidationFailed).Build())
+
+		return
+	}
+
+	var subtitleModel SubtitleModel
+	if result := h.db.WithContext(reqCtx).First(&subtitleModel, "id = ?", id); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgSubtitleNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a subtitle track", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var body bytes.Buffer
+
+	cues, err := ParseSubtitles([]byte(subtitleModel.Content), WebVTTSubtitleFormat)
+	if err == nil {
+		err = WriteSubtitles(&body, ShiftSubtitles(cues, offset), format)
+	}
+
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when exporting a subtitle track", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	contentType := "text/vtt; charset=utf-8"
+	if format == SRTSubtitleFormat {
+		contentType = "application/x-subrip; charset=utf-8"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+subtitleModel.Language+"."+format+`"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
@@ -0,0 +1,108 @@
+package showmgt
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// exportEpisodeHandler renders an episode as a metadata document for media centers.
+type exportEpisodeHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+	format ExportFormat
+}
+
+type ExportEpisodeHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*exportEpisodeHandler)(nil)
+
+// NewExportEpisodeNFOHandler creates a handler which renders the episode as a Kodi NFO document.
+func NewExportEpisodeNFOHandler(p ExportEpisodeHandlerParams) *exportEpisodeHandler {
+	return &exportEpisodeHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: NFOExportFormat,
+	}
+}
+
+// NewExportEpisodeJSONLDHandler creates a handler which renders the episode as schema.org JSON-LD.
+func NewExportEpisodeJSONLDHandler(p ExportEpisodeHandlerParams) *exportEpisodeHandler {
+	return &exportEpisodeHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: JSONLDExportFormat,
+	}
+}
+
+func (h *exportEpisodeHandler) Pattern() string {
+	return "GET /api/v1/episodes/{id}/export." + string(h.format)
+}
+
+func (h *exportEpisodeHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP renders the episode, localized for the languages of the request.
+func (h *exportEpisodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgEpisodeNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+
+	var episodeModel EpisodeModel
+	if result := db.Preload("Translations").First(&episodeModel, "id = ?", id); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgEpisodeNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting an episode", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var showModel ShowModel
+	if result := db.Preload("Translations").First(&showModel, "id = ?", episodeModel.ShowID); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	body, err := newCatalogExporter(r, h.format).ExportEpisode(&showModel, &episodeModel)
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when exporting an episode", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	writeExport(w, h.format, fmt.Sprintf("episode-%04d", episodeModel.Order), body)
+}
@@ -0,0 +1,108 @@
+package showmgt
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// exportSeasonHandler renders a season as a metadata document for media centers.
+type exportSeasonHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+	format ExportFormat
+}
+
+type ExportSeasonHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*exportSeasonHandler)(nil)
+
+// NewExportSeasonNFOHandler creates a handler which renders the season as a Kodi NFO document.
+func NewExportSeasonNFOHandler(p ExportSeasonHandlerParams) *exportSeasonHandler {
+	return &exportSeasonHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: NFOExportFormat,
+	}
+}
+
+// NewExportSeasonJSONLDHandler creates a handler which renders the season as schema.org JSON-LD.
+func NewExportSeasonJSONLDHandler(p ExportSeasonHandlerParams) *exportSeasonHandler {
+	return &exportSeasonHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: JSONLDExportFormat,
+	}
+}
+
+func (h *exportSeasonHandler) Pattern() string {
+	return "GET /api/v1/seasons/{id}/export." + string(h.format)
+}
+
+func (h *exportSeasonHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP renders the season, localized for the languages of the request.
+func (h *exportSeasonHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgSeasonNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+
+	var seasonModel SeasonModel
+	if result := db.Preload("Translations").First(&seasonModel, "id = ?", id); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgSeasonNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a season", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var showModel ShowModel
+	if result := db.Preload("Translations").First(&showModel, "id = ?", seasonModel.ShowID); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	body, err := newCatalogExporter(r, h.format).ExportSeason(&showModel, &seasonModel)
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when exporting a season", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	writeExport(w, h.format, fmt.Sprintf("season-%02d", seasonModel.Order), body)
+}
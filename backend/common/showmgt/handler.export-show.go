@@ -0,0 +1,137 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// exportShowHandler renders a show as a metadata document for media centers.
+type exportShowHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+	format ExportFormat
+}
+
+type ExportShowHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*exportShowHandler)(nil)
+
+// NewExportShowNFOHandler creates a handler which renders the show as a Kodi NFO document.
+func NewExportShowNFOHandler(p ExportShowHandlerParams) *exportShowHandler {
+	return &exportShowHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: NFOExportFormat,
+	}
+}
+
+// NewExportShowJSONLDHandler creates a handler which renders the show as schema.org JSON-LD.
+func NewExportShowJSONLDHandler(p ExportShowHandlerParams) *exportShowHandler {
+	return &exportShowHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: JSONLDExportFormat,
+	}
+}
+
+func (h *exportShowHandler) Pattern() string {
+	return "GET /api/v1/shows/{id}/export." + string(h.format)
+}
+
+func (h *exportShowHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP renders the show with its seasons, localized for the languages of the request.
+func (h *exportShowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+
+	var showModel ShowModel
+	if result := db.
+		Preload("Translations").
+		Preload("Seasons", func(tx *gorm.DB) *gorm.DB { return tx.Order("\"order\"") }).
+		Preload("Seasons.Translations").
+		Preload("Videos", "episode_id IS NULL").
+		First(&showModel, "id = ?", id); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var episodeCount int64
+	if result := db.Model(&EpisodeModel{}).Where("show_id = ?", id).Count(&episodeCount); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when counting the episodes of a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	exporter := newCatalogExporter(r, h.format)
+
+	body, err := exporter.ExportShow(&showModel, int(episodeCount))
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when exporting a show", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	filename := lo.FromPtrOr(showModel.Slug, showModel.ID.String())
+	if h.format == NFOExportFormat {
+		filename = lo.Ternary(showModel.Kind == "movie", "movie", "tvshow")
+	}
+
+	writeExport(w, h.format, filename, body)
+}
+
+// newCatalogExporter creates an exporter localized for the languages of the request.
+func newCatalogExporter(r *http.Request, format ExportFormat) *CatalogExporter {
+	return &CatalogExporter{
+		Format:    format,
+		Languages: core.GetLanguages(r),
+		BaseURL:   core.GetBaseURL(r),
+	}
+}
+
+// writeExport writes the exported document as an attachment with the given file name, without extension.
+func writeExport(w http.ResponseWriter, format ExportFormat, filename string, body []byte) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+"."+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
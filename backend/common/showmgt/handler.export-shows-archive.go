@@ -0,0 +1,142 @@
+package showmgt
+
+import (
+	"archive/zip"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// exportShowsArchiveBatchSize is the number of shows loaded at once when building an archive.
+const exportShowsArchiveBatchSize = 100
+
+// exportShowsArchiveHandler renders the whole catalog as a zip archive of metadata documents.
+type exportShowsArchiveHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+	format ExportFormat
+}
+
+type ExportShowsArchiveHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*exportShowsArchiveHandler)(nil)
+
+// NewExportShowsNFOArchiveHandler creates a handler which renders the catalog as Kodi NFO documents.
+func NewExportShowsNFOArchiveHandler(p ExportShowsArchiveHandlerParams) *exportShowsArchiveHandler {
+	return &exportShowsArchiveHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: NFOExportFormat,
+	}
+}
+
+// NewExportShowsJSONLDArchiveHandler creates a handler which renders the catalog as schema.org JSON-LD.
+func NewExportShowsJSONLDArchiveHandler(p ExportShowsArchiveHandlerParams) *exportShowsArchiveHandler {
+	return &exportShowsArchiveHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: JSONLDExportFormat,
+	}
+}
+
+func (h *exportShowsArchiveHandler) Pattern() string {
+	return "GET /api/v1/exports/shows." + string(h.format) + ".zip"
+}
+
+func (h *exportShowsArchiveHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP streams a zip archive with a folder per show, holding the documents of the show, its
+// seasons and its episodes localized for the languages of the request. The shows can be filtered
+// with the "kind" and "language" query parameters. As the archive is streamed, a failure after the
+// first shows have been sent can only be logged and leaves the archive truncated.
+func (h *exportShowsArchiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	query := r.URL.Query()
+
+	db := h.db.WithContext(reqCtx)
+
+	showsQuery := db.
+		Preload("Translations").
+		Preload("Seasons", func(tx *gorm.DB) *gorm.DB { return tx.Order("\"order\"") }).
+		Preload("Seasons.Translations").
+		Preload("Videos", "episode_id IS NULL")
+
+	if kind := query.Get("kind"); kind != "" {
+		showsQuery = showsQuery.Where("kind = ?", kind)
+	}
+
+	if language := query.Get("language"); language != "" {
+		showsQuery = showsQuery.Where("original_language = ?", language)
+	}
+
+	exporter := newCatalogExporter(r, h.format)
+
+	var archive *zip.Writer
+
+	startArchive := func() {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="shows.`+string(h.format)+`.zip"`)
+		w.WriteHeader(http.StatusOK)
+
+		archive = zip.NewWriter(w)
+	}
+
+	var showModels []ShowModel
+	result := showsQuery.Order("id").FindInBatches(&showModels, exportShowsArchiveBatchSize, func(_ *gorm.DB, _ int) error {
+		showIDs := lo.Map(showModels, func(showModel ShowModel, _ int) uuid.UUID { return showModel.ID })
+
+		var episodeModels []EpisodeModel
+		if result := db.Preload("Translations").
+			Where("show_id IN ?", showIDs).
+			Order("\"order\"").
+			Find(&episodeModels); result.Error != nil {
+			return result.Error
+		}
+
+		episodesByShow := lo.GroupBy(episodeModels, func(episodeModel EpisodeModel) uuid.UUID { return episodeModel.ShowID })
+
+		if archive == nil {
+			startArchive()
+		}
+
+		for i := range showModels {
+			if err := exporter.WriteArchive(archive, &showModels[i], episodesByShow[showModels[i].ID]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when exporting the shows", core.DetailsLogAttr(result.Error))
+
+		if archive == nil {
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, core.NewResponseBuilder(r).MessageID(core.MsgInternalServerError).Build())
+		}
+
+		return
+	}
+
+	if archive == nil {
+		startArchive()
+	}
+
+	if err := archive.Close(); err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when writing the shows archive", core.DetailsLogAttr(err))
+	}
+}
@@ -0,0 +1,112 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getBatchJobItemsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetBatchJobItemsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getBatchJobItemsHandler)(nil)
+
+func NewGetBatchJobItemsHandler(p GetBatchJobItemsHandlerParams) *getBatchJobItemsHandler {
+	return &getBatchJobItemsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getBatchJobItemsHandler) Pattern() string {
+	return "GET /api/v1/shows/batch/{id}/items"
+}
+
+func (h *getBatchJobItemsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a page of the per-show report of a batch job, in processing order. The items
+// can be filtered by status with the "status" query parameter (e.g. "?status=failed").
+func (h *getBatchJobItemsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgBatchJobNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+	if result := db.Select("id").First(&ShowBatchJobModel{}, "id = ?", id); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgBatchJobNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a batch job", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	jobItems := func() *gorm.DB {
+		query := db.Model(&ShowBatchJobItemModel{}).Where("job_id = ?", id)
+		if status := r.URL.Query().Get("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		return query
+	}
+
+	var totalRows int64
+	if result := jobItems().Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var itemModels []ShowBatchJobItemModel
+	if result := jobItems().
+		Order("position").
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Find(&itemModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting batch job items", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	itemDTOs := lo.Map(itemModels, func(itemModel ShowBatchJobItemModel, _ int) *ShowBatchJobItemDTO {
+		return ToShowBatchJobItemDTO(&itemModel)
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(itemDTOs).Pagination(totalRows).Build())
+}
@@ -0,0 +1,73 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getBatchJobHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetBatchJobHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getBatchJobHandler)(nil)
+
+func NewGetBatchJobHandler(p GetBatchJobHandlerParams) *getBatchJobHandler {
+	return &getBatchJobHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getBatchJobHandler) Pattern() string {
+	return "GET /api/v1/shows/batch/{id}"
+}
+
+func (h *getBatchJobHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a batch job with its progress.
+func (h *getBatchJobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgBatchJobNotFound).Build())
+
+		return
+	}
+
+	var jobModel ShowBatchJobModel
+
+	err = h.db.WithContext(reqCtx).First(&jobModel, "id = ?", id).Error
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(ToShowBatchJobDTO(&jobModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgBatchJobNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a batch job", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
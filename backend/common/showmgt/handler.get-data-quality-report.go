@@ -0,0 +1,119 @@
+package showmgt
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// getDataQualityReportHandler reports the data quality issues of the catalog, grouped by severity.
+type getDataQualityReportHandler struct {
+	logger    *slog.Logger
+	db        *gorm.DB
+	exportCSV bool
+}
+
+type GetDataQualityReportHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getDataQualityReportHandler)(nil)
+
+// NewGetDataQualityReportHandler creates a handler which returns the report as JSON.
+func NewGetDataQualityReportHandler(p GetDataQualityReportHandlerParams) *getDataQualityReportHandler {
+	return &getDataQualityReportHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+// NewExportDataQualityReportHandler creates a handler which exports the report as CSV.
+func NewExportDataQualityReportHandler(p GetDataQualityReportHandlerParams) *getDataQualityReportHandler {
+	return &getDataQualityReportHandler{
+		logger:    p.Logger,
+		db:        p.DB,
+		exportCSV: true,
+	}
+}
+
+func (h *getDataQualityReportHandler) Pattern() string {
+	if h.exportCSV {
+		return "GET /api/v1/reports/data-quality.csv"
+	}
+
+	return "GET /api/v1/reports/data-quality"
+}
+
+func (h *getDataQualityReportHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the report of the latest nightly snapshot. The report is built on the fly
+// when the "live" query parameter is true, or when no snapshot has been stored yet.
+// The report is restricted to admins.
+func (h *getDataQualityReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	if !core.HasAnyRole(core.MustGetAuthUserFromRequest(r), core.AdminRole) {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgPermissionDenied).Build())
+
+		return
+	}
+
+	live, _ := strconv.ParseBool(r.URL.Query().Get("live"))
+
+	var (
+		report *DataQualityReport
+		err    error
+	)
+
+	if !live {
+		report, err = LoadLatestDataQualityReport(reqCtx, h.db)
+	}
+
+	if live || errors.Is(err, gorm.ErrRecordNotFound) {
+		report, err = LoadDataQualityReport(reqCtx, h.db, time.Now().UTC())
+	}
+
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when building the data quality report", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	if !h.exportCSV {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(report).Build())
+
+		return
+	}
+
+	var body bytes.Buffer
+	if err = report.WriteCSV(&body); err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when exporting the data quality report", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="data-quality.csv"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
@@ -0,0 +1,105 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getEpisodesHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetEpisodesHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getEpisodesHandler)(nil)
+
+func NewGetEpisodesHandler(p GetEpisodesHandlerParams) *getEpisodesHandler {
+	return &getEpisodesHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getEpisodesHandler) Pattern() string {
+	return "GET /api/v1/shows/{id}/episodes"
+}
+
+func (h *getEpisodesHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns a page of the episodes of a show, by order, with the languages of their
+// subtitle tracks.
+func (h *getEpisodesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+	if result := db.Select("id").First(&ShowModel{}, "id = ?", showID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var totalRows int64
+	if result := db.Model(&EpisodeModel{}).Where("show_id = ?", showID).Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var episodeModels []EpisodeModel
+	if result := db.
+		Preload("Subtitles", func(tx *gorm.DB) *gorm.DB {
+			return tx.Select("id", "episode_id", "language")
+		}).
+		Where("show_id = ?", showID).
+		Offset(core.GetOffset(r)).
+		Limit(core.GetPageSize(r)).
+		Order("\"order\"").
+		Find(&episodeModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting the episodes of a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(lo.Map(episodeModels, func(episodeModel EpisodeModel, _ int) *EpisodeDTO {
+		return ToEpisodeDTO(&episodeModel)
+	})).Pagination(totalRows).Build())
+}
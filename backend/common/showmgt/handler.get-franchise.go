@@ -0,0 +1,91 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getFranchiseHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetFranchiseHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getFranchiseHandler)(nil)
+
+func NewGetFranchiseHandler(p GetFranchiseHandlerParams) *getFranchiseHandler {
+	return &getFranchiseHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getFranchiseHandler) Pattern() string {
+	return "GET /api/v1/shows/{id}/franchise"
+}
+
+func (h *getFranchiseHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the graph of the shows connected to the show by at most "depth" relations,
+// followed in both directions.
+func (h *getFranchiseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	depth, err := strconv.Atoi(r.URL.Query().Get("depth"))
+	if err != nil || depth < 1 {
+		depth = DefaultFranchiseDepth
+	}
+
+	db := h.db.WithContext(reqCtx)
+	if result := db.Select("id").First(&ShowModel{}, "id = ?", showID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	graph, err := LoadFranchiseGraph(db, showID, min(depth, MaxFranchiseDepth))
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting the franchise of a show", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(ToFranchiseGraphDTO(graph)).Build())
+}
@@ -0,0 +1,78 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getPublicShowHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetPublicShowHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getPublicShowHandler)(nil)
+
+func NewGetPublicShowHandler(p GetPublicShowHandlerParams) *getPublicShowHandler {
+	return &getPublicShowHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getPublicShowHandler) Pattern() string {
+	return "GET /api/v1/public/shows/{key}"
+}
+
+func (h *getPublicShowHandler) IsPrivateRoute() bool {
+	return false
+}
+
+// ServeHTTP returns a released show without requiring authentication, so that the links of the
+// feeds can be followed. The key is either the ID or the current slug of the show.
+func (h *getPublicShowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	key := r.PathValue("key")
+	includes := []ShowInclude{TranslationsShowInclude}
+	query := PreloadShowIncludes(h.db.WithContext(reqCtx), includes).Where("is_released = ?", true)
+
+	if id, err := uuid.Parse(key); err == nil {
+		query = query.Where("id = ?", id)
+	} else {
+		query = query.Where("slug = ?", key)
+	}
+
+	var showModel ShowModel
+	if result := query.First(&showModel); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a public show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(ExpandShowDTO(ToShowDTO(&showModel), &showModel, includes)).Build())
+}
@@ -0,0 +1,166 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getShowHandler struct {
+	logger           *slog.Logger
+	db               *gorm.DB
+	activityRecorder *ShowActivityRecorder
+}
+
+type GetShowHandlerParams struct {
+	fx.In
+
+	Logger           *slog.Logger
+	DB               *gorm.DB
+	ActivityRecorder *ShowActivityRecorder
+}
+
+var _ core.HTTPRoute = (*getShowHandler)(nil)
+
+func NewGetShowHandler(p GetShowHandlerParams) *getShowHandler {
+	return &getShowHandler{
+		logger:           p.Logger,
+		db:               p.DB,
+		activityRecorder: p.ActivityRecorder,
+	}
+}
+
+func (h *getShowHandler) Pattern() string {
+	return "GET /api/v1/shows/{id}"
+}
+
+func (h *getShowHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the show identified by its ID, its current slug or the current slug of one
+// of its translations, with its videos and related shows. When an outdated slug is used, it answers with a 301 redirect to the
+// URL built with the current slug.
+func (h *getShowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+	idOrSlug := r.PathValue("id")
+
+	showModel, err := h.findShow(r, idOrSlug)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		location, redirectErr := h.findCurrentLocation(r, idOrSlug)
+		if redirectErr == nil {
+			http.Redirect(w, r, location, http.StatusMovedPermanently)
+
+			return
+		}
+
+		err = redirectErr
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting the show", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	relatedShows, err := LoadRelatedShows(h.db.WithContext(reqCtx), showModel.ID)
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting the related shows", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	h.activityRecorder.Record(showModel.ID, ViewShowActivity)
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(&ShowDetailDTO{
+		ShowDTO: ExpandShowDTO(ToShowDTO(showModel), showModel, GetShowIncludes(r)),
+		Videos:  ToVideoDTOs(showModel.Videos, core.GetLanguages(r)),
+		Related: ToRelatedShowDTOs(relatedShows),
+	}).Build())
+}
+
+// findShow looks the show up by its ID, its current slug or the current slug of one of its translations.
+// The videos of the show itself, not the ones of its episodes, and the relations requested with
+// the "include" query parameter are loaded with it.
+func (h *getShowHandler) findShow(r *http.Request, idOrSlug string) (*ShowModel, error) {
+	db := PreloadShowIncludes(h.db.WithContext(r.Context()), GetShowIncludes(r)).Preload("Videos", "episode_id IS NULL")
+
+	var showModel ShowModel
+
+	if showID, err := uuid.Parse(idOrSlug); err == nil {
+		if result := db.First(&showModel, "id = ?", showID); result.Error != nil {
+			return nil, result.Error
+		}
+
+		return &showModel, nil
+	}
+
+	result := db.First(&showModel, "slug = ?", idOrSlug)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		result = db.First(&showModel, "id = (?)", h.db.Model(&ShowTranslationModel{}).
+			Select("show_id").
+			Where("slug = ?", idOrSlug).
+			Limit(1))
+	}
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &showModel, nil
+}
+
+// findCurrentLocation looks the given slug up in the slug history and returns the URL of the show
+// built with its current slug in the same locale.
+func (h *getShowHandler) findCurrentLocation(r *http.Request, slug string) (string, error) {
+	db := h.db.WithContext(r.Context())
+
+	var slugModel ShowSlugModel
+	if result := db.Where("slug = ?", slug).Order("created_at DESC").First(&slugModel); result.Error != nil {
+		return "", result.Error
+	}
+
+	var showModel ShowModel
+	if result := db.Preload("Translations").First(&showModel, "id = ?", slugModel.ShowID); result.Error != nil {
+		return "", result.Error
+	}
+
+	currentSlug := lo.FromPtr(showModel.Slug)
+
+	if translation, found := lo.Find(showModel.Translations, func(translation ShowTranslationModel) bool {
+		return translation.Locale == slugModel.Locale && translation.Slug != nil
+	}); slugModel.Locale != "" && found {
+		currentSlug = *translation.Slug
+	}
+
+	if currentSlug == "" || currentSlug == slug {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	location := "/api/v1/shows/" + currentSlug
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+
+	return location, nil
+}
@@ -0,0 +1,182 @@
+package showmgt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// showsFeedSize is the maximum number of shows included in a feed.
+	showsFeedSize = 50
+
+	// showsFeedTitle is the title of the feeds of newly published shows.
+	showsFeedTitle = "Recently published shows"
+
+	// showsFeedDescription is the description of the feeds of newly published shows.
+	showsFeedDescription = "The latest shows added to the catalog"
+
+	// showsFeedAuthor is the author of the feeds of newly published shows.
+	showsFeedAuthor = "Wano Island"
+)
+
+// getShowsFeedHandler serves a syndication feed of the recently published shows.
+// Feeds are public so that other sites can subscribe to them.
+type getShowsFeedHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+	format FeedFormat
+}
+
+type GetShowsFeedHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getShowsFeedHandler)(nil)
+
+// NewGetShowsAtomFeedHandler creates a handler which serves the feed as Atom 1.0.
+func NewGetShowsAtomFeedHandler(p GetShowsFeedHandlerParams) *getShowsFeedHandler {
+	return &getShowsFeedHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: AtomFeedFormat,
+	}
+}
+
+// NewGetShowsRSSFeedHandler creates a handler which serves the feed as RSS 2.0.
+func NewGetShowsRSSFeedHandler(p GetShowsFeedHandlerParams) *getShowsFeedHandler {
+	return &getShowsFeedHandler{
+		logger: p.Logger,
+		db:     p.DB,
+		format: RSSFeedFormat,
+	}
+}
+
+func (h *getShowsFeedHandler) Pattern() string {
+	return "GET /api/v1/feeds/shows." + string(h.format)
+}
+
+func (h *getShowsFeedHandler) IsPrivateRoute() bool {
+	return false
+}
+
+// ServeHTTP renders the feed of the most recently published shows.
+// The feed can be filtered with the "kind" and "language" query parameters and is localized
+// according to the "lang" query parameter. It supports conditional requests through
+// the ETag and Last-Modified headers.
+func (h *getShowsFeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	query := r.URL.Query()
+
+	db := h.db.WithContext(reqCtx).Where("is_released = ?", true)
+
+	if kind := query.Get("kind"); kind != "" {
+		db = db.Where("kind = ?", kind)
+	}
+
+	if language := query.Get("language"); language != "" {
+		db = db.Where("original_language = ?", language)
+	}
+
+	var showModels []ShowModel
+	if result := db.Preload("Translations").
+		Order("created_at DESC").
+		Limit(showsFeedSize).
+		Find(&showModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting shows for the feed", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, core.NewResponseBuilder(r).MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	feed := h.buildFeed(r, showModels)
+
+	body, err := feed.Render(h.format)
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when rendering the feed", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, core.NewResponseBuilder(r).MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	checksum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(checksum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+
+	if !feed.UpdatedAt.IsZero() {
+		w.Header().Set("Last-Modified", feed.UpdatedAt.UTC().Format(http.TimeFormat))
+	}
+
+	if core.IsNotModified(r, etag, feed.UpdatedAt) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", h.format.ContentType())
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// buildFeed converts the given shows into a feed localized for the request. Shows are published
+// when they were added to the catalog, which is what the feed announces, and not on their first
+// air date.
+func (h *getShowsFeedHandler) buildFeed(r *http.Request, showModels []ShowModel) *ShowsFeed {
+	baseURL := core.GetBaseURL(r)
+	languages := core.GetLanguages(r)
+
+	feed := &ShowsFeed{
+		Title:       showsFeedTitle,
+		Description: showsFeedDescription,
+		Author:      showsFeedAuthor,
+		SelfURL:     baseURL + r.URL.RequestURI(),
+		Language:    languages[0],
+		Items:       make([]ShowsFeedItem, 0, len(showModels)),
+	}
+
+	for i := range showModels {
+		showModel := &showModels[i]
+		localizedShow := LocalizeShow(showModel, languages)
+		updatedAt := latestTime(showModel.CreatedAt, showModel.UpdatedAt)
+
+		feed.Items = append(feed.Items, ShowsFeedItem{
+			ID:          showModel.ID.String(),
+			Title:       localizedShow.Title,
+			Summary:     localizedShow.Overview,
+			URL:         baseURL + "/api/v1/public/shows/" + showModel.ID.String(),
+			Categories:  append([]string{showModel.Kind}, showModel.Keywords...),
+			PublishedAt: showModel.CreatedAt,
+			UpdatedAt:   updatedAt,
+		})
+
+		feed.UpdatedAt = latestTime(feed.UpdatedAt, updatedAt)
+
+		for _, translation := range showModel.Translations {
+			feed.UpdatedAt = latestTime(feed.UpdatedAt, translation.UpdatedAt)
+		}
+	}
+
+	return feed
+}
+
+// latestTime returns the later of the two given times.
+func latestTime(a time.Time, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
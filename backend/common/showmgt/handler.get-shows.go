@@ -40,6 +40,12 @@ func (h *getShowsHandler) IsPrivateRoute() bool {
 	return true
 }
 
+// ServeHTTP returns a page of shows matching the filters (see GetShowFilters), ordered as
+// requested with the "sort" query parameter (see OrderShows). When facets are requested with
+// the "facets" query parameter, their buckets are returned next to the data (see ShowListResponse).
+// The relations requested with the "include" query parameter are expanded in every show of the
+// page (see GetShowIncludes).
+// The total is counted as requested with the "count" query parameter (see CountRows).
 func (h *getShowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	reqCtx := r.Context()
 	responseBuilder := core.NewResponseBuilder(r)
@@ -48,17 +54,19 @@ func (h *getShowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	pageSize := core.GetPageSize(r)
 	offset := core.GetOffset(r)
+	filters := GetShowFilters(r)
+	includes := GetShowIncludes(r)
 
-	var totalRows int64
-	if result := h.db.Model(&ShowModel{}).Count(&totalRows); result.Error != nil {
-		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+	count, err := core.CountRows(filters.Apply(h.db.Model(&ShowModel{}), ""), core.GetCountMode(r))
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(err))
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
 
 		return
 	}
 
-	if result := h.db.Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&showModels); result.Error != nil {
+	if result := OrderShows(r, filters.Apply(PreloadShowIncludes(h.db, includes), "")).Offset(offset).Limit(pageSize).Find(&showModels); result.Error != nil {
 		h.logger.ErrorContext(reqCtx, "Something went wrong when getting shows", core.DetailsLogAttr(result.Error))
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
@@ -67,9 +75,21 @@ func (h *getShowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	showDTOs := lo.Map(showModels, func(showModel ShowModel, _ int) *ShowDTO {
-		return ToShowDTO(&showModel)
+		return ExpandShowDTO(ToShowDTO(&showModel), &showModel, includes)
 	})
 
+	response := ShowListResponse{Response: responseBuilder.Data(showDTOs).CountedPagination(count).Build()}
+
+	if facets := GetShowFacets(r); len(facets) > 0 {
+		if response.Facets, err = ComputeShowFacets(h.db.WithContext(reqCtx), filters, facets); err != nil {
+			h.logger.ErrorContext(reqCtx, "Something went wrong when computing facets", core.DetailsLogAttr(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, core.NewResponseBuilder(r).MessageID(core.MsgInternalServerError).Build())
+
+			return
+		}
+	}
+
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, responseBuilder.Data(showDTOs).Pagination(totalRows).Build())
+	render.JSON(w, r, response)
 }
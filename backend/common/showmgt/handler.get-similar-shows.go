@@ -0,0 +1,125 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getSimilarShowsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetSimilarShowsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+// SimilarShowDTO is a show together with how similar it is to the requested show.
+type SimilarShowDTO struct {
+	*ShowDTO
+	Score float64 `json:"score"`
+}
+
+var _ core.HTTPRoute = (*getSimilarShowsHandler)(nil)
+
+func NewGetSimilarShowsHandler(p GetSimilarShowsHandlerParams) *getSimilarShowsHandler {
+	return &getSimilarShowsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getSimilarShowsHandler) Pattern() string {
+	return "GET /api/v1/shows/{id}/similar"
+}
+
+func (h *getSimilarShowsHandler) IsPrivateRoute() bool {
+	return false
+}
+
+// ServeHTTP returns the precomputed similar shows of the requested show, ordered by their score.
+// The route is public; when the caller is logged in, the shows already in their lists are left out.
+func (h *getSimilarShowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	if result := h.db.WithContext(reqCtx).Select("id").First(&ShowModel{}, "id = ?", showID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting the show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	similarShowsQuery := func() *gorm.DB {
+		query := h.db.WithContext(reqCtx).Model(&ShowSimilarityModel{}).Where("show_id = ?", showID)
+
+		if authUser, err := core.GetAuthUserFromRequest(r); err == nil {
+			query = query.Where("similar_show_id NOT IN (?)", h.db.
+				Table("public.list_items").
+				Select("list_items.show_id").
+				Joins("JOIN public.lists ON lists.id = list_items.list_id").
+				Where("lists.owner_id = ?", authUser.GetID()))
+		}
+
+		return query
+	}
+
+	var totalRows int64
+	if result := similarShowsQuery().Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var similarities []ShowSimilarityModel
+	if result := similarShowsQuery().
+		Preload("SimilarShow").
+		Order("score DESC").
+		Scopes(core.Paginate(r)).
+		Find(&similarities); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting similar shows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	similarShowDTOs := lo.Map(similarities, func(similarity ShowSimilarityModel, _ int) *SimilarShowDTO {
+		return &SimilarShowDTO{
+			ShowDTO: ToShowDTO(&similarity.SimilarShow),
+			Score:   similarity.Score,
+		}
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(similarShowDTOs).Pagination(totalRows).Build())
+}
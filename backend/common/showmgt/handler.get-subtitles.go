@@ -0,0 +1,95 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getSubtitlesHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetSubtitlesHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getSubtitlesHandler)(nil)
+
+func NewGetSubtitlesHandler(p GetSubtitlesHandlerParams) *getSubtitlesHandler {
+	return &getSubtitlesHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getSubtitlesHandler) Pattern() string {
+	return "GET /api/v1/shows/{id}/subtitles"
+}
+
+func (h *getSubtitlesHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the subtitle tracks of a movie, sorted by language. The "episodeId" query
+// parameter returns the tracks of one of its episodes instead.
+func (h *getSubtitlesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+	if result := db.Select("id").First(&ShowModel{}, "id = ?", showID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	query := db.Omit("content").Where("show_id = ?", showID)
+	if episodeID, err := uuid.Parse(r.URL.Query().Get("episodeId")); err == nil {
+		query = query.Where("episode_id = ?", episodeID)
+	} else {
+		query = query.Where("episode_id IS NULL")
+	}
+
+	var subtitleModels []SubtitleModel
+	if result := query.Order("language").Find(&subtitleModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting the subtitle tracks of a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(lo.Map(subtitleModels, func(subtitleModel SubtitleModel, _ int) *SubtitleDTO {
+		return ToSubtitleDTO(&subtitleModel)
+	})).Build())
+}
@@ -0,0 +1,103 @@
+package showmgt
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// getTranslationCoverageHandler reports, per locale, the shows, seasons and episodes which are
+// not translated yet or whose translation is outdated.
+type getTranslationCoverageHandler struct {
+	logger    *slog.Logger
+	db        *gorm.DB
+	exportCSV bool
+}
+
+type GetTranslationCoverageHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getTranslationCoverageHandler)(nil)
+
+// NewGetTranslationCoverageHandler creates a handler which returns the report as JSON.
+func NewGetTranslationCoverageHandler(p GetTranslationCoverageHandlerParams) *getTranslationCoverageHandler {
+	return &getTranslationCoverageHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+// NewExportTranslationCoverageHandler creates a handler which exports the report as CSV.
+func NewExportTranslationCoverageHandler(p GetTranslationCoverageHandlerParams) *getTranslationCoverageHandler {
+	return &getTranslationCoverageHandler{
+		logger:    p.Logger,
+		db:        p.DB,
+		exportCSV: true,
+	}
+}
+
+func (h *getTranslationCoverageHandler) Pattern() string {
+	if h.exportCSV {
+		return "GET /api/v1/reports/translation-coverage.csv"
+	}
+
+	return "GET /api/v1/reports/translation-coverage"
+}
+
+func (h *getTranslationCoverageHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP builds the translation coverage report of the locales given by the "locale" query
+// parameter, which can be repeated. Without it, every locale having translations is reported.
+// The report is restricted to admins.
+func (h *getTranslationCoverageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	if !core.HasAnyRole(core.MustGetAuthUserFromRequest(r), core.AdminRole) {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgPermissionDenied).Build())
+
+		return
+	}
+
+	report, err := LoadTranslationCoverage(h.db.WithContext(reqCtx), r.URL.Query()["locale"])
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when building the translation coverage report", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	if !h.exportCSV {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(report).Build())
+
+		return
+	}
+
+	var body bytes.Buffer
+	if err = report.WriteCSV(&body); err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when exporting the translation coverage report", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="translation-coverage.csv"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
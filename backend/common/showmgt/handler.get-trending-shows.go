@@ -0,0 +1,91 @@
+package showmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getTrendingShowsHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetTrendingShowsHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+// TrendingShowDTO is a show together with its trending score in the requested window.
+type TrendingShowDTO struct {
+	*ShowDTO
+	Score float64 `json:"score"`
+}
+
+var _ core.HTTPRoute = (*getTrendingShowsHandler)(nil)
+
+func NewGetTrendingShowsHandler(p GetTrendingShowsHandlerParams) *getTrendingShowsHandler {
+	return &getTrendingShowsHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getTrendingShowsHandler) Pattern() string {
+	return "GET /api/v1/shows/trending"
+}
+
+func (h *getTrendingShowsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the shows trending today ("?window=day", the default) or this week
+// ("?window=week"), from the scores precomputed by the show popularity job.
+func (h *getTrendingShowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+	column := trendingColumn(GetTrendingWindow(r))
+
+	var totalRows int64
+	if result := h.db.WithContext(reqCtx).
+		Model(&ShowPopularityModel{}).
+		Where(column + " > 0").
+		Count(&totalRows); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting total rows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	var popularities []ShowPopularityModel
+	if result := h.db.WithContext(reqCtx).
+		Preload("Show").
+		Where(column + " > 0").
+		Order(column + " DESC").
+		Scopes(core.Paginate(r)).
+		Find(&popularities); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting trending shows", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	trendingShowDTOs := lo.Map(popularities, func(popularity ShowPopularityModel, _ int) *TrendingShowDTO {
+		return &TrendingShowDTO{
+			ShowDTO: ToShowDTO(&popularity.Show),
+			Score:   lo.Ternary(column == trendingWeekScoreDef.column, popularity.TrendingWeek, popularity.TrendingDay),
+		}
+	})
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(trendingShowDTOs).Pagination(totalRows).Build())
+}
@@ -0,0 +1,92 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type getVideosHandler struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type GetVideosHandlerParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.HTTPRoute = (*getVideosHandler)(nil)
+
+func NewGetVideosHandler(p GetVideosHandlerParams) *getVideosHandler {
+	return &getVideosHandler{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (h *getVideosHandler) Pattern() string {
+	return "GET /api/v1/shows/{id}/videos"
+}
+
+func (h *getVideosHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP returns the videos of a show, sorted by preference for the languages of the request.
+// The "episodeId" query parameter returns the videos of one of its episodes instead.
+func (h *getVideosHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	db := h.db.WithContext(reqCtx)
+	if result := db.Select("id").First(&ShowModel{}, "id = ?", showID); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+			return
+		}
+
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	query := db.Where("show_id = ?", showID)
+	if episodeID, err := uuid.Parse(r.URL.Query().Get("episodeId")); err == nil {
+		query = query.Where("episode_id = ?", episodeID)
+	} else {
+		query = query.Where("episode_id IS NULL")
+	}
+
+	var videoModels []VideoModel
+	if result := query.Find(&videoModels); result.Error != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when getting the videos of a show", core.DetailsLogAttr(result.Error))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(ToVideoDTOs(videoModels, core.GetLanguages(r))).Build())
+}
@@ -0,0 +1,125 @@
+package showmgt
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultShowSuggestions is the number of suggestions returned when no limit is given.
+	defaultShowSuggestions = 10
+
+	// maxShowSuggestions is the maximum number of suggestions returned at once.
+	maxShowSuggestions = 20
+)
+
+type suggestShowsHandler struct {
+	logger      *slog.Logger
+	db          *gorm.DB
+	searchIndex SearchIndex
+}
+
+type SuggestShowsHandlerParams struct {
+	fx.In
+
+	Logger      *slog.Logger
+	DB          *gorm.DB
+	SearchIndex SearchIndex
+}
+
+var _ core.HTTPRoute = (*suggestShowsHandler)(nil)
+
+func NewSuggestShowsHandler(p SuggestShowsHandlerParams) *suggestShowsHandler {
+	return &suggestShowsHandler{
+		logger:      p.Logger,
+		db:          p.DB,
+		searchIndex: p.SearchIndex,
+	}
+}
+
+func (h *suggestShowsHandler) Pattern() string {
+	return "GET /api/v1/shows/suggest"
+}
+
+func (h *suggestShowsHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP suggests the shows whose original or translated title best matches the "q" query
+// parameter, with the locale and title that matched, using the configured search index. The
+// number of suggestions is set by the "limit" query parameter.
+func (h *suggestShowsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	text := strings.TrimSpace(r.URL.Query().Get("q"))
+	if text == "" {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data([]*ShowSuggestionDTO{}).Build())
+
+		return
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = defaultShowSuggestions
+	}
+
+	hits, err := h.searchIndex.Search(reqCtx, text, min(limit, maxShowSuggestions))
+	if err != nil {
+		h.logger.ErrorContext(reqCtx, "Something went wrong when suggesting shows", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+		return
+	}
+
+	suggestionDTOs := []*ShowSuggestionDTO{}
+
+	if len(hits) > 0 {
+		showIDs := lo.Map(hits, func(hit SearchHit, _ int) uuid.UUID {
+			return hit.ShowID
+		})
+
+		var showModels []ShowModel
+		if result := h.db.WithContext(reqCtx).Where("id IN ?", showIDs).Find(&showModels); result.Error != nil {
+			h.logger.ErrorContext(reqCtx, "Something went wrong when getting suggested shows",
+				core.DetailsLogAttr(result.Error))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+
+			return
+		}
+
+		showsByID := lo.KeyBy(showModels, func(showModel ShowModel) uuid.UUID {
+			return showModel.ID
+		})
+
+		// Hits of shows deleted since they have been indexed are dropped.
+		suggestionDTOs = lo.FilterMap(hits, func(hit SearchHit, _ int) (*ShowSuggestionDTO, bool) {
+			showModel, ok := showsByID[hit.ShowID]
+
+			return &ShowSuggestionDTO{
+				ID:            hit.ShowID,
+				Slug:          lo.FromPtr(showModel.Slug),
+				Kind:          showModel.Kind,
+				OriginalTitle: showModel.OriginalTitle,
+				MatchedLocale: hit.Locale,
+				MatchedTitle:  hit.Title,
+				Score:         hit.Score,
+			}, ok
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, responseBuilder.Data(suggestionDTOs).Build())
+}
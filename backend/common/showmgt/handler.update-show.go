@@ -0,0 +1,133 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type updateShowHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type UpdateShowHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*updateShowHandler)(nil)
+
+func NewUpdateShowHandler(p UpdateShowHandlerParams) *updateShowHandler {
+	return &updateShowHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *updateShowHandler) Pattern() string {
+	return "PUT /api/v1/shows/{id}"
+}
+
+func (h *updateShowHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP replaces the fields of a show with the ones of the request body. The keywords are
+// mapped to canonical keywords, which are created for the values matching none, and the slug is
+// regenerated when the title has changed.
+// The catalog events of the change (see DetectCatalogEvents) are published with it.
+func (h *updateShowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	var requestBody CreateShowRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	var showModel ShowModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Preload("Translations").First(&showModel, "id = ?", id); result.Error != nil {
+			return result.Error
+		}
+
+		keywords, err := ResolveOrCreateKeywords(tx, requestBody.Keywords)
+		if err != nil {
+			return err
+		}
+
+		previousShowModel := showModel
+		requestBody.applyTo(&showModel)
+		events := append(DetectCatalogEvents(&previousShowModel, &showModel, time.Now()),
+			CatalogEvent{Type: ShowChangedCatalogEvent, ShowID: showModel.ID, OccurredAt: time.Now()})
+		showModel.Keywords = KeywordNames(keywords)
+
+		if result := tx.Omit(clause.Associations).Save(&showModel); result.Error != nil {
+			return result.Error
+		}
+
+		if err = LinkShowKeywords(tx, &showModel, keywords); err != nil {
+			return err
+		}
+
+		if err = AssignShowSlugs(tx, &showModel); err != nil {
+			return err
+		}
+
+		return PublishCatalogEvents(tx, events...)
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(ToShowDTO(&showModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when updating a show", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
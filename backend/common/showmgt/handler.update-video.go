@@ -0,0 +1,125 @@
+package showmgt
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type updateVideoHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	config              core.AppConfig
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type UpdateVideoHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Config              core.AppConfig
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*updateVideoHandler)(nil)
+
+func NewUpdateVideoHandler(p UpdateVideoHandlerParams) *updateVideoHandler {
+	return &updateVideoHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		config:              p.Config,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *updateVideoHandler) Pattern() string {
+	return "PUT /api/v1/videos/{id}"
+}
+
+func (h *updateVideoHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP replaces the fields of a video with the ones of the request body.
+// The video stays attached to its show.
+func (h *updateVideoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgVideoNotFound).Build())
+
+		return
+	}
+
+	var requestBody VideoRequestBody
+	if err := render.DecodeJSON(r.Body, &requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if err := h.validator.Struct(requestBody); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	if err := ValidateVideoKey(requestBody.Site, requestBody.Key, h.config.GetVideoConfig().AllowedHosts); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInvalidVideoLink).Build())
+
+		return
+	}
+
+	var videoModel VideoModel
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.First(&videoModel, "id = ?", id); result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkShowEpisode(tx, videoModel.ShowID, requestBody.EpisodeID); err != nil {
+			return err
+		}
+
+		requestBody.applyTo(&videoModel)
+
+		return tx.Save(&videoModel).Error
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, responseBuilder.Data(ToVideoDTO(&videoModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgVideoNotFound).Build())
+	case errors.Is(err, ErrEpisodeNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgEpisodeNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when updating a video", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
@@ -0,0 +1,169 @@
+package showmgt
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"wano-island/common/core"
+
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+)
+
+type uploadSubtitlesHandler struct {
+	logger              *slog.Logger
+	db                  *gorm.DB
+	validator           *validator.Validate
+	universalTranslator *ut.UniversalTranslator
+}
+
+type UploadSubtitlesHandlerParams struct {
+	fx.In
+
+	Logger              *slog.Logger
+	DB                  *gorm.DB
+	Validator           *validator.Validate
+	UniversalTranslator *ut.UniversalTranslator
+}
+
+var _ core.HTTPRoute = (*uploadSubtitlesHandler)(nil)
+
+func NewUploadSubtitlesHandler(p UploadSubtitlesHandlerParams) *uploadSubtitlesHandler {
+	return &uploadSubtitlesHandler{
+		logger:              p.Logger,
+		db:                  p.DB,
+		validator:           p.Validator,
+		universalTranslator: p.UniversalTranslator,
+	}
+}
+
+func (h *uploadSubtitlesHandler) Pattern() string {
+	return "PUT /api/v1/shows/{id}/subtitles/{language}"
+}
+
+func (h *uploadSubtitlesHandler) IsPrivateRoute() bool {
+	return true
+}
+
+// ServeHTTP stores the SRT or WebVTT file sent as the request body as the subtitle track of a
+// movie in the given language, or of one of its episodes when the "episodeId" query parameter is
+// given. The format is detected from the content unless the "format" query parameter is given.
+// An existing track in the same language is replaced.
+func (h *uploadSubtitlesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqCtx := r.Context()
+	responseBuilder := core.NewResponseBuilder(r)
+
+	showID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+
+		return
+	}
+
+	var episodeID *uuid.UUID
+	if value := r.URL.Query().Get("episodeId"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgEpisodeNotFound).Build())
+
+			return
+		}
+
+		episodeID = &id
+	}
+
+	params := SubtitleUploadParams{
+		Language: r.PathValue("language"),
+		Format:   r.URL.Query().Get("format"),
+	}
+
+	if err := h.validator.Struct(params); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.
+			MessageID(core.MsgValidationFailed).
+			Data(core.TranslateValidationErrors(r, h.universalTranslator, err)).
+			Build())
+
+		return
+	}
+
+	content, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxSubtitleFileSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			render.Status(r, http.StatusRequestEntityTooLarge)
+			render.JSON(w, r, responseBuilder.MessageID(core.MsgSubtitleFileTooLarge).Build())
+
+			return
+		}
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgFailedToDecodeRequestBody).Build())
+
+		return
+	}
+
+	if params.Format == "" {
+		params.Format = DetectSubtitleFormat(content)
+	}
+
+	cues, err := ParseSubtitles(content, params.Format)
+	if err != nil {
+		var problems SubtitleProblems
+		errors.As(err, &problems)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInvalidSubtitleFile).Data(problems).Build())
+
+		return
+	}
+
+	subtitleModel := SubtitleModel{
+		ShowID:    showID,
+		EpisodeID: episodeID,
+		Language:  language.Make(params.Language).String(),
+		CueCount:  len(cues),
+		Content:   NormalizeSubtitles(cues),
+	}
+
+	var created bool
+
+	err = h.db.WithContext(reqCtx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Select("id").First(&ShowModel{}, "id = ?", showID); result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkShowEpisode(tx, showID, episodeID); err != nil {
+			return err
+		}
+
+		created, err = SaveSubtitleTrack(tx, &subtitleModel)
+
+		return err
+	})
+
+	switch {
+	case err == nil:
+		render.Status(r, lo.Ternary(created, http.StatusCreated, http.StatusOK))
+		render.JSON(w, r, responseBuilder.Data(ToSubtitleDTO(&subtitleModel)).Build())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgShowNotFound).Build())
+	case errors.Is(err, ErrEpisodeNotFound):
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgEpisodeNotFound).Build())
+	default:
+		h.logger.ErrorContext(reqCtx, "Something went wrong when saving a subtitle track", core.DetailsLogAttr(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, responseBuilder.MessageID(core.MsgInternalServerError).Build())
+	}
+}
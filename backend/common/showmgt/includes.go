@@ -0,0 +1,130 @@
+package showmgt
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// ShowInclude is a relation of the shows which can be expanded in the show responses with the
+// "include" query parameter.
+type ShowInclude string
+
+const (
+	TranslationsShowInclude        ShowInclude = "translations"
+	SeasonsShowInclude             ShowInclude = "seasons"
+	SeasonTranslationsShowInclude  ShowInclude = "seasons.translations"
+	EpisodesShowInclude            ShowInclude = "episodes"
+	EpisodeTranslationsShowInclude ShowInclude = "episodes.translations"
+)
+
+// maxShowIncludeDepth is the maximum number of relations followed by an include.
+const maxShowIncludeDepth = 2
+
+// showIncludePreloads maps the supported includes to their preloads. Every preload runs a single
+// query for all the shows of a page, so expanding a relation never costs a query per show.
+var showIncludePreloads = map[ShowInclude]func(db *gorm.DB) *gorm.DB{
+	TranslationsShowInclude: func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Translations", func(tx *gorm.DB) *gorm.DB { return tx.Order("locale") })
+	},
+	SeasonsShowInclude: func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Seasons", func(tx *gorm.DB) *gorm.DB { return tx.Order("\"order\"") })
+	},
+	SeasonTranslationsShowInclude: func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Seasons.Translations", func(tx *gorm.DB) *gorm.DB { return tx.Order("locale") })
+	},
+	EpisodesShowInclude: func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Episodes", func(tx *gorm.DB) *gorm.DB { return tx.Order("\"order\"") }).
+			Preload("Episodes.Subtitles", func(tx *gorm.DB) *gorm.DB { return tx.Select("id", "episode_id", "language") })
+	},
+	EpisodeTranslationsShowInclude: func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Episodes.Translations", func(tx *gorm.DB) *gorm.DB { return tx.Order("locale") })
+	},
+}
+
+// GetShowIncludes reads the relations to expand from the "include" query parameter
+// (e.g. "?include=seasons.translations,episodes"). A nested include expands its parents too.
+// Unknown includes and includes nested deeper than maxShowIncludeDepth are ignored.
+func GetShowIncludes(r *http.Request) []ShowInclude {
+	var includes []ShowInclude
+
+	for _, name := range strings.Split(r.URL.Query().Get("include"), ",") {
+		segments := strings.Split(strings.TrimSpace(name), ".")
+		if len(segments) > maxShowIncludeDepth {
+			continue
+		}
+
+		include := ShowInclude(strings.Join(segments, "."))
+		if _, supported := showIncludePreloads[include]; !supported {
+			continue
+		}
+
+		for i := 1; i < len(segments); i++ {
+			includes = append(includes, ShowInclude(strings.Join(segments[:i], ".")))
+		}
+
+		includes = append(includes, include)
+	}
+
+	includes = lo.Uniq(includes)
+	slices.Sort(includes)
+
+	return includes
+}
+
+// PreloadShowIncludes adds the preloads of the includes to the query.
+func PreloadShowIncludes(db *gorm.DB, includes []ShowInclude) *gorm.DB {
+	for _, include := range includes {
+		db = showIncludePreloads[include](db)
+	}
+
+	return db
+}
+
+// ExpandShowDTO adds the included relations of the show, which must have been preloaded with
+// PreloadShowIncludes, to its DTO.
+func ExpandShowDTO(showDTO *ShowDTO, showModel *ShowModel, includes []ShowInclude) *ShowDTO {
+	if slices.Contains(includes, TranslationsShowInclude) {
+		showDTO.Translations = lo.ToPtr(lo.Map(showModel.Translations, func(translation ShowTranslationModel, _ int) *TranslationDTO {
+			return &TranslationDTO{
+				Locale:   translation.Locale,
+				Title:    translation.Title,
+				Overview: translation.Overview,
+				Slug:     translation.Slug,
+			}
+		}))
+	}
+
+	if slices.Contains(includes, SeasonsShowInclude) {
+		showDTO.Seasons = lo.ToPtr(lo.Map(showModel.Seasons, func(seasonModel SeasonModel, _ int) *SeasonDTO {
+			seasonDTO := ToSeasonDTO(&seasonModel)
+
+			if slices.Contains(includes, SeasonTranslationsShowInclude) {
+				seasonDTO.Translations = lo.ToPtr(lo.Map(seasonModel.Translations, func(translation SeasonTranslationModel, _ int) *TranslationDTO {
+					return &TranslationDTO{Locale: translation.Locale, Title: translation.Title, Overview: translation.Overview}
+				}))
+			}
+
+			return seasonDTO
+		}))
+	}
+
+	if slices.Contains(includes, EpisodesShowInclude) {
+		showDTO.Episodes = lo.ToPtr(lo.Map(showModel.Episodes, func(episodeModel EpisodeModel, _ int) *EpisodeDTO {
+			episodeDTO := ToEpisodeDTO(&episodeModel)
+
+			if slices.Contains(includes, EpisodeTranslationsShowInclude) {
+				episodeDTO.Translations = lo.ToPtr(lo.Map(episodeModel.Translations, func(translation EpisodeTranslationModel, _ int) *TranslationDTO {
+					return &TranslationDTO{Locale: translation.Locale, Title: translation.Title, Overview: translation.Overview}
+				}))
+			}
+
+			return episodeDTO
+		}))
+	}
+
+	return showDTO
+}
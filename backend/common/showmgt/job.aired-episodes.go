@@ -0,0 +1,88 @@
+package showmgt
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// airedEpisodesJobInterval is the duration between two runs of the aired episodes job.
+const airedEpisodesJobInterval = time.Hour
+
+// airedEpisodesJob publishes the NewEpisodeCatalogEvent of the shows whose last air date was in
+// the future when it was set, once it has been reached.
+type airedEpisodesJob struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type AiredEpisodesJobParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.ScheduledJob = (*airedEpisodesJob)(nil)
+
+func NewAiredEpisodesJob(p AiredEpisodesJobParams) *airedEpisodesJob {
+	return &airedEpisodesJob{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (j *airedEpisodesJob) Name() string {
+	return "aired-episodes"
+}
+
+func (j *airedEpisodesJob) Interval() time.Duration {
+	return airedEpisodesJobInterval
+}
+
+// Run marks the reached air dates as announced and publishes their events in a transaction holding
+// an advisory lock, so a single replica publishes each event.
+func (j *airedEpisodesJob) Run(ctx context.Context) error {
+	now := time.Now()
+
+	var airedShows []ShowModel
+
+	if err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if result := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", j.Name()).
+			Scan(&locked); result.Error != nil {
+			return result.Error
+		}
+
+		if !locked {
+			return nil
+		}
+
+		if result := tx.Model(&airedShows).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "last_air_date"}}}).
+			Where("last_air_date <= ? AND (announced_air_date IS NULL OR last_air_date > announced_air_date)", now).
+			UpdateColumn("announced_air_date", gorm.Expr("last_air_date")); result.Error != nil {
+			return result.Error
+		}
+
+		return PublishCatalogEvents(tx, lo.Map(airedShows, func(show ShowModel, _ int) CatalogEvent {
+			return CatalogEvent{Type: NewEpisodeCatalogEvent, ShowID: show.ID, OccurredAt: now, AirDate: show.LastAirDate}
+		})...)
+	}); err != nil {
+		return err
+	}
+
+	if len(airedShows) == 0 {
+		return nil
+	}
+
+	j.logger.InfoContext(ctx, "The aired episodes have been announced", slog.Int("shows", len(airedShows)))
+
+	return nil
+}
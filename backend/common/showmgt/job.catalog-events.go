@@ -0,0 +1,92 @@
+package showmgt
+
+import (
+	"context"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// catalogEventsJobInterval is the duration between two deliveries of the published catalog events.
+	catalogEventsJobInterval = 10 * time.Second
+
+	// catalogEventsBatchSize is the number of catalog events delivered at once.
+	catalogEventsBatchSize = 500
+)
+
+// catalogEventsJob delivers the catalog events stored by PublishCatalogEvents to the listeners,
+// in the order they have been published, and deletes them.
+type catalogEventsJob struct {
+	db     *gorm.DB
+	events *CatalogEvents
+}
+
+type CatalogEventsJobParams struct {
+	fx.In
+
+	DB     *gorm.DB
+	Events *CatalogEvents
+}
+
+var _ core.ScheduledJob = (*catalogEventsJob)(nil)
+
+func NewCatalogEventsJob(p CatalogEventsJobParams) *catalogEventsJob {
+	return &catalogEventsJob{
+		db:     p.DB,
+		events: p.Events,
+	}
+}
+
+func (j *catalogEventsJob) Name() string {
+	return "catalog-events"
+}
+
+func (j *catalogEventsJob) Interval() time.Duration {
+	return catalogEventsJobInterval
+}
+
+// Run delivers the events in a transaction holding an advisory lock, so a single replica delivers
+// each event. The events are deleted with the transaction, so the events of an interrupted run are
+// delivered again by the next one.
+func (j *catalogEventsJob) Run(ctx context.Context) error {
+	return j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if result := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", j.Name()).
+			Scan(&locked); result.Error != nil {
+			return result.Error
+		}
+
+		if !locked {
+			return nil
+		}
+
+		for ctx.Err() == nil {
+			var eventModels []CatalogEventModel
+			if result := tx.Order("id").Limit(catalogEventsBatchSize).Find(&eventModels); result.Error != nil {
+				return result.Error
+			}
+
+			if len(eventModels) == 0 {
+				return nil
+			}
+
+			j.events.Dispatch(ctx, lo.Map(eventModels, func(eventModel CatalogEventModel, _ int) CatalogEvent {
+				return ToCatalogEvent(&eventModel)
+			})...)
+
+			if result := tx.Delete(&eventModels); result.Error != nil {
+				return result.Error
+			}
+
+			if len(eventModels) < catalogEventsBatchSize {
+				return nil
+			}
+		}
+
+		return ctx.Err()
+	})
+}
@@ -0,0 +1,121 @@
+package showmgt
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+	"wano-island/common/core"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// dataQualityJobInterval is the duration between two checks of the data quality job. A
+	// snapshot is only taken when there is none for the current day.
+	dataQualityJobInterval = time.Hour
+
+	// dataQualitySnapshotRetention is how long the data quality snapshots are kept.
+	dataQualitySnapshotRetention = 30 * 24 * time.Hour
+
+	// dataQualityIssuesInsertSize is the number of issues inserted per statement when storing a snapshot.
+	dataQualityIssuesInsertSize = 500
+)
+
+// dataQualityJob stores a snapshot of the data quality report once a day (UTC) and removes the
+// snapshots older than dataQualitySnapshotRetention.
+type dataQualityJob struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type DataQualityJobParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.ScheduledJob = (*dataQualityJob)(nil)
+
+func NewDataQualityJob(p DataQualityJobParams) *dataQualityJob {
+	return &dataQualityJob{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (j *dataQualityJob) Name() string {
+	return "data-quality"
+}
+
+func (j *dataQualityJob) Interval() time.Duration {
+	return dataQualityJobInterval
+}
+
+// Run stores a snapshot in a transaction holding an advisory lock, so a single replica does the
+// work when several run the job at the same time, and the day is not snapshotted twice.
+func (j *dataQualityJob) Run(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	var snapshot *DataQualitySnapshotModel
+
+	if err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if result := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", j.Name()).
+			Scan(&locked); result.Error != nil {
+			return result.Error
+		}
+
+		if !locked {
+			return nil
+		}
+
+		var lastGeneratedAt sql.NullTime
+		if result := tx.Model(&DataQualitySnapshotModel{}).
+			Select("MAX(generated_at)").
+			Scan(&lastGeneratedAt); result.Error != nil {
+			return result.Error
+		}
+
+		if lastGeneratedAt.Valid && !lastGeneratedAt.Time.UTC().Before(now.Truncate(24*time.Hour)) {
+			return nil
+		}
+
+		report, err := LoadDataQualityReport(ctx, tx, now)
+		if err != nil {
+			return err
+		}
+
+		snapshot = NewDataQualitySnapshot(report)
+
+		if result := tx.Omit("Issues").Create(snapshot); result.Error != nil {
+			return result.Error
+		}
+
+		for i := range snapshot.Issues {
+			snapshot.Issues[i].SnapshotID = snapshot.ID
+		}
+
+		if len(snapshot.Issues) > 0 {
+			if result := tx.CreateInBatches(snapshot.Issues, dataQualityIssuesInsertSize); result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return tx.Where("generated_at < ?", now.Add(-dataQualitySnapshotRetention)).
+			Delete(&DataQualitySnapshotModel{}).Error
+	}); err != nil {
+		return err
+	}
+
+	if snapshot == nil {
+		return nil
+	}
+
+	j.logger.InfoContext(ctx, "A data quality snapshot has been stored",
+		slog.Int("issues", len(snapshot.Issues)))
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package showmgt
+
+import (
+	"context"
+	"time"
+	"wano-island/common/core"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// showActivityJobInterval is the duration between two writes of the buffered show activity.
+const showActivityJobInterval = 30 * time.Second
+
+// showActivityJob writes the activity buffered by the ShowActivityRecorder.
+type showActivityJob struct {
+	db       *gorm.DB
+	recorder *ShowActivityRecorder
+}
+
+type ShowActivityJobParams struct {
+	fx.In
+
+	DB       *gorm.DB
+	Recorder *ShowActivityRecorder
+}
+
+var _ core.ScheduledJob = (*showActivityJob)(nil)
+
+func NewShowActivityJob(p ShowActivityJobParams) *showActivityJob {
+	return &showActivityJob{
+		db:       p.DB,
+		recorder: p.Recorder,
+	}
+}
+
+func (j *showActivityJob) Name() string {
+	return "show-activity"
+}
+
+func (j *showActivityJob) Interval() time.Duration {
+	return showActivityJobInterval
+}
+
+// Run writes the buffer in a transaction holding an advisory lock, so the replicas do not write
+// the same counts concurrently. A replica which does not get the lock keeps its buffer for the
+// next run.
+func (j *showActivityJob) Run(ctx context.Context) error {
+	return j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if result := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", j.Name()).
+			Scan(&locked); result.Error != nil {
+			return result.Error
+		}
+
+		if !locked {
+			return nil
+		}
+
+		return j.recorder.flush(tx)
+	})
+}
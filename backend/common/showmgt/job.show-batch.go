@@ -0,0 +1,84 @@
+package showmgt
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// showBatchJobInterval is the duration between two checks for batch jobs to run.
+const showBatchJobInterval = 5 * time.Second
+
+// showBatchJob is the batch job worker: it runs the pending and interrupted batch jobs, one at a
+// time, in the order they have been created. Every instance of the application runs a worker, and
+// each job is claimed by a single worker (see ClaimBatchJob).
+type showBatchJob struct {
+	logger *slog.Logger
+	db     *gorm.DB
+	worker string
+}
+
+type ShowBatchJobParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.ScheduledJob = (*showBatchJob)(nil)
+
+func NewShowBatchJob(p ShowBatchJobParams) *showBatchJob {
+	return &showBatchJob{
+		logger: p.Logger,
+		db:     p.DB,
+		worker: uuid.NewString(),
+	}
+}
+
+func (j *showBatchJob) Name() string {
+	return "show-batch"
+}
+
+func (j *showBatchJob) Interval() time.Duration {
+	return showBatchJobInterval
+}
+
+func (j *showBatchJob) Run(ctx context.Context) error {
+	db := j.db.WithContext(ctx)
+
+	for ctx.Err() == nil {
+		jobModel, err := ClaimBatchJob(db, j.worker)
+		if err != nil {
+			return err
+		}
+
+		if jobModel == nil {
+			return nil
+		}
+
+		err = RunBatchJob(ctx, db, jobModel)
+		if errors.Is(err, ErrBatchJobLost) {
+			j.logger.WarnContext(ctx, "A batch job has been claimed by another worker",
+				slog.String("id", jobModel.ID.String()))
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		j.logger.InfoContext(ctx, "A batch job has been run",
+			slog.String("id", jobModel.ID.String()),
+			slog.String("operation", jobModel.Operation),
+			slog.String("status", jobModel.Status))
+	}
+
+	return nil
+}
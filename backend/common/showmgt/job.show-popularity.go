@@ -0,0 +1,80 @@
+package showmgt
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wano-island/common/core"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// showPopularityJobInterval is the duration between two runs of the show popularity job.
+const showPopularityJobInterval = 15 * time.Minute
+
+// showPopularityJob recomputes the popularity and trending scores of the shows from their
+// recorded activity, and stores them in the public.show_popularities table.
+type showPopularityJob struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type ShowPopularityJobParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.ScheduledJob = (*showPopularityJob)(nil)
+
+func NewShowPopularityJob(p ShowPopularityJobParams) *showPopularityJob {
+	return &showPopularityJob{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (j *showPopularityJob) Name() string {
+	return "show-popularity"
+}
+
+func (j *showPopularityJob) Interval() time.Duration {
+	return showPopularityJobInterval
+}
+
+// Run recomputes the scores in a transaction holding an advisory lock, so a single replica does
+// the work when several run the job at the same time.
+func (j *showPopularityJob) Run(ctx context.Context) error {
+	var (
+		locked      bool
+		scoredShows int64
+	)
+
+	if err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", j.Name()).
+			Scan(&locked); result.Error != nil {
+			return result.Error
+		}
+
+		if !locked {
+			return nil
+		}
+
+		var err error
+		scoredShows, err = ComputeShowPopularities(tx, time.Now())
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if !locked {
+		return nil
+	}
+
+	j.logger.InfoContext(ctx, "Show popularities have been recomputed", slog.Int64("shows", scoredShows))
+
+	return nil
+}
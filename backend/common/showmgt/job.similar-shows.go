@@ -0,0 +1,314 @@
+package showmgt
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+	"time"
+	"wano-island/common/core"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxSimilarShows is the maximum number of similar shows which are stored per show.
+	MaxSimilarShows = 20
+
+	// similarShowsJobInterval is the duration between two runs of the similar shows job.
+	similarShowsJobInterval = 5 * time.Minute
+
+	// similarShowsBatchSize is the number of rows inserted per statement when storing the results.
+	similarShowsBatchSize = 500
+)
+
+// similarShowsJob precomputes the similar shows of every show and stores them in the
+// public.show_similarities table. Only the shows created or updated since the last computation,
+// which is the latest computed_at of the table, and the shows they affect are recomputed.
+type similarShowsJob struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+type SimilarShowsJobParams struct {
+	fx.In
+
+	Logger *slog.Logger
+	DB     *gorm.DB
+}
+
+var _ core.ScheduledJob = (*similarShowsJob)(nil)
+
+func NewSimilarShowsJob(p SimilarShowsJobParams) *similarShowsJob {
+	return &similarShowsJob{
+		logger: p.Logger,
+		db:     p.DB,
+	}
+}
+
+func (j *similarShowsJob) Name() string {
+	return "similar-shows"
+}
+
+func (j *similarShowsJob) Interval() time.Duration {
+	return similarShowsJobInterval
+}
+
+// Run recomputes the similar shows in a transaction holding an advisory lock, so a single replica
+// does the work when several run the job at the same time.
+func (j *similarShowsJob) Run(ctx context.Context) error {
+	return j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if result := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", j.Name()).
+			Scan(&locked); result.Error != nil {
+			return result.Error
+		}
+
+		if !locked {
+			return nil
+		}
+
+		var lastComputedAt sql.NullTime
+		if result := tx.Model(&ShowSimilarityModel{}).
+			Select("MAX(computed_at)").
+			Scan(&lastComputedAt); result.Error != nil {
+			return result.Error
+		}
+
+		computedAt := time.Now()
+
+		changedShows := tx.Model(&ShowModel{})
+		if lastComputedAt.Valid {
+			changedShows = changedShows.Where("updated_at > ?", lastComputedAt.Time)
+		}
+
+		var changedIDs []uuid.UUID
+		if result := changedShows.Pluck("id", &changedIDs); result.Error != nil {
+			return result.Error
+		}
+
+		if len(changedIDs) == 0 {
+			return nil
+		}
+
+		var shows []ShowModel
+		if result := tx.Select("id", "original_language", "keywords").Find(&shows); result.Error != nil {
+			return result.Error
+		}
+
+		if !lastComputedAt.Valid {
+			return j.rebuild(ctx, tx, shows, computedAt)
+		}
+
+		return j.update(ctx, tx, shows, changedIDs, computedAt)
+	})
+}
+
+// rebuild replaces the whole table, when no similar shows have been computed yet.
+func (j *similarShowsJob) rebuild(ctx context.Context, tx *gorm.DB, shows []ShowModel, computedAt time.Time) error {
+	similarities := ComputeSimilarShows(shows, computedAt)
+
+	if result := tx.Where("1 = 1").Delete(&ShowSimilarityModel{}); result.Error != nil {
+		return result.Error
+	}
+
+	if len(similarities) > 0 {
+		if result := tx.Omit("Show", "SimilarShow").
+			CreateInBatches(similarities, similarShowsBatchSize); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	j.logger.InfoContext(ctx, "Similar shows have been computed",
+		slog.Int("shows", len(shows)),
+		slog.Int("similarities", len(similarities)))
+
+	return nil
+}
+
+// update replaces the similar shows of the shows affected by the changed shows.
+func (j *similarShowsJob) update(
+	ctx context.Context,
+	tx *gorm.DB,
+	shows []ShowModel,
+	changedIDs []uuid.UUID,
+	computedAt time.Time,
+) error {
+	var staleIDs []uuid.UUID
+	for _, changedIDsChunk := range lo.Chunk(changedIDs, similarShowsBatchSize) {
+		var chunkStaleIDs []uuid.UUID
+		if result := tx.Model(&ShowSimilarityModel{}).
+			Distinct("show_id").
+			Where("similar_show_id IN ?", changedIDsChunk).
+			Pluck("show_id", &chunkStaleIDs); result.Error != nil {
+			return result.Error
+		}
+
+		staleIDs = append(staleIDs, chunkStaleIDs...)
+	}
+
+	affected, err := ComputeChangedSimilarShows(shows, changedIDs, staleIDs, func(showIDs []uuid.UUID) ([]ShowSimilarityModel, error) {
+		var stored []ShowSimilarityModel
+		for _, showIDsChunk := range lo.Chunk(showIDs, similarShowsBatchSize) {
+			var chunkStored []ShowSimilarityModel
+			if result := tx.Where("show_id IN ?", showIDsChunk).Find(&chunkStored); result.Error != nil {
+				return nil, result.Error
+			}
+
+			stored = append(stored, chunkStored...)
+		}
+
+		return stored, nil
+	}, computedAt)
+	if err != nil {
+		return err
+	}
+
+	affectedIDs := lo.Keys(affected)
+	for _, affectedIDsChunk := range lo.Chunk(affectedIDs, similarShowsBatchSize) {
+		if result := tx.Where("show_id IN ?", affectedIDsChunk).
+			Delete(&ShowSimilarityModel{}); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	similarities := lo.Flatten(lo.Values(affected))
+	if len(similarities) > 0 {
+		if result := tx.Omit("Show", "SimilarShow").
+			CreateInBatches(similarities, similarShowsBatchSize); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	j.logger.InfoContext(ctx, "Similar shows have been updated",
+		slog.Int("changedShows", len(changedIDs)),
+		slog.Int("affectedShows", len(affectedIDs)),
+		slog.Int("similarities", len(similarities)))
+
+	return nil
+}
+
+// ComputeSimilarShows scores every pair of the given shows and keeps, for each show,
+// the MaxSimilarShows shows with the highest positive score.
+func ComputeSimilarShows(shows []ShowModel, computedAt time.Time) []ShowSimilarityModel {
+	similarities := []ShowSimilarityModel{}
+
+	for i := range shows {
+		similarities = append(similarities, scoreSimilarShows(&shows[i], shows, computedAt)...)
+	}
+
+	return similarities
+}
+
+// ComputeChangedSimilarShows recomputes, keyed by show ID, the similar shows of the changed shows
+// and of the shows they affect:
+//   - the changed shows and the stale shows, whose stored similar shows include a changed show,
+//     are scored against every show;
+//   - the other shows scoring positively with a changed show merge it into their stored similar
+//     shows, which loadStored returns. Scores are symmetric, so the other shows are unaffected.
+func ComputeChangedSimilarShows(
+	shows []ShowModel,
+	changedIDs []uuid.UUID,
+	staleIDs []uuid.UUID,
+	loadStored func(showIDs []uuid.UUID) ([]ShowSimilarityModel, error),
+	computedAt time.Time,
+) (map[uuid.UUID][]ShowSimilarityModel, error) {
+	showsByID := lo.KeyBy(shows, func(show ShowModel) uuid.UUID { return show.ID })
+	affected := map[uuid.UUID][]ShowSimilarityModel{}
+
+	for _, showID := range lo.Union(changedIDs, staleIDs) {
+		if show, ok := showsByID[showID]; ok {
+			affected[showID] = scoreSimilarShows(&show, shows, computedAt)
+		}
+	}
+
+	merged := map[uuid.UUID][]ShowSimilarityModel{}
+
+	for _, changedID := range changedIDs {
+		changedShow, ok := showsByID[changedID]
+		if !ok {
+			continue
+		}
+
+		for i := range shows {
+			if _, recomputed := affected[shows[i].ID]; recomputed {
+				continue
+			}
+
+			if score := ScoreSimilarity(&shows[i], &changedShow); score > 0 {
+				merged[shows[i].ID] = append(merged[shows[i].ID], ShowSimilarityModel{
+					ShowID:        shows[i].ID,
+					SimilarShowID: changedID,
+					Score:         score,
+				})
+			}
+		}
+	}
+
+	if len(merged) == 0 {
+		return affected, nil
+	}
+
+	stored, err := loadStored(lo.Keys(merged))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, similarity := range stored {
+		merged[similarity.ShowID] = append(merged[similarity.ShowID], similarity)
+	}
+
+	for showID, candidates := range merged {
+		affected[showID] = keepMostSimilarShows(candidates, computedAt)
+	}
+
+	return affected, nil
+}
+
+// scoreSimilarShows scores the show against the given shows and keeps the MaxSimilarShows shows
+// with the highest positive score.
+func scoreSimilarShows(show *ShowModel, shows []ShowModel, computedAt time.Time) []ShowSimilarityModel {
+	candidates := []ShowSimilarityModel{}
+
+	for i := range shows {
+		if shows[i].ID == show.ID {
+			continue
+		}
+
+		score := ScoreSimilarity(show, &shows[i])
+		if score <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, ShowSimilarityModel{
+			ShowID:        show.ID,
+			SimilarShowID: shows[i].ID,
+			Score:         score,
+		})
+	}
+
+	return keepMostSimilarShows(candidates, computedAt)
+}
+
+// keepMostSimilarShows orders the candidates by score and keeps the MaxSimilarShows first ones,
+// computed at the given time.
+func keepMostSimilarShows(candidates []ShowSimilarityModel, computedAt time.Time) []ShowSimilarityModel {
+	slices.SortStableFunc(candidates, func(a, b ShowSimilarityModel) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return lo.Map(candidates[:min(len(candidates), MaxSimilarShows)], func(similarity ShowSimilarityModel, _ int) ShowSimilarityModel {
+		similarity.ComputedAt = computedAt
+		return similarity
+	})
+}
@@ -0,0 +1,246 @@
+package showmgt
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"wano-island/common/core"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NormalizeKeyword returns the normalized form of a keyword, used as canonical name and to look
+// keywords up: full-width and compatibility characters are folded (NFKC), letters are lowercased
+// and whitespace is collapsed (e.g. " Ａnime  Movie" becomes "anime movie").
+func NormalizeKeyword(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(norm.NFKC.String(value))), " ")
+}
+
+// NormalizeKeywords normalizes the given keywords, dropping empty values and duplicates.
+func NormalizeKeywords(values []string) []string {
+	return lo.Compact(lo.Uniq(lo.Map(values, func(value string, _ int) string {
+		return NormalizeKeyword(value)
+	})))
+}
+
+// keywordMatch is a value matching the name or a synonym of a canonical keyword.
+type keywordMatch struct {
+	Value     string
+	KeywordID uuid.UUID
+	Name      string
+}
+
+// UnknownKeywordsError is returned when values match no canonical keyword. New keywords are added
+// to the vocabulary with the create keyword endpoint.
+type UnknownKeywordsError struct {
+	// The normalized values which match no keyword.
+	Values []string
+}
+
+func (e *UnknownKeywordsError) Error() string {
+	return "unknown keywords: " + strings.Join(e.Values, ", ")
+}
+
+// ResolveKeywords maps the given free-form values to canonical keywords, through their name or
+// one of their synonyms. Values which match no keyword are rejected with an UnknownKeywordsError.
+// The keywords are returned in the order of the values, without duplicates.
+func ResolveKeywords(tx *gorm.DB, values []string) ([]KeywordModel, error) {
+	normalizedValues := NormalizeKeywords(values)
+	if len(normalizedValues) == 0 {
+		return []KeywordModel{}, nil
+	}
+
+	var matches []keywordMatch
+	if result := tx.Raw(`SELECT name AS value, id AS keyword_id, name FROM public.keywords WHERE name IN (?)
+		UNION SELECT keyword_synonyms.synonym, keywords.id, keywords.name FROM public.keyword_synonyms
+		JOIN public.keywords ON keywords.id = keyword_synonyms.keyword_id WHERE keyword_synonyms.synonym IN (?)`,
+		normalizedValues, normalizedValues).
+		Scan(&matches); result.Error != nil {
+		return nil, result.Error
+	}
+
+	matchesByValue := lo.KeyBy(matches, func(match keywordMatch) string {
+		return match.Value
+	})
+
+	if unknownValues := lo.Reject(normalizedValues, func(value string, _ int) bool {
+		return lo.HasKey(matchesByValue, value)
+	}); len(unknownValues) > 0 {
+		return nil, &UnknownKeywordsError{Values: unknownValues}
+	}
+
+	keywords := lo.Map(normalizedValues, func(value string, _ int) KeywordModel {
+		match := matchesByValue[value]
+
+		return KeywordModel{Model: core.Model{ID: match.KeywordID}, Name: match.Name}
+	})
+
+	return lo.UniqBy(keywords, func(keyword KeywordModel) uuid.UUID {
+		return keyword.ID
+	}), nil
+}
+
+// ResolveOrCreateKeywords maps the given values to canonical keywords like ResolveKeywords, but
+// creates a canonical keyword for every value which matches none. It is meant to import existing
+// values into the vocabulary. A keyword created concurrently with the same name is reused.
+func ResolveOrCreateKeywords(tx *gorm.DB, values []string) ([]KeywordModel, error) {
+	keywords, err := ResolveKeywords(tx, values)
+
+	var unknownKeywordsErr *UnknownKeywordsError
+	if !errors.As(err, &unknownKeywordsErr) {
+		return keywords, err
+	}
+
+	newKeywords := lo.Map(unknownKeywordsErr.Values, func(value string, _ int) KeywordModel {
+		return KeywordModel{Name: value}
+	})
+
+	if result := tx.Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&newKeywords); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ResolveKeywords(tx, values)
+}
+
+// KeywordNames returns the canonical names of the given keywords.
+func KeywordNames(keywords []KeywordModel) pq.StringArray {
+	return lo.Map(keywords, func(keyword KeywordModel, _ int) string {
+		return keyword.Name
+	})
+}
+
+// LinkShowKeywords replaces the keywords linked to the show. The show must already exist in the
+// database and its Keywords array should hold the names of the given keywords.
+func LinkShowKeywords(tx *gorm.DB, show *ShowModel, keywords []KeywordModel) error {
+	if result := tx.Where("show_id = ?", show.ID).Delete(&ShowKeywordModel{}); result.Error != nil {
+		return result.Error
+	}
+
+	if len(keywords) == 0 {
+		return nil
+	}
+
+	showKeywords := lo.Map(keywords, func(keyword KeywordModel, position int) ShowKeywordModel {
+		return ShowKeywordModel{ShowID: show.ID, KeywordID: keyword.ID, Position: position}
+	})
+
+	return tx.Omit("Keyword").Create(&showKeywords).Error
+}
+
+// SyncShowKeywordArrays rewrites the Keywords array of the shows linked to the given keyword, so
+// that it keeps matching the canonical names after the keyword has been renamed.
+func SyncShowKeywordArrays(tx *gorm.DB, keywordID uuid.UUID) error {
+	return tx.Exec(`UPDATE public.shows SET keywords = ARRAY(
+		SELECT keywords.name FROM public.show_keywords
+		JOIN public.keywords ON keywords.id = show_keywords.keyword_id
+		WHERE show_keywords.show_id = shows.id ORDER BY show_keywords.position
+	) WHERE id IN (SELECT show_id FROM public.show_keywords WHERE keyword_id = ?)`, keywordID).Error
+}
+
+// ErrKeywordConflict is returned when the name or a synonym of a keyword is already used by
+// another keyword.
+var ErrKeywordConflict = errors.New("the keyword name or one of its synonyms is already used")
+
+// KeywordRequestBody holds the request body for creating or updating a keyword.
+type KeywordRequestBody struct {
+	// The canonical name of the keyword, normalized before being saved.
+	Name string `json:"name" validate:"required"`
+
+	// The labels of the keyword by locale (e.g. {"ja": "アニメ"}).
+	Labels map[string]string `json:"labels"`
+
+	// Other values which should resolve to the keyword.
+	Synonyms []string `json:"synonyms"`
+}
+
+// SaveKeyword creates or updates the keyword from the request body, replacing its labels and
+// synonyms. The labels are also registered as synonyms, so that a localized value resolves to the
+// keyword. When the keyword is renamed, the Keywords array of the linked shows is kept in sync.
+func SaveKeyword(tx *gorm.DB, keyword *KeywordModel, body *KeywordRequestBody) error {
+	name := NormalizeKeyword(body.Name)
+	previousName := keyword.Name
+
+	labels := lo.MapToSlice(body.Labels, func(locale string, label string) KeywordLabelModel {
+		return KeywordLabelModel{Locale: locale, Label: strings.TrimSpace(label)}
+	})
+	slices.SortFunc(labels, func(a, b KeywordLabelModel) int {
+		return strings.Compare(a.Locale, b.Locale)
+	})
+
+	synonyms := lo.Without(NormalizeKeywords(slices.Concat(body.Synonyms, lo.Map(labels,
+		func(label KeywordLabelModel, _ int) string {
+			return label.Label
+		}))), name)
+	values := append([]string{name}, synonyms...)
+
+	var conflicts int64
+	if result := tx.Model(&KeywordModel{}).
+		Where("id <> ? AND (name IN (?) OR id IN (?))", keyword.ID, values,
+			tx.Model(&KeywordSynonymModel{}).Select("keyword_id").Where("synonym IN (?)", values)).
+		Count(&conflicts); result.Error != nil {
+		return result.Error
+	}
+
+	if conflicts > 0 {
+		return ErrKeywordConflict
+	}
+
+	keyword.Name = name
+
+	if keyword.ID == uuid.Nil {
+		result := tx.Omit("Labels", "Synonyms").Clauses(clause.OnConflict{DoNothing: true}).Create(keyword)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// The name has been taken by a keyword created concurrently.
+		if result.RowsAffected == 0 {
+			return ErrKeywordConflict
+		}
+	} else if result := tx.Omit("Labels", "Synonyms").Save(keyword); result.Error != nil {
+		return result.Error
+	}
+
+	keyword.Labels = lo.Map(labels, func(label KeywordLabelModel, _ int) KeywordLabelModel {
+		label.KeywordID = keyword.ID
+
+		return label
+	})
+
+	keyword.Synonyms = lo.Map(synonyms, func(synonym string, _ int) KeywordSynonymModel {
+		return KeywordSynonymModel{KeywordID: keyword.ID, Synonym: synonym}
+	})
+
+	if result := tx.Where("keyword_id = ?", keyword.ID).Delete(&KeywordLabelModel{}); result.Error != nil {
+		return result.Error
+	}
+
+	if result := tx.Where("keyword_id = ?", keyword.ID).Delete(&KeywordSynonymModel{}); result.Error != nil {
+		return result.Error
+	}
+
+	if len(keyword.Labels) > 0 {
+		if result := tx.Create(&keyword.Labels); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	if len(keyword.Synonyms) > 0 {
+		if result := tx.Create(&keyword.Synonyms); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	if previousName != "" && previousName != name {
+		return SyncShowKeywordArrays(tx, keyword.ID)
+	}
+
+	return nil
+}
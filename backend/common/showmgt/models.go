@@ -1,12 +1,21 @@
 package showmgt
 
 import (
+	"time"
 	"wano-island/common/core"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
+// Production statuses of a show.
+const (
+	RumoredShowStatus      = "rumored"
+	InProductionShowStatus = "in_production"
+	EndedShowStatus        = "ended"
+	ReturningShowStatus    = "returning"
+)
+
 type ShowModel struct {
 	core.Model
 	core.HasCreatedAtColumn
@@ -15,11 +24,25 @@ type ShowModel struct {
 	Kind             string                 `gorm:"type:string;size:7;not null"`
 	OriginalLanguage string                 `gorm:"type:string;size:256;not null"`
 	OriginalTitle    string                 `gorm:"type:string;size:256;not null"`
-	OriginalOverview *string                `gorm:"type:string;size:256"`
+	Slug             *string                `gorm:"type:string;size:256;uniqueIndex"`
+	OriginalOverview *string                `gorm:"type:text"`
+	Tagline          *string                `gorm:"type:string;size:256"`
 	Keywords         pq.StringArray         `gorm:"type:text[]"`
 	IsReleased       bool                   `gorm:"type:boolean;not null"`
+	Status           *string                `gorm:"type:string;size:16"`
+	Runtime          *int                   `gorm:"type:integer"`
+	FirstAirDate     *time.Time             `gorm:"type:date"`
+	LastAirDate      *time.Time             `gorm:"type:date"`
+	AnnouncedAirDate *time.Time             `gorm:"type:date"` // See AnnounceAiredEpisode.
+	Homepage         *string                `gorm:"type:string;size:2048"`
+	OriginCountries  pq.StringArray         `gorm:"type:text[]"`
 	Seasons          []SeasonModel          `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+	Episodes         []EpisodeModel         `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
 	Translations     []ShowTranslationModel `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+	SlugHistory      []ShowSlugModel        `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+	ShowKeywords     []ShowKeywordModel     `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+	Videos           []VideoModel           `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+	Subtitles        []SubtitleModel        `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
 }
 
 type ShowTranslationModel struct {
@@ -28,9 +51,23 @@ type ShowTranslationModel struct {
 	core.HasUpdatedAtColumn
 
 	ShowID   uuid.UUID
-	Locale   string `gorm:"type:string;size:256;not null"`
-	Title    string `gorm:"type:string;size:256;not null"`
-	Overview string `gorm:"type:string;size:256;not null"`
+	Locale   string  `gorm:"type:string;size:256;not null;uniqueIndex:idx_show_translations_locale_slug"`
+	Title    string  `gorm:"type:string;size:256;not null"`
+	Overview string  `gorm:"type:text;not null"`
+	Slug     *string `gorm:"type:string;size:256;uniqueIndex:idx_show_translations_locale_slug"`
+}
+
+// ShowSlugModel records every slug that has been assigned to a show, including the current one,
+// so that URLs built with an old slug keep working after a rename. Slugs built from a translated
+// title are stored with the locale of the translation; slugs built from the original title have
+// an empty locale.
+type ShowSlugModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+
+	ShowID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Locale string    `gorm:"type:string;size:256;not null;uniqueIndex:idx_show_slugs_locale_slug"`
+	Slug   string    `gorm:"type:string;size:256;not null;uniqueIndex:idx_show_slugs_locale_slug"`
 }
 
 type SeasonModel struct {
@@ -51,7 +88,7 @@ type SeasonTranslationModel struct {
 	SeasonID uuid.UUID
 	Locale   string `gorm:"type:string;size:256;not null"`
 	Title    string `gorm:"type:string;size:256;not null"`
-	Overview string `gorm:"type:string;size:256;not null"`
+	Overview string `gorm:"type:text;not null"`
 }
 
 type EpisodeModel struct {
@@ -62,8 +99,10 @@ type EpisodeModel struct {
 	ShowID       uuid.UUID
 	Order        int
 	Title        string                    `gorm:"type:string;size:256;not null"`
-	Overview     string                    `gorm:"type:string;size:256"`
+	Overview     string                    `gorm:"type:text"`
 	Translations []EpisodeTranslationModel `gorm:"foreignKey:EpisodeID;constraint:OnDelete:CASCADE"`
+	Videos       []VideoModel              `gorm:"foreignKey:EpisodeID;constraint:OnDelete:CASCADE"`
+	Subtitles    []SubtitleModel           `gorm:"foreignKey:EpisodeID;constraint:OnDelete:CASCADE"`
 }
 
 type EpisodeTranslationModel struct {
@@ -74,7 +113,225 @@ type EpisodeTranslationModel struct {
 	EpisodeID uuid.UUID
 	Locale    string `gorm:"type:string;size:256;not null"`
 	Title     string `gorm:"type:string;size:256;not null"`
-	Overview  string `gorm:"type:string;size:256;not null"`
+	Overview  string `gorm:"type:text;not null"`
+}
+
+// VideoModel is a video (e.g. a trailer) of a show, or of one of its episodes when EpisodeID is set.
+// The key is the video ID on YouTube and Vimeo, and the URL of self-hosted videos.
+type VideoModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+
+	ShowID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	EpisodeID  *uuid.UUID `gorm:"type:uuid;index"`
+	Site       string     `gorm:"type:string;size:16;not null"`
+	Key        string     `gorm:"type:string;size:2048;not null"`
+	Name       string     `gorm:"type:string;size:256;not null"`
+	Language   string     `gorm:"type:string;size:256;not null"`
+	Type       string     `gorm:"type:string;size:32;not null"`
+	IsOfficial bool       `gorm:"type:boolean;not null"`
+}
+
+// SubtitleModel is the subtitle track of a movie in a language, or of one of its episodes when
+// EpisodeID is set. The cues are stored as a normalized WebVTT file, whatever the format of the
+// uploaded file.
+type SubtitleModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+
+	ShowID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	EpisodeID *uuid.UUID `gorm:"type:uuid;index"`
+	Language  string     `gorm:"type:string;size:256;not null"`
+	CueCount  int        `gorm:"type:integer;not null"`
+	Content   string     `gorm:"type:text;not null"`
+}
+
+// ShowSimilarityModel stores a precomputed similarity score between a show and another show.
+// The rows are maintained by the similar shows job, so reading them is cheap.
+type ShowSimilarityModel struct {
+	ShowID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	SimilarShowID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Score         float64   `gorm:"not null"`
+	ComputedAt    time.Time `gorm:"type:time;not null"`
+	Show          ShowModel `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+	SimilarShow   ShowModel `gorm:"foreignKey:SimilarShowID;constraint:OnDelete:CASCADE"`
+}
+
+// ShowRelationModel relates a show to another show, read as "<show> <type> <related show>". Only
+// one direction of each relation is stored, the inverse one is derived (see TypeFrom).
+type ShowRelationModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+
+	ShowID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_show_relations_shows_type"`
+	RelatedShowID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_show_relations_shows_type;index"`
+	Type          string    `gorm:"type:string;size:16;not null;uniqueIndex:idx_show_relations_shows_type"`
+	Show          ShowModel `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+	RelatedShow   ShowModel `gorm:"foreignKey:RelatedShowID;constraint:OnDelete:CASCADE"`
+}
+
+// KeywordModel is a canonical keyword. Its name is normalized (see NormalizeKeyword) and unique.
+type KeywordModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+
+	Name     string                `gorm:"type:string;size:256;not null;uniqueIndex"`
+	Labels   []KeywordLabelModel   `gorm:"foreignKey:KeywordID;constraint:OnDelete:CASCADE"`
+	Synonyms []KeywordSynonymModel `gorm:"foreignKey:KeywordID;constraint:OnDelete:CASCADE"`
+}
+
+// KeywordLabelModel is the name of a keyword displayed to the users of a locale.
+type KeywordLabelModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+
+	KeywordID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_keyword_labels_keyword_locale"`
+	Locale    string    `gorm:"type:string;size:256;not null;uniqueIndex:idx_keyword_labels_keyword_locale"`
+	Label     string    `gorm:"type:string;size:256;not null"`
+}
+
+// KeywordSynonymModel is a normalized value which resolves to a canonical keyword.
+// A synonym belongs to a single keyword.
+type KeywordSynonymModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+
+	KeywordID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Synonym   string    `gorm:"type:string;size:256;not null;uniqueIndex"`
+}
+
+// ShowKeywordModel links a show to a canonical keyword. ShowModel.Keywords mirrors the names of
+// the linked keywords, ordered by position, for backward compatibility.
+type ShowKeywordModel struct {
+	ShowID    uuid.UUID    `gorm:"primaryKey;type:uuid"`
+	KeywordID uuid.UUID    `gorm:"primaryKey;type:uuid;index"`
+	Position  int          `gorm:"not null"`
+	Keyword   KeywordModel `gorm:"foreignKey:KeywordID;constraint:OnDelete:CASCADE"`
+}
+
+// ShowRevisionModel records a change of a field of a show, with its previous and new values encoded
+// as JSON. The revisions of the changes made by a batch job reference the job.
+type ShowRevisionModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+	core.HasCreatedByColumn
+
+	ShowID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	BatchJobID *uuid.UUID `gorm:"type:uuid;index"`
+	Field      string     `gorm:"type:string;size:64;not null"`
+	OldValue   string     `gorm:"type:text;not null"`
+	NewValue   string     `gorm:"type:text;not null"`
+	Show       ShowModel  `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+}
+
+// DataQualitySnapshotModel is a data quality report stored by the data quality job.
+type DataQualitySnapshotModel struct {
+	core.Model
+
+	GeneratedAt time.Time               `gorm:"type:time;not null;index"`
+	Issues      []DataQualityIssueModel `gorm:"foreignKey:SnapshotID;constraint:OnDelete:CASCADE"`
+}
+
+// DataQualityIssueModel is an issue of a stored data quality report. The entities are not
+// foreign keys, so that a snapshot keeps the issues of the entities deleted since.
+type DataQualityIssueModel struct {
+	core.Model
+
+	SnapshotID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Position   int       `gorm:"type:integer;not null"`
+	Check      string    `gorm:"column:check_name;type:string;size:32;not null"`
+	Severity   string    `gorm:"type:string;size:16;not null"`
+	EntityKind string    `gorm:"type:string;size:32;not null"`
+	EntityID   uuid.UUID `gorm:"type:uuid;not null"`
+	ShowID     uuid.UUID `gorm:"type:uuid;not null"`
+	ShowTitle  string    `gorm:"type:string;size:256;not null"`
+	Detail     string    `gorm:"type:text;not null"`
+}
+
+// ShowBatchJobModel is a change applied to many shows in the background by the batch job
+// worker. The selection is resolved when the job is created and stored as its items, so
+// the shows which are created or changed afterwards are not affected.
+type ShowBatchJobModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+	core.HasUpdatedAtColumn
+	core.HasCreatedByColumn
+
+	Operation string `gorm:"type:string;size:32;not null"`
+
+	// The canonical name of the keyword of the keyword operations.
+	Keyword *string `gorm:"type:string;size:256"`
+
+	// The value of the set_released operation.
+	IsReleased      *bool                   `gorm:"type:boolean"`
+	Status          string                  `gorm:"type:string;size:16;not null;index"`
+	Total           int                     `gorm:"type:integer;not null"`
+	Processed       int                     `gorm:"type:integer;not null"`
+	Succeeded       int                     `gorm:"type:integer;not null"`
+	Skipped         int                     `gorm:"type:integer;not null"`
+	Failed          int                     `gorm:"type:integer;not null"`
+	CancelRequested bool                    `gorm:"type:boolean;not null"`
+	StartedAt       *time.Time              `gorm:"type:time"`
+	FinishedAt      *time.Time              `gorm:"type:time"`
+	Items           []ShowBatchJobItemModel `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE"`
+
+	// The worker which has claimed the job, and when it last renewed its claim (see ClaimBatchJob).
+	Worker      *string    `gorm:"type:string;size:64"`
+	HeartbeatAt *time.Time `gorm:"type:time"`
+}
+
+// ShowBatchJobItemModel is the result of a batch job on a show. Shows are processed by position.
+// The show is not a foreign key, so that the report keeps the shows which have been deleted.
+type ShowBatchJobItemModel struct {
+	JobID       uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	ShowID      uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	Position    int        `gorm:"type:integer;not null"`
+	Status      string     `gorm:"type:string;size:16;not null"`
+	Error       *string    `gorm:"type:text"`
+	ProcessedAt *time.Time `gorm:"type:time"`
+
+	// The number of times the operation has been tried on the show (see maxBatchItemAttempts).
+	Attempts int `gorm:"type:integer;not null;default:0"`
+}
+
+// ShowActivityModel counts the activity of a kind on a show during an hour. Counts are written
+// in batches by ShowActivityRecorder, so recording an activity never costs a query.
+type ShowActivityModel struct {
+	ShowID uuid.UUID `gorm:"primaryKey;type:uuid"`
+
+	// The start of the hour, as a full timestamp so that the age of the activity can be computed.
+	Bucket time.Time `gorm:"primaryKey;type:timestamptz;index"`
+	Kind   string    `gorm:"primaryKey;type:string;size:16"`
+	Count  int64     `gorm:"not null"`
+	Show   ShowModel `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+}
+
+// ShowPopularityModel stores the time-decayed scores of a show. The rows are maintained by the
+// popularity job; shows without recent activity have no row.
+type ShowPopularityModel struct {
+	ShowID       uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Popularity   float64   `gorm:"not null;index"`
+	TrendingDay  float64   `gorm:"not null;index"`
+	TrendingWeek float64   `gorm:"not null;index"`
+	ComputedAt   time.Time `gorm:"type:timestamptz;not null"`
+	Show         ShowModel `gorm:"foreignKey:ShowID;constraint:OnDelete:CASCADE"`
+}
+
+// CatalogEventModel is a catalog event waiting to be delivered to the listeners (see
+// PublishCatalogEvents). The show is not a foreign key, so that the events of a deleted show are
+// still delivered.
+type CatalogEventModel struct {
+	core.Model
+	core.HasCreatedAtColumn
+
+	Type       string     `gorm:"type:string;size:32;not null"`
+	ShowID     uuid.UUID  `gorm:"type:uuid;not null"`
+	OccurredAt time.Time  `gorm:"type:time;not null"`
+	AirDate    *time.Time `gorm:"type:date"`
 }
 
 func (ShowModel) TableName() string {
@@ -85,6 +342,10 @@ func (ShowTranslationModel) TableName() string {
 	return "public.show_translations"
 }
 
+func (ShowSlugModel) TableName() string {
+	return "public.show_slugs"
+}
+
 func (SeasonModel) TableName() string {
 	return "public.seasons"
 }
@@ -100,3 +361,67 @@ func (EpisodeModel) TableName() string {
 func (EpisodeTranslationModel) TableName() string {
 	return "public.episode_translations"
 }
+
+func (SubtitleModel) TableName() string {
+	return "public.subtitles"
+}
+
+func (ShowSimilarityModel) TableName() string {
+	return "public.show_similarities"
+}
+
+func (ShowRelationModel) TableName() string {
+	return "public.show_relations"
+}
+
+func (KeywordModel) TableName() string {
+	return "public.keywords"
+}
+
+func (KeywordLabelModel) TableName() string {
+	return "public.keyword_labels"
+}
+
+func (KeywordSynonymModel) TableName() string {
+	return "public.keyword_synonyms"
+}
+
+func (ShowKeywordModel) TableName() string {
+	return "public.show_keywords"
+}
+
+func (VideoModel) TableName() string {
+	return "public.videos"
+}
+
+func (ShowRevisionModel) TableName() string {
+	return "public.show_revisions"
+}
+
+func (DataQualitySnapshotModel) TableName() string {
+	return "public.data_quality_snapshots"
+}
+
+func (DataQualityIssueModel) TableName() string {
+	return "public.data_quality_issues"
+}
+
+func (ShowBatchJobModel) TableName() string {
+	return "public.show_batch_jobs"
+}
+
+func (ShowBatchJobItemModel) TableName() string {
+	return "public.show_batch_job_items"
+}
+
+func (ShowActivityModel) TableName() string {
+	return "public.show_activities"
+}
+
+func (ShowPopularityModel) TableName() string {
+	return "public.show_popularities"
+}
+
+func (CatalogEventModel) TableName() string {
+	return "public.catalog_events"
+}
@@ -12,7 +12,57 @@ func NewShowMgtModule() fx.Option {
 		"Show management module",
 		fx.Provide(
 			core.AsRoute(NewGetShowsHandler),
+			core.AsRoute(NewSuggestShowsHandler),
+			core.AsRoute(NewGetTrendingShowsHandler),
+			core.AsRoute(NewGetShowHandler),
 			core.AsRoute(NewCreateMovieHandler),
+			core.AsRoute(NewUpdateShowHandler),
+			core.AsRoute(NewGetSimilarShowsHandler),
+			core.AsRoute(NewCreateShowRelationHandler),
+			core.AsRoute(NewDeleteShowRelationHandler),
+			core.AsRoute(NewGetFranchiseHandler),
+			core.AsScheduledJob(NewSimilarShowsJob),
+			NewShowActivityRecorder,
+			core.AsScheduledJob(NewShowActivityJob),
+			core.AsScheduledJob(NewShowPopularityJob),
+			core.AsRoute(NewGetShowsAtomFeedHandler),
+			core.AsRoute(NewGetShowsRSSFeedHandler),
+			core.AsRoute(NewGetPublicShowHandler),
+			core.AsRoute(NewGetTranslationCoverageHandler),
+			core.AsRoute(NewExportTranslationCoverageHandler),
+			core.AsRoute(NewGetDataQualityReportHandler),
+			core.AsRoute(NewExportDataQualityReportHandler),
+			core.AsScheduledJob(NewDataQualityJob),
+			core.AsRoute(NewCreateKeywordHandler),
+			core.AsRoute(NewUpdateKeywordHandler),
+			core.AsRoute(NewAutocompleteKeywordsHandler),
+			core.AsRoute(NewGetVideosHandler),
+			core.AsRoute(NewCreateVideoHandler),
+			core.AsRoute(NewUpdateVideoHandler),
+			core.AsRoute(NewDeleteVideoHandler),
+			core.AsRoute(NewGetEpisodesHandler),
+			core.AsRoute(NewGetSubtitlesHandler),
+			core.AsRoute(NewUploadSubtitlesHandler),
+			core.AsRoute(NewDownloadSubtitlesHandler),
+			core.AsRoute(NewDeleteSubtitlesHandler),
+			core.AsRoute(NewExportShowNFOHandler),
+			core.AsRoute(NewExportShowJSONLDHandler),
+			core.AsRoute(NewExportSeasonNFOHandler),
+			core.AsRoute(NewExportSeasonJSONLDHandler),
+			core.AsRoute(NewExportEpisodeNFOHandler),
+			core.AsRoute(NewExportEpisodeJSONLDHandler),
+			core.AsRoute(NewExportShowsNFOArchiveHandler),
+			core.AsRoute(NewExportShowsJSONLDArchiveHandler),
+			core.AsRoute(NewCreateBatchJobHandler),
+			core.AsRoute(NewGetBatchJobHandler),
+			core.AsRoute(NewGetBatchJobItemsHandler),
+			core.AsRoute(NewCancelBatchJobHandler),
+			core.AsScheduledJob(NewShowBatchJob),
+			NewCatalogEvents,
+			core.AsScheduledJob(NewAiredEpisodesJob),
+			core.AsScheduledJob(NewCatalogEventsJob),
+			NewSearchIndex,
+			AsCatalogEventListener(NewSearchIndexListener),
 		),
 	)
 }
@@ -0,0 +1,218 @@
+package showmgt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Kinds of activity recorded on shows.
+const (
+	// ViewShowActivity is recorded when the detail of a show is returned.
+	ViewShowActivity = "view"
+
+	// ListAddShowActivity is recorded when a show is added to a user-curated list.
+	ListAddShowActivity = "list_add"
+)
+
+// Trending windows, selected with the "window" query parameter of the trending endpoint.
+const (
+	DayTrendingWindow  = "day"
+	WeekTrendingWindow = "week"
+)
+
+// maxBufferedShowActivities is the number of counts kept in the buffer of ShowActivityRecorder
+// when its writes fail. The counts above it are dropped, so that the buffer cannot grow forever
+// while the database is unavailable.
+const maxBufferedShowActivities = 100000
+
+// PopularityShowSort is the value of the "sort" query parameter ordering shows by popularity.
+const PopularityShowSort = "popularity"
+
+// showActivityWeights is how much an activity of each kind counts in the scores. Adding a show
+// to a list shows more interest than viewing it.
+var showActivityWeights = map[string]float64{
+	ViewShowActivity:    1,
+	ListAddShowActivity: 5,
+}
+
+// popularityScore describes how a score decays with the age of the activity. Only the activity
+// of the window is taken into account, and its weight is halved every half-life.
+type popularityScore struct {
+	column   string
+	window   time.Duration
+	halfLife time.Duration
+}
+
+var (
+	popularityScoreDef   = popularityScore{column: "popularity", window: 90 * 24 * time.Hour, halfLife: 7 * 24 * time.Hour}
+	trendingDayScoreDef  = popularityScore{column: "trending_day", window: 24 * time.Hour, halfLife: 6 * time.Hour}
+	trendingWeekScoreDef = popularityScore{column: "trending_week", window: 7 * 24 * time.Hour, halfLife: 2 * 24 * time.Hour}
+)
+
+type showActivityKey struct {
+	showID uuid.UUID
+	bucket time.Time
+	kind   string
+}
+
+// ShowActivityRecorder buffers the activity on shows in memory. The buffer is written by the
+// show activity job and when the application stops.
+type ShowActivityRecorder struct {
+	db     *gorm.DB
+	mu     sync.Mutex
+	counts map[showActivityKey]int64
+}
+
+type ShowActivityRecorderParams struct {
+	fx.In
+
+	DB           *gorm.DB
+	AppLifeCycle fx.Lifecycle `optional:"true"`
+}
+
+func NewShowActivityRecorder(p ShowActivityRecorderParams) *ShowActivityRecorder {
+	recorder := &ShowActivityRecorder{
+		db:     p.DB,
+		counts: map[showActivityKey]int64{},
+	}
+
+	if p.AppLifeCycle != nil {
+		p.AppLifeCycle.Append(fx.Hook{
+			OnStop: recorder.Flush,
+		})
+	}
+
+	return recorder
+}
+
+// Record counts an activity of the given kind on a show. It only updates the in-memory buffer.
+func (r *ShowActivityRecorder) Record(showID uuid.UUID, kind string) {
+	key := showActivityKey{showID: showID, bucket: time.Now().UTC().Truncate(time.Hour), kind: kind}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counts[key]++
+}
+
+// Flush writes the buffered counts with a single upsert, adding them to the stored counts. The
+// counts of shows which have been deleted since are dropped by the join on the shows, so that
+// they cannot make the whole write fail.
+// When the write fails, the counts are put back in the buffer for the next flush, as long as the
+// buffer holds fewer than maxBufferedShowActivities counts.
+func (r *ShowActivityRecorder) Flush(ctx context.Context) error {
+	return r.flush(r.db.WithContext(ctx))
+}
+
+// flush writes the buffered counts with the given connection (see Flush).
+func (r *ShowActivityRecorder) flush(db *gorm.DB) error {
+	r.mu.Lock()
+	counts := r.counts
+	r.counts = map[showActivityKey]int64{}
+	r.mu.Unlock()
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	rows := make([]string, 0, len(counts))
+	values := make([]any, 0, 4*len(counts))
+
+	for key, count := range counts {
+		rows = append(rows, "(?::uuid, ?::timestamptz, ?, ?::bigint)")
+		values = append(values, key.showID, key.bucket, key.kind, count)
+	}
+
+	if result := db.Exec(`INSERT INTO public.show_activities (show_id, bucket, kind, count)
+		SELECT activities.show_id, activities.bucket, activities.kind, activities.count
+		FROM (VALUES `+strings.Join(rows, ", ")+`) AS activities (show_id, bucket, kind, count)
+		JOIN public.shows ON shows.id = activities.show_id
+		ON CONFLICT (show_id, bucket, kind) DO UPDATE SET count = show_activities.count + excluded.count`,
+		values...); result.Error != nil {
+		r.mu.Lock()
+		for key, count := range counts {
+			if _, ok := r.counts[key]; ok || len(r.counts) < maxBufferedShowActivities {
+				r.counts[key] += count
+			}
+		}
+		r.mu.Unlock()
+
+		return result.Error
+	}
+
+	return nil
+}
+
+// decayedScoreExpr returns the SQL expression summing the weighted activity of the window of
+// the score, each count halved every half-life since the end of its hour.
+func decayedScoreExpr(score popularityScore, now time.Time) clause.Expr {
+	return gorm.Expr(`COALESCE(SUM(activities.weight * activities.count * POWER(0.5,
+		GREATEST(EXTRACT(EPOCH FROM (?::timestamptz - activities.bucket)) - 3600, 0) / ?))
+		FILTER (WHERE activities.bucket > ?), 0)`,
+		now, score.halfLife.Seconds(), now.Add(-score.window))
+}
+
+// ComputeShowPopularities replaces the stored scores with the ones computed from the activity
+// of the popularity window, and deletes the activity older than that window.
+func ComputeShowPopularities(tx *gorm.DB, now time.Time) (int64, error) {
+	weights := tx.Raw("SELECT * FROM (VALUES (?, ?::float8), (?, ?::float8)) AS weights (kind, weight)",
+		ViewShowActivity, showActivityWeights[ViewShowActivity],
+		ListAddShowActivity, showActivityWeights[ListAddShowActivity])
+
+	activities := tx.Model(&ShowActivityModel{}).
+		Select("show_activities.show_id, show_activities.bucket, show_activities.count, weights.weight").
+		Joins("JOIN (?) AS weights ON weights.kind = show_activities.kind", weights).
+		Where("show_activities.bucket > ?", now.Add(-popularityScoreDef.window))
+
+	scores := tx.Table("(?) AS activities", activities).
+		Select("activities.show_id, ? AS popularity, ? AS trending_day, ? AS trending_week, ?::timestamptz AS computed_at",
+			decayedScoreExpr(popularityScoreDef, now),
+			decayedScoreExpr(trendingDayScoreDef, now),
+			decayedScoreExpr(trendingWeekScoreDef, now),
+			now).
+		Group("activities.show_id")
+
+	if result := tx.Where("1 = 1").Delete(&ShowPopularityModel{}); result.Error != nil {
+		return 0, result.Error
+	}
+
+	result := tx.Exec("INSERT INTO public.show_popularities (show_id, popularity, trending_day, trending_week, computed_at) ?", scores)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if pruned := tx.Where("bucket <= ?", now.Add(-popularityScoreDef.window)).Delete(&ShowActivityModel{}); pruned.Error != nil {
+		return 0, pruned.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// GetTrendingWindow reads the "window" query parameter. Unknown values fall back to the day window.
+func GetTrendingWindow(r *http.Request) string {
+	return lo.Ternary(r.URL.Query().Get("window") == WeekTrendingWindow, WeekTrendingWindow, DayTrendingWindow)
+}
+
+// trendingColumn returns the column of show_popularities holding the score of the window.
+func trendingColumn(window string) string {
+	return lo.Ternary(window == WeekTrendingWindow, trendingWeekScoreDef.column, trendingDayScoreDef.column)
+}
+
+// OrderShows orders the shows by the "sort" query parameter: by popularity when it is
+// "popularity", shows without a score last, and by creation date otherwise.
+func OrderShows(r *http.Request, db *gorm.DB) *gorm.DB {
+	if r.URL.Query().Get("sort") == PopularityShowSort {
+		db = db.Order("(SELECT popularity FROM public.show_popularities WHERE show_popularities.show_id = shows.id) DESC NULLS LAST")
+	}
+
+	return db.Order("created_at DESC")
+}
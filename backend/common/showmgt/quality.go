@@ -0,0 +1,435 @@
+package showmgt
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+)
+
+// DataQualitySeverity tells how urgently a data quality issue should be fixed.
+type DataQualitySeverity string
+
+const (
+	// ErrorDataQualitySeverity issues break features, e.g. content in an invalid language cannot be localized.
+	ErrorDataQualitySeverity DataQualitySeverity = "error"
+
+	// WarningDataQualitySeverity issues degrade what users see, e.g. a show without an overview.
+	WarningDataQualitySeverity DataQualitySeverity = "warning"
+
+	// InfoDataQualitySeverity issues are worth a look but may be intended.
+	InfoDataQualitySeverity DataQualitySeverity = "info"
+)
+
+// DataQualitySeverities lists the severities from the most to the least urgent.
+var DataQualitySeverities = []DataQualitySeverity{
+	ErrorDataQualitySeverity,
+	WarningDataQualitySeverity,
+	InfoDataQualitySeverity,
+}
+
+// Checks of the data quality report.
+const (
+	MissingOverviewCheck      = "missing_overview"
+	ShortOverviewCheck        = "short_overview"
+	NoTranslationsCheck       = "no_translations"
+	NoSeasonsCheck            = "no_seasons"
+	EpisodeOrderGapCheck      = "episode_order_gap"
+	DuplicateSeasonOrderCheck = "duplicate_season_order"
+	UnknownKeywordCheck       = "unknown_keyword"
+	InvalidLanguageCheck      = "invalid_language"
+)
+
+// dataQualityCheckSeverities maps every check to the severity of its issues.
+var dataQualityCheckSeverities = map[string]DataQualitySeverity{
+	InvalidLanguageCheck:      ErrorDataQualitySeverity,
+	DuplicateSeasonOrderCheck: ErrorDataQualitySeverity,
+	MissingOverviewCheck:      WarningDataQualitySeverity,
+	NoTranslationsCheck:       WarningDataQualitySeverity,
+	NoSeasonsCheck:            WarningDataQualitySeverity,
+	EpisodeOrderGapCheck:      WarningDataQualitySeverity,
+	ShortOverviewCheck:        InfoDataQualitySeverity,
+	UnknownKeywordCheck:       InfoDataQualitySeverity,
+}
+
+// Kinds of the entities affected by a data quality issue.
+const (
+	ShowDataQualityEntity               = "show"
+	ShowTranslationDataQualityEntity    = "show_translation"
+	SeasonTranslationDataQualityEntity  = "season_translation"
+	EpisodeTranslationDataQualityEntity = "episode_translation"
+	VideoDataQualityEntity              = "video"
+)
+
+// MinOverviewLength is the number of characters below which an overview is reported as too short.
+const MinOverviewLength = 50
+
+// seasonedShowKinds are the kinds of shows which are expected to have seasons.
+var seasonedShowKinds = []string{"tv"}
+
+// DataQualityIssue is a problem found in the catalog. The link points to the API resource to fix.
+type DataQualityIssue struct {
+	Check      string              `json:"check"`
+	Severity   DataQualitySeverity `json:"severity"`
+	EntityKind string              `json:"entityKind"`
+	EntityID   uuid.UUID           `json:"entityId"`
+	ShowID     uuid.UUID           `json:"showId"`
+	ShowTitle  string              `json:"showTitle"`
+	Detail     string              `json:"detail"`
+	Link       string              `json:"link"`
+}
+
+// DataQualityGroup holds the issues of a single severity.
+type DataQualityGroup struct {
+	Severity DataQualitySeverity `json:"severity"`
+	Count    int                 `json:"count"`
+	Issues   []DataQualityIssue  `json:"issues"`
+}
+
+// DataQualityReport lists the data quality issues of the catalog, grouped by severity. The
+// snapshot ID is only set when the report has been loaded from a stored snapshot.
+type DataQualityReport struct {
+	SnapshotID  *uuid.UUID         `json:"snapshotId,omitempty"`
+	GeneratedAt time.Time          `json:"generatedAt"`
+	Total       int                `json:"total"`
+	Groups      []DataQualityGroup `json:"groups"`
+}
+
+// dataQualityReportBuilder collects the issues of the report while the catalog is checked.
+type dataQualityReportBuilder struct {
+	issues []DataQualityIssue
+}
+
+// add records an issue of the given check on an entity of the given show.
+func (b *dataQualityReportBuilder) add(
+	check string,
+	show *ShowModel,
+	entityKind string,
+	entityID uuid.UUID,
+	detail string,
+) {
+	b.issues = append(b.issues, DataQualityIssue{
+		Check:      check,
+		Severity:   dataQualityCheckSeverities[check],
+		EntityKind: entityKind,
+		EntityID:   entityID,
+		ShowID:     show.ID,
+		ShowTitle:  show.OriginalTitle,
+		Detail:     detail,
+		Link:       dataQualityLink(entityKind, show.ID),
+	})
+}
+
+// dataQualityLink returns the API resource where the given entity can be fixed. Seasons,
+// episodes and translations are edited through their show.
+func dataQualityLink(entityKind string, showID uuid.UUID) string {
+	if entityKind == VideoDataQualityEntity {
+		return "/api/v1/shows/" + showID.String() + "/videos"
+	}
+
+	return "/api/v1/shows/" + showID.String()
+}
+
+// BuildDataQualityReport checks the given shows, with their translations and seasons, and their
+// episodes and videos. Keywords are expected to be in the given vocabulary of canonical names.
+func BuildDataQualityReport(
+	shows []ShowModel,
+	episodes []EpisodeModel,
+	videos []VideoModel,
+	vocabulary []string,
+	generatedAt time.Time,
+) *DataQualityReport {
+	episodesByShow := lo.GroupBy(episodes, func(episode EpisodeModel) uuid.UUID { return episode.ShowID })
+	videosByShow := lo.GroupBy(videos, func(video VideoModel) uuid.UUID { return video.ShowID })
+	knownKeywords := lo.SliceToMap(vocabulary, func(name string) (string, bool) { return name, true })
+
+	builder := &dataQualityReportBuilder{}
+
+	for i := range shows {
+		show := &shows[i]
+
+		builder.checkShow(show, knownKeywords)
+		builder.checkSeasons(show)
+		builder.checkEpisodes(show, episodesByShow[show.ID])
+
+		for _, video := range videosByShow[show.ID] {
+			if !isValidLanguage(video.Language) {
+				builder.add(InvalidLanguageCheck, show, VideoDataQualityEntity, video.ID,
+					fmt.Sprintf("The video %q has the invalid language %q", video.Name, video.Language))
+			}
+		}
+	}
+
+	return newDataQualityReport(builder.issues, generatedAt)
+}
+
+// checkShow checks the language, overviews, translations and keywords of the show.
+func (b *dataQualityReportBuilder) checkShow(show *ShowModel, knownKeywords map[string]bool) {
+	if !isValidLanguage(show.OriginalLanguage) {
+		b.add(InvalidLanguageCheck, show, ShowDataQualityEntity, show.ID,
+			fmt.Sprintf("The original language %q is invalid", show.OriginalLanguage))
+	}
+
+	b.checkOverview(show, ShowDataQualityEntity, show.ID, "The original overview", lo.FromPtr(show.OriginalOverview))
+
+	if len(show.Translations) == 0 {
+		b.add(NoTranslationsCheck, show, ShowDataQualityEntity, show.ID, "The show has no translations")
+	}
+
+	for _, translation := range show.Translations {
+		if !isValidLanguage(translation.Locale) {
+			b.add(InvalidLanguageCheck, show, ShowTranslationDataQualityEntity, translation.ID,
+				fmt.Sprintf("The translation locale %q is invalid", translation.Locale))
+		}
+
+		b.checkOverview(show, ShowTranslationDataQualityEntity, translation.ID,
+			fmt.Sprintf("The %q overview", translation.Locale), translation.Overview)
+	}
+
+	for _, keyword := range show.Keywords {
+		if !knownKeywords[keyword] {
+			b.add(UnknownKeywordCheck, show, ShowDataQualityEntity, show.ID,
+				fmt.Sprintf("The keyword %q is not in the vocabulary", keyword))
+		}
+	}
+}
+
+// checkOverview reports an overview which is missing or shorter than MinOverviewLength.
+func (b *dataQualityReportBuilder) checkOverview(
+	show *ShowModel,
+	entityKind string,
+	entityID uuid.UUID,
+	name string,
+	overview string,
+) {
+	length := utf8.RuneCountInString(strings.TrimSpace(overview))
+
+	switch {
+	case length == 0:
+		b.add(MissingOverviewCheck, show, entityKind, entityID, name+" is missing")
+	case length < MinOverviewLength:
+		b.add(ShortOverviewCheck, show, entityKind, entityID,
+			fmt.Sprintf("%s has only %d characters", name, length))
+	}
+}
+
+// checkSeasons reports the shows which should have seasons but have none, the orders used by
+// several seasons and the invalid locales of the season translations.
+func (b *dataQualityReportBuilder) checkSeasons(show *ShowModel) {
+	if len(show.Seasons) == 0 && slices.Contains(seasonedShowKinds, show.Kind) {
+		b.add(NoSeasonsCheck, show, ShowDataQualityEntity, show.ID,
+			fmt.Sprintf("The %q show has no seasons", show.Kind))
+	}
+
+	seasonsByOrder := lo.CountValuesBy(show.Seasons, func(season SeasonModel) int { return season.Order })
+	orders := lo.Keys(seasonsByOrder)
+	slices.Sort(orders)
+
+	for _, order := range orders {
+		if count := seasonsByOrder[order]; count > 1 {
+			b.add(DuplicateSeasonOrderCheck, show, ShowDataQualityEntity, show.ID,
+				fmt.Sprintf("The season order %d is used by %d seasons", order, count))
+		}
+	}
+
+	for _, season := range show.Seasons {
+		for _, translation := range season.Translations {
+			if !isValidLanguage(translation.Locale) {
+				b.add(InvalidLanguageCheck, show, SeasonTranslationDataQualityEntity, translation.ID,
+					fmt.Sprintf("The locale %q of a translation of the season %d is invalid", translation.Locale, season.Order))
+			}
+		}
+	}
+}
+
+// checkEpisodes reports the gaps between the orders of the episodes of the show and the invalid
+// locales of the episode translations.
+func (b *dataQualityReportBuilder) checkEpisodes(show *ShowModel, episodes []EpisodeModel) {
+	orders := lo.Uniq(lo.Map(episodes, func(episode EpisodeModel, _ int) int { return episode.Order }))
+	slices.Sort(orders)
+
+	for i := 1; i < len(orders); i++ {
+		switch missingFrom, missingTo := orders[i-1]+1, orders[i]-1; {
+		case missingFrom == missingTo:
+			b.add(EpisodeOrderGapCheck, show, ShowDataQualityEntity, show.ID,
+				fmt.Sprintf("The episode %d is missing", missingFrom))
+		case missingFrom < missingTo:
+			b.add(EpisodeOrderGapCheck, show, ShowDataQualityEntity, show.ID,
+				fmt.Sprintf("The episodes %d to %d are missing", missingFrom, missingTo))
+		}
+	}
+
+	for _, episode := range episodes {
+		for _, translation := range episode.Translations {
+			if !isValidLanguage(translation.Locale) {
+				b.add(InvalidLanguageCheck, show, EpisodeTranslationDataQualityEntity, translation.ID,
+					fmt.Sprintf("The locale %q of a translation of the episode %d is invalid", translation.Locale, episode.Order))
+			}
+		}
+	}
+}
+
+// isValidLanguage tells whether the given value is a well-formed BCP 47 language tag.
+func isValidLanguage(tag string) bool {
+	_, err := language.Parse(tag)
+
+	return err == nil
+}
+
+// newDataQualityReport groups the given issues by severity, keeping their order in each group.
+func newDataQualityReport(issues []DataQualityIssue, generatedAt time.Time) *DataQualityReport {
+	report := &DataQualityReport{
+		GeneratedAt: generatedAt,
+		Total:       len(issues),
+		Groups:      make([]DataQualityGroup, 0, len(DataQualitySeverities)),
+	}
+
+	for _, severity := range DataQualitySeverities {
+		groupIssues := lo.Filter(issues, func(issue DataQualityIssue, _ int) bool {
+			return issue.Severity == severity
+		})
+
+		report.Groups = append(report.Groups, DataQualityGroup{
+			Severity: severity,
+			Count:    len(groupIssues),
+			Issues:   groupIssues,
+		})
+	}
+
+	return report
+}
+
+// WriteCSV writes one line per issue, the most urgent first.
+func (r *DataQualityReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{
+		"severity", "check", "entity_kind", "entity_id", "show_id", "show_title", "detail", "link",
+	}); err != nil {
+		return err
+	}
+
+	for _, group := range r.Groups {
+		for _, issue := range group.Issues {
+			if err := writer.Write([]string{
+				string(issue.Severity), issue.Check, issue.EntityKind, issue.EntityID.String(),
+				issue.ShowID.String(), issue.ShowTitle, issue.Detail, issue.Link,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// NewDataQualitySnapshot returns the model storing the given report.
+func NewDataQualitySnapshot(report *DataQualityReport) *DataQualitySnapshotModel {
+	snapshot := &DataQualitySnapshotModel{
+		GeneratedAt: report.GeneratedAt,
+		Issues:      []DataQualityIssueModel{},
+	}
+
+	for _, group := range report.Groups {
+		for _, issue := range group.Issues {
+			snapshot.Issues = append(snapshot.Issues, DataQualityIssueModel{
+				Position:   len(snapshot.Issues),
+				Check:      issue.Check,
+				Severity:   string(issue.Severity),
+				EntityKind: issue.EntityKind,
+				EntityID:   issue.EntityID,
+				ShowID:     issue.ShowID,
+				ShowTitle:  issue.ShowTitle,
+				Detail:     issue.Detail,
+			})
+		}
+	}
+
+	return snapshot
+}
+
+// Report returns the report stored by the snapshot. The issues must be loaded.
+func (s *DataQualitySnapshotModel) Report() *DataQualityReport {
+	issues := lo.Map(s.Issues, func(issue DataQualityIssueModel, _ int) DataQualityIssue {
+		return DataQualityIssue{
+			Check:      issue.Check,
+			Severity:   DataQualitySeverity(issue.Severity),
+			EntityKind: issue.EntityKind,
+			EntityID:   issue.EntityID,
+			ShowID:     issue.ShowID,
+			ShowTitle:  issue.ShowTitle,
+			Detail:     issue.Detail,
+			Link:       dataQualityLink(issue.EntityKind, issue.ShowID),
+		}
+	})
+
+	report := newDataQualityReport(issues, s.GeneratedAt)
+	report.SnapshotID = &s.ID
+
+	return report
+}
+
+// LoadDataQualityReport loads the catalog from the database and checks it.
+func LoadDataQualityReport(ctx context.Context, db *gorm.DB, generatedAt time.Time) (*DataQualityReport, error) {
+	db = db.WithContext(ctx)
+
+	var shows []ShowModel
+	if result := db.
+		Preload("Translations").
+		Preload("Seasons.Translations").
+		Order("original_title, id").
+		Find(&shows); result.Error != nil {
+		return nil, result.Error
+	}
+
+	var episodes []EpisodeModel
+	if result := db.
+		Select("id", "show_id", "\"order\"").
+		Preload("Translations", func(tx *gorm.DB) *gorm.DB {
+			return tx.Select("id", "episode_id", "locale")
+		}).
+		Find(&episodes); result.Error != nil {
+		return nil, result.Error
+	}
+
+	var videos []VideoModel
+	if result := db.
+		Select("id", "show_id", "name", "language").
+		Find(&videos); result.Error != nil {
+		return nil, result.Error
+	}
+
+	var vocabulary []string
+	if result := db.Model(&KeywordModel{}).Pluck("name", &vocabulary); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return BuildDataQualityReport(shows, episodes, videos, vocabulary, generatedAt), nil
+}
+
+// LoadLatestDataQualityReport loads the report of the latest snapshot. It returns
+// gorm.ErrRecordNotFound when no snapshot has been stored yet.
+func LoadLatestDataQualityReport(ctx context.Context, db *gorm.DB) (*DataQualityReport, error) {
+	var snapshot DataQualitySnapshotModel
+	if result := db.WithContext(ctx).
+		Preload("Issues", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("position")
+		}).
+		Order("generated_at DESC").
+		First(&snapshot); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return snapshot.Report(), nil
+}
@@ -0,0 +1,279 @@
+package showmgt
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// Types of relations between shows, read as "<show> <type> <related show>".
+const (
+	SequelOfShowRelation     = "sequel_of"
+	PrequelOfShowRelation    = "prequel_of"
+	SpinOffOfShowRelation    = "spin_off_of"
+	HasSpinOffShowRelation   = "has_spin_off"
+	RemakeOfShowRelation     = "remake_of"
+	RemadeAsShowRelation     = "remade_as"
+	SideStoryOfShowRelation  = "side_story_of"
+	HasSideStoryShowRelation = "has_side_story"
+)
+
+const (
+	// DefaultFranchiseDepth is the number of hops followed from a show when building its franchise graph.
+	DefaultFranchiseDepth = 2
+
+	// MaxFranchiseDepth is the maximum number of hops followed when building a franchise graph.
+	MaxFranchiseDepth = 5
+)
+
+// showRelationInverses maps every relation type to the type of the same relation seen from the
+// related show. Only the types which are keys of storedShowRelationTypes are stored, the others
+// are derived when reading.
+var showRelationInverses = map[string]string{
+	SequelOfShowRelation:     PrequelOfShowRelation,
+	PrequelOfShowRelation:    SequelOfShowRelation,
+	SpinOffOfShowRelation:    HasSpinOffShowRelation,
+	HasSpinOffShowRelation:   SpinOffOfShowRelation,
+	RemakeOfShowRelation:     RemadeAsShowRelation,
+	RemadeAsShowRelation:     RemakeOfShowRelation,
+	SideStoryOfShowRelation:  HasSideStoryShowRelation,
+	HasSideStoryShowRelation: SideStoryOfShowRelation,
+}
+
+// storedShowRelationTypes are the relation types stored in the database, telling whether a chain
+// of relations of that type may loop back to its first show. A show can't come before itself in
+// a chain of sequels, be remade from its own remake or be spun off its own spin-off, but two
+// shows may each tell side stories of the other.
+var storedShowRelationTypes = map[string]bool{
+	SequelOfShowRelation:    false,
+	SpinOffOfShowRelation:   false,
+	RemakeOfShowRelation:    false,
+	SideStoryOfShowRelation: true,
+}
+
+var (
+	// ErrShowRelatedToItself is returned when a show is related to itself.
+	ErrShowRelatedToItself = errors.New("a show can't be related to itself")
+
+	// ErrShowRelationExists is returned when the shows are already related with the same type.
+	ErrShowRelationExists = errors.New("the shows are already related with this type")
+
+	// ErrShowRelationCycle is returned when a relation would close a cycle its type forbids.
+	ErrShowRelationCycle = errors.New("the relation would create a cycle")
+)
+
+// ShowRelationRequestBody holds the request body for relating a show to another show.
+type ShowRelationRequestBody struct {
+	RelatedShowID uuid.UUID `json:"relatedShowId" validate:"required"`
+
+	// The relation seen from the show, e.g. "prequel_of" when the show is the prequel of the related show.
+	Type string `json:"type" validate:"required,oneof=sequel_of prequel_of spin_off_of remake_of side_story_of"`
+}
+
+// RelatedShow is a show related to another show, with the type of the relation seen from the other show.
+type RelatedShow struct {
+	RelationID uuid.UUID
+	Type       string
+	Show       *ShowModel
+}
+
+// FranchiseEdge is a relation of a franchise graph, in the stored direction.
+type FranchiseEdge struct {
+	RelationID    uuid.UUID
+	ShowID        uuid.UUID
+	RelatedShowID uuid.UUID
+	Type          string
+}
+
+// FranchiseGraph holds the shows connected to a show, with the number of hops between them, and
+// the relations connecting them.
+type FranchiseGraph struct {
+	Shows  []ShowModel
+	Depths map[uuid.UUID]int
+	Edges  []FranchiseEdge
+}
+
+// NewShowRelation returns the relation to store for relating the show to the related show with
+// the given type, which is stored from the other side when it is an inverse type.
+func NewShowRelation(showID uuid.UUID, relatedShowID uuid.UUID, relationType string) *ShowRelationModel {
+	if _, stored := storedShowRelationTypes[relationType]; !stored {
+		return &ShowRelationModel{ShowID: relatedShowID, RelatedShowID: showID, Type: showRelationInverses[relationType]}
+	}
+
+	return &ShowRelationModel{ShowID: showID, RelatedShowID: relatedShowID, Type: relationType}
+}
+
+// TypeFrom returns the type of the relation seen from the given show, which must be one of its ends.
+func (m *ShowRelationModel) TypeFrom(showID uuid.UUID) string {
+	if m.ShowID == showID {
+		return m.Type
+	}
+
+	return showRelationInverses[m.Type]
+}
+
+// CreateShowRelation stores the relation after checking that both shows exist, returning
+// gorm.ErrRecordNotFound otherwise, that the shows are not already related with this type and
+// that the relation does not close a cycle its type forbids.
+func CreateShowRelation(tx *gorm.DB, relation *ShowRelationModel) error {
+	if relation.ShowID == relation.RelatedShowID {
+		return ErrShowRelatedToItself
+	}
+
+	var count int64
+	if result := tx.Model(&ShowModel{}).
+		Where("id IN ?", []uuid.UUID{relation.ShowID, relation.RelatedShowID}).
+		Count(&count); result.Error != nil {
+		return result.Error
+	}
+
+	if count != 2 {
+		return gorm.ErrRecordNotFound
+	}
+
+	if result := tx.Model(&ShowRelationModel{}).
+		Where("show_id = ? AND related_show_id = ? AND type = ?", relation.ShowID, relation.RelatedShowID, relation.Type).
+		Count(&count); result.Error != nil {
+		return result.Error
+	}
+
+	if count > 0 {
+		return ErrShowRelationExists
+	}
+
+	if !storedShowRelationTypes[relation.Type] {
+		reachable, err := isShowReachable(tx, relation.RelatedShowID, relation.ShowID, relation.Type)
+		if err != nil {
+			return err
+		}
+
+		if reachable {
+			return ErrShowRelationCycle
+		}
+	}
+
+	return tx.Create(relation).Error
+}
+
+// isShowReachable tells whether the target show can be reached from the show by following the
+// stored relations of the given type.
+func isShowReachable(tx *gorm.DB, showID uuid.UUID, targetID uuid.UUID, relationType string) (bool, error) {
+	visited := map[uuid.UUID]bool{showID: true}
+	frontier := []uuid.UUID{showID}
+
+	for len(frontier) > 0 {
+		var nextIDs []uuid.UUID
+		if result := tx.Model(&ShowRelationModel{}).
+			Where("show_id IN ? AND type = ?", frontier, relationType).
+			Pluck("related_show_id", &nextIDs); result.Error != nil {
+			return false, result.Error
+		}
+
+		frontier = nil
+
+		for _, id := range nextIDs {
+			if id == targetID {
+				return true, nil
+			}
+
+			if !visited[id] {
+				visited[id] = true
+				frontier = append(frontier, id)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// LoadRelatedShows returns the shows related to the show in either direction, with the type of
+// the relations seen from the show.
+func LoadRelatedShows(db *gorm.DB, showID uuid.UUID) ([]RelatedShow, error) {
+	var relationModels []ShowRelationModel
+	if result := db.Preload("Show").
+		Preload("RelatedShow").
+		Where("show_id = ? OR related_show_id = ?", showID, showID).
+		Order("type, created_at").
+		Find(&relationModels); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return lo.Map(relationModels, func(relationModel ShowRelationModel, _ int) RelatedShow {
+		relatedShow := RelatedShow{RelationID: relationModel.ID, Type: relationModel.TypeFrom(showID)}
+
+		if relationModel.ShowID == showID {
+			relatedShow.Show = &relationModel.RelatedShow
+		} else {
+			relatedShow.Show = &relationModel.Show
+		}
+
+		return relatedShow
+	}), nil
+}
+
+// LoadFranchiseGraph returns the shows connected to the show by at most the given number of
+// relations, whatever their type and direction. The shows are sorted by their distance to the
+// show, then by their first air date.
+func LoadFranchiseGraph(db *gorm.DB, showID uuid.UUID, depth int) (*FranchiseGraph, error) {
+	graph := &FranchiseGraph{Depths: map[uuid.UUID]int{showID: 0}}
+	seenRelations := map[uuid.UUID]bool{}
+	frontier := []uuid.UUID{showID}
+
+	for hop := 1; hop <= depth && len(frontier) > 0; hop++ {
+		var relationModels []ShowRelationModel
+		if result := db.Where("show_id IN ? OR related_show_id IN ?", frontier, frontier).
+			Order("created_at").
+			Find(&relationModels); result.Error != nil {
+			return nil, result.Error
+		}
+
+		frontier = nil
+
+		for _, relationModel := range relationModels {
+			if seenRelations[relationModel.ID] {
+				continue
+			}
+
+			seenRelations[relationModel.ID] = true
+			graph.Edges = append(graph.Edges, FranchiseEdge{
+				RelationID:    relationModel.ID,
+				ShowID:        relationModel.ShowID,
+				RelatedShowID: relationModel.RelatedShowID,
+				Type:          relationModel.Type,
+			})
+
+			for _, id := range []uuid.UUID{relationModel.ShowID, relationModel.RelatedShowID} {
+				if _, found := graph.Depths[id]; !found {
+					graph.Depths[id] = hop
+					frontier = append(frontier, id)
+				}
+			}
+		}
+	}
+
+	if result := db.Where("id IN ?", lo.Keys(graph.Depths)).Find(&graph.Shows); result.Error != nil {
+		return nil, result.Error
+	}
+
+	slices.SortStableFunc(graph.Shows, func(a, b ShowModel) int {
+		if depthA, depthB := graph.Depths[a.ID], graph.Depths[b.ID]; depthA != depthB {
+			return depthA - depthB
+		}
+
+		switch {
+		case a.FirstAirDate == nil && b.FirstAirDate == nil:
+			return 0
+		case a.FirstAirDate == nil:
+			return 1
+		case b.FirstAirDate == nil:
+			return -1
+		default:
+			return a.FirstAirDate.Compare(*b.FirstAirDate)
+		}
+	})
+
+	return graph, nil
+}
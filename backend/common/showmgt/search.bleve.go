@@ -0,0 +1,326 @@
+package showmgt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/analysis/lang/de"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/lang/es"
+	"github.com/blevesearch/bleve/v2/analysis/lang/fr"
+	"github.com/blevesearch/bleve/v2/analysis/lang/it"
+	"github.com/blevesearch/bleve/v2/analysis/lang/pt"
+	"github.com/blevesearch/bleve/v2/analysis/lang/ru"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+const (
+	// bleveDefaultTitleField is the field of the titles whose language has no dedicated analyzer.
+	bleveDefaultTitleField = "title_default"
+
+	// bleveSearchPageSize is the number of documents fetched at once when searching. A show has a
+	// document per title, so several pages may be needed to collect enough distinct shows.
+	bleveSearchPageSize = 50
+
+	// bleveIndexChunkSize is the number of shows loaded and indexed at once when rebuilding.
+	bleveIndexChunkSize = 500
+)
+
+// bleveTitleAnalyzers maps the base languages which have a dedicated title field to the analyzer
+// of the field. Chinese, Japanese and Korean titles are split into bigrams, since their words are
+// not separated by spaces.
+var bleveTitleAnalyzers = map[string]string{
+	"ja": cjk.AnalyzerName,
+	"zh": cjk.AnalyzerName,
+	"ko": cjk.AnalyzerName,
+	"en": en.AnalyzerName,
+	"fr": fr.AnalyzerName,
+	"de": de.AnalyzerName,
+	"es": es.AnalyzerName,
+	"it": it.AnalyzerName,
+	"pt": pt.AnalyzerName,
+	"ru": ru.AnalyzerName,
+}
+
+// bleveTitleField returns the field of the titles of the given locale.
+func bleveTitleField(locale string) string {
+	if lang := baseLanguage(locale); bleveTitleAnalyzers[lang] != "" {
+		return "title_" + lang
+	}
+
+	return bleveDefaultTitleField
+}
+
+// newBleveIndexMapping returns the mapping of the search documents. Every original or translated
+// title is a document, holding the title in the field of its locale.
+func newBleveIndexMapping() mapping.IndexMapping {
+	documentMapping := bleve.NewDocumentMapping()
+	documentMapping.Dynamic = false
+
+	showIDMapping := bleve.NewKeywordFieldMapping()
+	documentMapping.AddFieldMappingsAt("show_id", showIDMapping)
+
+	for _, field := range []string{"locale", "title"} {
+		storedMapping := bleve.NewTextFieldMapping()
+		storedMapping.Index = false
+		documentMapping.AddFieldMappingsAt(field, storedMapping)
+	}
+
+	for lang, analyzer := range bleveTitleAnalyzers {
+		titleMapping := bleve.NewTextFieldMapping()
+		titleMapping.Analyzer = analyzer
+		titleMapping.Store = false
+		documentMapping.AddFieldMappingsAt("title_"+lang, titleMapping)
+	}
+
+	defaultTitleMapping := bleve.NewTextFieldMapping()
+	defaultTitleMapping.Analyzer = standard.Name
+	defaultTitleMapping.Store = false
+	documentMapping.AddFieldMappingsAt(bleveDefaultTitleField, defaultTitleMapping)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = documentMapping
+
+	return indexMapping
+}
+
+// bleveSearchIndex is an embedded Bleve index of the original and translated titles. Its
+// documents are kept up to date by the search index listener.
+type bleveSearchIndex struct {
+	db   *gorm.DB
+	path string
+
+	// mu guards index, which is replaced when the index is rebuilt.
+	mu    sync.RWMutex
+	index bleve.Index
+}
+
+var _ SearchIndex = (*bleveSearchIndex)(nil)
+
+// OpenBleveSearchIndex opens the Bleve index at the given path, or creates it empty when it does
+// not exist, in which case created is true. The index is kept in memory when the path is empty.
+func OpenBleveSearchIndex(db *gorm.DB, path string) (index *bleveSearchIndex, created bool, err error) {
+	searchIndex := &bleveSearchIndex{db: db, path: path}
+
+	if path != "" {
+		searchIndex.index, err = bleve.Open(path)
+		if err == nil {
+			return searchIndex, false, nil
+		}
+
+		if !errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+			return nil, false, err
+		}
+	}
+
+	if searchIndex.index, err = newBleveIndex(path); err != nil {
+		return nil, false, err
+	}
+
+	return searchIndex, true, nil
+}
+
+// newBleveIndex creates an empty index at the given path, or in memory when it is empty.
+func newBleveIndex(path string) (bleve.Index, error) {
+	if path == "" {
+		return bleve.NewMemOnly(newBleveIndexMapping())
+	}
+
+	return bleve.New(path, newBleveIndexMapping())
+}
+
+// Close closes the index.
+func (i *bleveSearchIndex) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.index.Close()
+}
+
+// Search matches every word of the text against the title fields, each with its own analyzer.
+// Small typos are tolerated, except in CJK titles, whose bigrams are too short for it.
+func (i *bleveSearchIndex) Search(ctx context.Context, text string, limit int) ([]SearchHit, error) {
+	titleQueries := []query.Query{}
+
+	for _, field := range append(lo.Keys(bleveTitleAnalyzers), "default") {
+		titleQuery := bleve.NewMatchQuery(text)
+		titleQuery.SetField("title_" + field)
+		titleQuery.SetOperator(query.MatchQueryOperatorAnd)
+
+		if bleveTitleAnalyzers[field] != cjk.AnalyzerName {
+			titleQuery.SetFuzziness(1)
+		}
+
+		titleQueries = append(titleQueries, titleQuery)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	hits := []SearchHit{}
+	seenShows := map[uuid.UUID]bool{}
+
+	for from := 0; len(hits) < limit; from += bleveSearchPageSize {
+		request := bleve.NewSearchRequestOptions(bleve.NewDisjunctionQuery(titleQueries...),
+			bleveSearchPageSize, from, false)
+		request.Fields = []string{"show_id", "locale", "title"}
+
+		result, err := i.index.SearchInContext(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range result.Hits {
+			showID, err := uuid.Parse(fmt.Sprint(match.Fields["show_id"]))
+			if err != nil || seenShows[showID] || len(hits) == limit {
+				continue
+			}
+
+			seenShows[showID] = true
+			hits = append(hits, SearchHit{
+				ShowID: showID,
+				Locale: fmt.Sprint(match.Fields["locale"]),
+				Title:  fmt.Sprint(match.Fields["title"]),
+				Score:  match.Score,
+			})
+		}
+
+		if len(result.Hits) < bleveSearchPageSize {
+			break
+		}
+	}
+
+	return hits, nil
+}
+
+// IndexShows replaces the documents of the given shows with their current titles.
+func (i *bleveSearchIndex) IndexShows(ctx context.Context, showIDs []uuid.UUID) error {
+	var showModels []ShowModel
+	if result := i.db.WithContext(ctx).
+		Preload("Translations").
+		Where("id IN ?", showIDs).
+		Find(&showModels); result.Error != nil {
+		return result.Error
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	batch := i.index.NewBatch()
+
+	for _, showID := range showIDs {
+		showQuery := bleve.NewTermQuery(showID.String())
+		showQuery.SetField("show_id")
+
+		result, err := i.index.SearchInContext(ctx, bleve.NewSearchRequestOptions(showQuery, 1000, 0, false))
+		if err != nil {
+			return err
+		}
+
+		for _, match := range result.Hits {
+			batch.Delete(match.ID)
+		}
+	}
+
+	if err := addBleveDocuments(batch, showModels); err != nil {
+		return err
+	}
+
+	return i.index.Batch(batch)
+}
+
+// Rebuild replaces the index with a new one, with the current mapping, and indexes every show.
+// The new index is built next to the current one, which keeps serving searches meanwhile.
+func (i *bleveSearchIndex) Rebuild(ctx context.Context) error {
+	buildPath := lo.Ternary(i.path == "", "", i.path+".rebuild")
+	if buildPath != "" {
+		if err := os.RemoveAll(buildPath); err != nil {
+			return err
+		}
+	}
+
+	index, err := newBleveIndex(buildPath)
+	if err != nil {
+		return err
+	}
+
+	var showModels []ShowModel
+	if result := i.db.WithContext(ctx).
+		Preload("Translations").
+		FindInBatches(&showModels, bleveIndexChunkSize, func(_ *gorm.DB, _ int) error {
+			batch := index.NewBatch()
+			if err := addBleveDocuments(batch, showModels); err != nil {
+				return err
+			}
+
+			return index.Batch(batch)
+		}); result.Error != nil {
+		_ = index.Close()
+
+		return result.Error
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.path == "" {
+		_ = i.index.Close()
+		i.index = index
+
+		return nil
+	}
+
+	if err := index.Close(); err != nil {
+		return err
+	}
+
+	if err := i.index.Close(); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(i.path); err != nil {
+		return err
+	}
+
+	if err := os.Rename(buildPath, i.path); err != nil {
+		return err
+	}
+
+	i.index, err = bleve.Open(i.path)
+
+	return err
+}
+
+// addBleveDocuments adds a document per original or translated title of the shows to the batch.
+func addBleveDocuments(batch *bleve.Batch, showModels []ShowModel) error {
+	for _, showModel := range showModels {
+		titles := append([]ShowTranslationModel{{
+			Locale: showModel.OriginalLanguage,
+			Title:  showModel.OriginalTitle,
+		}}, showModel.Translations...)
+
+		for n, title := range titles {
+			documentID := fmt.Sprintf("%s/%d", showModel.ID, n)
+			if err := batch.Index(documentID, map[string]any{
+				"show_id":                     showModel.ID.String(),
+				"locale":                      title.Locale,
+				"title":                       title.Title,
+				bleveTitleField(title.Locale): title.Title,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
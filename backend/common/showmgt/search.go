@@ -0,0 +1,154 @@
+package showmgt
+
+import (
+	"context"
+	"log/slog"
+	"wano-island/common/core"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// SearchHit is a show matching a search, with the original or translated title which matched.
+// The scale of the score depends on the backend; only the order of the hits is meaningful.
+type SearchHit struct {
+	ShowID uuid.UUID
+	Locale string
+	Title  string
+	Score  float64
+}
+
+// SearchIndex searches the catalog by title.
+type SearchIndex interface {
+	// Search returns the shows whose original or translated titles best match the text, the best
+	// match first. Each show is returned once, with its best matching title.
+	Search(ctx context.Context, text string, limit int) ([]SearchHit, error)
+
+	// IndexShows brings the given shows up to date in the index. Shows which no longer exist are
+	// removed from it.
+	IndexShows(ctx context.Context, showIDs []uuid.UUID) error
+
+	// Rebuild indexes the whole catalog from scratch.
+	Rebuild(ctx context.Context) error
+}
+
+type SearchIndexParams struct {
+	fx.In
+
+	Logger       *slog.Logger
+	DB           *gorm.DB
+	Config       core.AppConfig
+	AppLifeCycle fx.Lifecycle
+}
+
+// NewSearchIndex returns the search index of the backend set in the search configuration. The
+// Bleve index is opened, or created empty, at the configured path and closed when the app stops.
+func NewSearchIndex(p SearchIndexParams) (SearchIndex, error) {
+	searchConfig := p.Config.GetSearchConfig()
+	if searchConfig.Backend != core.BleveSearchBackend {
+		return NewPostgresSearchIndex(p.DB), nil
+	}
+
+	index, created, err := OpenBleveSearchIndex(p.DB, searchConfig.IndexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		p.Logger.Warn("The search index has been created empty, it must be rebuilt",
+			slog.String("path", searchConfig.IndexPath))
+	}
+
+	p.AppLifeCycle.Append(fx.Hook{
+		OnStop: func(_ context.Context) error {
+			return index.Close()
+		},
+	})
+
+	return index, nil
+}
+
+// postgresSearchQuery matches the search text against the original and translated titles with the
+// word similarity of pg_trgm, so that typos and partial words still match. The "<%" operator is
+// served by the trigram GIN indexes; only the best matching title of each show is kept.
+const postgresSearchQuery = `SELECT matches.show_id, matches.locale, matches.title, matches.score
+FROM (
+	SELECT DISTINCT ON (show_id) show_id, locale, title, score FROM (
+		SELECT id AS show_id, original_language AS locale, original_title AS title,
+			word_similarity(@text, original_title) AS score
+		FROM public.shows WHERE @text <% original_title
+		UNION ALL
+		SELECT show_id, locale, title, word_similarity(@text, title) AS score
+		FROM public.show_translations WHERE @text <% title
+	) AS candidates
+	ORDER BY show_id, score DESC
+) AS matches
+JOIN public.shows ON shows.id = matches.show_id
+ORDER BY matches.score DESC, shows.original_title
+LIMIT @limit`
+
+// postgresSearchIndex searches the catalog tables directly, so it never needs to be updated.
+type postgresSearchIndex struct {
+	db *gorm.DB
+}
+
+var _ SearchIndex = (*postgresSearchIndex)(nil)
+
+func NewPostgresSearchIndex(db *gorm.DB) *postgresSearchIndex {
+	return &postgresSearchIndex{db: db}
+}
+
+func (i *postgresSearchIndex) Search(ctx context.Context, text string, limit int) ([]SearchHit, error) {
+	hits := []SearchHit{}
+	if result := i.db.WithContext(ctx).Raw(postgresSearchQuery, map[string]any{
+		"text":  text,
+		"limit": limit,
+	}).Scan(&hits); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return hits, nil
+}
+
+func (i *postgresSearchIndex) IndexShows(context.Context, []uuid.UUID) error {
+	return nil
+}
+
+func (i *postgresSearchIndex) Rebuild(context.Context) error {
+	return nil
+}
+
+// searchIndexListener keeps the search index up to date with the changes of the shows.
+type searchIndexListener struct {
+	index SearchIndex
+}
+
+type SearchIndexListenerParams struct {
+	fx.In
+
+	Index SearchIndex
+}
+
+var _ CatalogEventListener = (*searchIndexListener)(nil)
+
+func NewSearchIndexListener(p SearchIndexListenerParams) *searchIndexListener {
+	return &searchIndexListener{index: p.Index}
+}
+
+func (l *searchIndexListener) Name() string {
+	return "search index"
+}
+
+// OnCatalogEvents reindexes the shows which have changed.
+func (l *searchIndexListener) OnCatalogEvents(ctx context.Context, events []CatalogEvent) error {
+	showIDs := lo.Uniq(lo.FilterMap(events, func(event CatalogEvent, _ int) (uuid.UUID, bool) {
+		return event.ShowID, event.Type == ShowChangedCatalogEvent
+	}))
+	if len(showIDs) == 0 {
+		return nil
+	}
+
+	return l.index.IndexShows(ctx, showIDs)
+}
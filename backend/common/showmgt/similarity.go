@@ -0,0 +1,47 @@
+package showmgt
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// similarityKeywordWeight is the weight of the keyword overlap in the similarity score.
+	similarityKeywordWeight = 0.75
+
+	// similarityLanguageWeight is the weight of sharing the same original language in the similarity score.
+	similarityLanguageWeight = 0.25
+)
+
+// ScoreSimilarity returns a weighted similarity score between 0 and 1 for the given shows.
+// The score combines the Jaccard index of their keywords (case-insensitive) and whether
+// they share the same original language.
+func ScoreSimilarity(a *ShowModel, b *ShowModel) float64 {
+	score := similarityKeywordWeight * jaccardIndex(normalizeKeywords(a.Keywords), normalizeKeywords(b.Keywords))
+
+	if a.OriginalLanguage != "" && strings.EqualFold(a.OriginalLanguage, b.OriginalLanguage) {
+		score += similarityLanguageWeight
+	}
+
+	return score
+}
+
+// normalizeKeywords lowercases and trims the given keywords and removes duplicates.
+func normalizeKeywords(keywords []string) []string {
+	normalized := lo.Map(keywords, func(keyword string, _ int) string {
+		return strings.ToLower(strings.TrimSpace(keyword))
+	})
+
+	return lo.Uniq(lo.Compact(normalized))
+}
+
+// jaccardIndex returns the size of the intersection divided by the size of the union of the given sets.
+func jaccardIndex(a []string, b []string) float64 {
+	union := lo.Union(a, b)
+	if len(union) == 0 {
+		return 0
+	}
+
+	return float64(len(lo.Intersect(a, b))) / float64(len(union))
+}
@@ -0,0 +1,310 @@
+package showmgt
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"wano-island/common/core"
+
+	"github.com/samber/lo"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+// maxSlugLength is the maximum length of a generated slug, without the year and the uniqueness suffix.
+const maxSlugLength = 80
+
+// reservedShowSlugs are the static path segments of the routes registered under /api/v1/shows/
+// (see ShowRouteSegments), which would shadow a show with the same slug at /api/v1/shows/{id}.
+// A new static segment must be added here.
+var reservedShowSlugs = []string{"batch", "suggest", "trending"}
+
+// transliterations maps letters which are not decomposed by Unicode normalization to ASCII.
+var transliterations = map[rune]string{
+	'đ': "d", 'ð': "d", 'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'þ': "th", 'ı': "i",
+}
+
+// kanaRomaji maps hiragana (single characters and contracted sounds) to their Hepburn romanization.
+// Katakana is converted to hiragana before the lookup.
+var kanaRomaji = map[string]string{
+	"あ": "a", "い": "i", "う": "u", "え": "e", "お": "o",
+	"か": "ka", "き": "ki", "く": "ku", "け": "ke", "こ": "ko",
+	"が": "ga", "ぎ": "gi", "ぐ": "gu", "げ": "ge", "ご": "go",
+	"さ": "sa", "し": "shi", "す": "su", "せ": "se", "そ": "so",
+	"ざ": "za", "じ": "ji", "ず": "zu", "ぜ": "ze", "ぞ": "zo",
+	"た": "ta", "ち": "chi", "つ": "tsu", "て": "te", "と": "to",
+	"だ": "da", "ぢ": "ji", "づ": "zu", "で": "de", "ど": "do",
+	"な": "na", "に": "ni", "ぬ": "nu", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "hi", "ふ": "fu", "へ": "he", "ほ": "ho",
+	"ば": "ba", "び": "bi", "ぶ": "bu", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pi", "ぷ": "pu", "ぺ": "pe", "ぽ": "po",
+	"ま": "ma", "み": "mi", "む": "mu", "め": "me", "も": "mo",
+	"や": "ya", "ゆ": "yu", "よ": "yo",
+	"ら": "ra", "り": "ri", "る": "ru", "れ": "re", "ろ": "ro",
+	"わ": "wa", "ゐ": "i", "ゑ": "e", "を": "o", "ん": "n", "ゔ": "vu",
+	"ぁ": "a", "ぃ": "i", "ぅ": "u", "ぇ": "e", "ぉ": "o", "ゃ": "ya", "ゅ": "yu", "ょ": "yo", "ゎ": "wa",
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo", "ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "じゃ": "ja", "じゅ": "ju", "じょ": "jo",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo", "びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo", "みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo", "てぃ": "ti", "でぃ": "di",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo", "しぇ": "she", "じぇ": "je", "ちぇ": "che",
+}
+
+// Slugify converts the given text into a URL-friendly slug made of lowercase ASCII letters,
+// digits and hyphens (e.g. "One Piece" becomes "one-piece"). Latin letters with diacritics are
+// transliterated to ASCII and Japanese kana are romanized. Characters which cannot be
+// transliterated (e.g. kanji) are dropped, so the result may be empty.
+func Slugify(text string) string {
+	var builder strings.Builder
+
+	for _, r := range norm.NFKD.String(romanizeKana(text)) {
+		r = unicode.ToLower(r)
+
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			builder.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+			// Drop the combining marks left by the decomposition (e.g. accents).
+		case transliterations[r] != "":
+			builder.WriteString(transliterations[r])
+		default:
+			builder.WriteRune('-')
+		}
+	}
+
+	slug := strings.Join(lo.Compact(strings.Split(builder.String(), "-")), "-")
+
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+		if index := strings.LastIndex(slug, "-"); index > maxSlugLength/2 {
+			slug = slug[:index]
+		}
+	}
+
+	return slug
+}
+
+// romanizeKana replaces hiragana and katakana in the given text with their Hepburn romanization.
+func romanizeKana(text string) string {
+	const katakanaToHiraganaOffset = 0x60
+
+	runes := []rune(text)
+
+	// Convert katakana to hiragana so that a single table is enough.
+	for i, r := range runes {
+		if r >= 'ァ' && r <= 'ヶ' {
+			runes[i] = r - katakanaToHiraganaOffset
+		}
+	}
+
+	var builder strings.Builder
+
+	doubleNextConsonant := false
+
+	for i := 0; i < len(runes); i++ {
+		romaji, size := "", 0
+
+		if i+1 < len(runes) {
+			romaji, size = kanaRomaji[string(runes[i:i+2])], 2
+		}
+
+		if romaji == "" {
+			romaji, size = kanaRomaji[string(runes[i])], 1
+		}
+
+		switch {
+		case runes[i] == 'っ':
+			doubleNextConsonant = true
+		case runes[i] == 'ー':
+			// The long vowel mark only extends the previous sound.
+		case romaji != "":
+			if doubleNextConsonant {
+				builder.WriteByte(romaji[0])
+			}
+
+			builder.WriteString(romaji)
+
+			doubleNextConsonant = false
+			i += size - 1
+		default:
+			builder.WriteRune(runes[i])
+
+			doubleNextConsonant = false
+		}
+	}
+
+	return builder.String()
+}
+
+// ShowSlugBase returns the slug a show should have, before making it unique. The original
+// title is used first, then the translated titles (English first), followed by the year of the
+// first air date when it is known (e.g. "one-piece-1999"). If none of the titles can be
+// transliterated, a prefix of the show ID is used.
+func ShowSlugBase(show *ShowModel) string {
+	titles := []string{show.OriginalTitle}
+
+	for _, translation := range show.Translations {
+		if translation.Locale == "en" {
+			titles = append(titles, translation.Title)
+		}
+	}
+
+	for _, translation := range show.Translations {
+		if translation.Locale != "en" {
+			titles = append(titles, translation.Title)
+		}
+	}
+
+	slug, found := lo.Find(lo.Map(titles, func(title string, _ int) string {
+		return Slugify(title)
+	}), func(slug string) bool {
+		return slug != ""
+	})
+
+	if !found {
+		return "show-" + strings.Split(show.ID.String(), "-")[0]
+	}
+
+	return withSlugYear(slug, show)
+}
+
+// withSlugYear appends the year of the first air date of the show to the slug, unless the slug
+// is empty, already ends with it or the date is unknown.
+func withSlugYear(slug string, show *ShowModel) string {
+	if slug == "" || show.FirstAirDate == nil {
+		return slug
+	}
+
+	year := strconv.Itoa(show.FirstAirDate.Year())
+	if slug == year || strings.HasSuffix(slug, "-"+year) {
+		return slug
+	}
+
+	return slug + "-" + year
+}
+
+// ShowRouteSegments returns the static path segments which directly follow /api/v1/shows/ in the
+// patterns of the given routes, such as "trending" for "GET /api/v1/shows/trending".
+func ShowRouteSegments(routes []core.HTTPRoute) []string {
+	return lo.Uniq(lo.FilterMap(routes, func(route core.HTTPRoute, _ int) (string, bool) {
+		pattern := route.Pattern()
+		if _, path, found := strings.Cut(pattern, " "); found {
+			pattern = path
+		}
+
+		rest, found := strings.CutPrefix(pattern, "/api/v1/shows/")
+		if !found {
+			return "", false
+		}
+
+		segment, _, _ := strings.Cut(rest, "/")
+
+		return segment, segment != "" && !strings.HasPrefix(segment, "{")
+	}))
+}
+
+// IsReservedShowSlug reports whether the slug cannot be assigned to a show because it is a static
+// path segment of the show routes.
+func IsReservedShowSlug(slug string) bool {
+	return lo.Contains(reservedShowSlugs, slug)
+}
+
+// uniqueSlug returns the given slug, or the slug with the smallest numeric suffix ("-2", "-3"...),
+// that is not reserved and has never been used by a show other than the given one, whatever the
+// locale. Slugs are looked up across the original and the translated slugs, so a slug must
+// identify a single show. The slug history holds every slug ever assigned, current ones included.
+func uniqueSlug(tx *gorm.DB, slug string, show *ShowModel) (string, error) {
+	var takenSlugs []string
+
+	if result := tx.Model(&ShowSlugModel{}).
+		Where("(slug = ? OR slug LIKE ?) AND show_id <> ?", slug, slug+"-%", show.ID).
+		Pluck("slug", &takenSlugs); result.Error != nil {
+		return "", result.Error
+	}
+
+	takenSlugs = append(takenSlugs, reservedShowSlugs...)
+
+	candidate := slug
+	for suffix := 2; lo.Contains(takenSlugs, candidate); suffix++ {
+		candidate = slug + "-" + strconv.Itoa(suffix)
+	}
+
+	return candidate, nil
+}
+
+// hasSlugBase reports whether the slug has been generated from the given base,
+// with or without a uniqueness suffix.
+func hasSlugBase(slug string, base string) bool {
+	if slug == base {
+		return true
+	}
+
+	suffix, found := strings.CutPrefix(slug, base+"-")
+	if !found {
+		return false
+	}
+
+	_, err := strconv.Atoi(suffix)
+
+	return err == nil
+}
+
+// AssignShowSlugs makes sure the show and each of its translations have an up-to-date slug.
+// A new slug is only generated when the title it is built from has changed, and every slug ever
+// assigned is kept in the public.show_slugs table, so that old URLs can be redirected.
+// The show must already exist in the database.
+func AssignShowSlugs(tx *gorm.DB, show *ShowModel) error {
+	base := ShowSlugBase(show)
+
+	if !hasSlugBase(lo.FromPtr(show.Slug), base) {
+		slug, err := uniqueSlug(tx, base, show)
+		if err != nil {
+			return err
+		}
+
+		if err = recordSlug(tx, show, "", slug); err != nil {
+			return err
+		}
+
+		if result := tx.Model(show).UpdateColumn("slug", slug); result.Error != nil {
+			return result.Error
+		}
+
+		show.Slug = &slug
+	}
+
+	for i := range show.Translations {
+		translation := &show.Translations[i]
+
+		translationBase := withSlugYear(Slugify(translation.Title), show)
+		if translationBase == "" || hasSlugBase(lo.FromPtr(translation.Slug), translationBase) {
+			continue
+		}
+
+		slug, err := uniqueSlug(tx, translationBase, show)
+		if err != nil {
+			return err
+		}
+
+		if err = recordSlug(tx, show, translation.Locale, slug); err != nil {
+			return err
+		}
+
+		if result := tx.Model(translation).UpdateColumn("slug", slug); result.Error != nil {
+			return result.Error
+		}
+
+		translation.Slug = &slug
+	}
+
+	return nil
+}
+
+// recordSlug adds the slug to the slug history of the show.
+func recordSlug(tx *gorm.DB, show *ShowModel, locale string, slug string) error {
+	return tx.Where(ShowSlugModel{ShowID: show.ID, Locale: locale, Slug: slug}).
+		FirstOrCreate(&ShowSlugModel{}).Error
+}
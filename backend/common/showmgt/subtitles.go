@@ -0,0 +1,332 @@
+package showmgt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Formats of the subtitle files.
+const (
+	SRTSubtitleFormat    = "srt"
+	WebVTTSubtitleFormat = "vtt"
+)
+
+const (
+	// MaxSubtitleFileSize is the maximum size in bytes of an uploaded subtitle file.
+	MaxSubtitleFileSize = 2 << 20
+
+	// maxSubtitleErrors is the maximum number of problems reported for an invalid subtitle file.
+	maxSubtitleErrors = 20
+)
+
+// subtitleTimestampPattern matches the timestamps of both formats: SRT uses a comma before the
+// milliseconds and always has the hours, WebVTT uses a dot and may omit the hours.
+var subtitleTimestampPattern = regexp.MustCompile(`^(?:(\d{2,}):)?([0-5]\d):([0-5]\d)([,.])(\d{3})$`)
+
+// SubtitleUploadParams holds the path and query parameters of a subtitle file upload.
+type SubtitleUploadParams struct {
+	Language string `json:"language" validate:"required,bcp47_language_tag"`
+
+	// The format of the file, detected from its content when empty.
+	Format string `json:"format" validate:"omitempty,oneof=srt vtt"`
+}
+
+// SubtitleCue is a text displayed between two instants of an episode or a movie. Lines of the
+// text are separated by "\n".
+type SubtitleCue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// SubtitleProblem is a problem found at a line of a subtitle file.
+type SubtitleProblem struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// SubtitleProblems is the error returned when a subtitle file cannot be parsed or has invalid cues.
+type SubtitleProblems []SubtitleProblem
+
+func (p SubtitleProblems) Error() string {
+	messages := make([]string, 0, len(p))
+	for _, problem := range p {
+		messages = append(messages, fmt.Sprintf("line %d: %s", problem.Line, problem.Message))
+	}
+
+	return "invalid subtitles: " + strings.Join(messages, "; ")
+}
+
+// ErrInvalidSubtitleOffset is returned when the timing offset of a download is not a number of milliseconds.
+var ErrInvalidSubtitleOffset = errors.New("the subtitle offset is invalid")
+
+// DetectSubtitleFormat returns the format of the given file: WebVTT files start with a "WEBVTT"
+// header, anything else is read as SRT.
+func DetectSubtitleFormat(content []byte) string {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	if bytes.HasPrefix(content, []byte("WEBVTT")) {
+		return WebVTTSubtitleFormat
+	}
+
+	return SRTSubtitleFormat
+}
+
+// ParseSubtitles reads the cues of an SRT or WebVTT file and validates them. Cues must end after
+// they start, and must not start before the end of the previous cue. A SubtitleProblems error
+// lists what is wrong with the file. WebVTT notes, styles, regions and cue settings are dropped.
+func ParseSubtitles(content []byte, format string) ([]SubtitleCue, error) {
+	text := strings.TrimPrefix(string(content), "\ufeff")
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	lines := strings.Split(text, "\n")
+
+	parser := &subtitleParser{format: format}
+
+	for start := 0; start < len(lines); {
+		for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+			start++
+		}
+
+		end := start
+		for end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+			end++
+		}
+
+		if start < end {
+			parser.parseBlock(start+1, lines[start:end])
+		}
+
+		start = end
+	}
+
+	if format == WebVTTSubtitleFormat && !parser.hasHeader {
+		parser.addProblem(1, `The WebVTT file must start with "WEBVTT"`)
+	}
+
+	if len(parser.cues) == 0 && len(parser.problems) == 0 {
+		parser.addProblem(1, "The file has no cues")
+	}
+
+	if len(parser.problems) > 0 {
+		return nil, parser.problems
+	}
+
+	return parser.cues, nil
+}
+
+// subtitleParser collects the cues and the problems while the blocks of a file are read.
+type subtitleParser struct {
+	format    string
+	hasHeader bool
+	cues      []SubtitleCue
+	problems  SubtitleProblems
+}
+
+func (p *subtitleParser) addProblem(line int, message string) {
+	if len(p.problems) < maxSubtitleErrors {
+		p.problems = append(p.problems, SubtitleProblem{Line: line, Message: message})
+	}
+}
+
+// parseBlock reads a block of non-empty lines, starting at the given line number.
+func (p *subtitleParser) parseBlock(lineNumber int, lines []string) {
+	if p.format == WebVTTSubtitleFormat {
+		if lineNumber == 1 {
+			p.hasHeader = strings.HasPrefix(lines[0], "WEBVTT")
+			if p.hasHeader {
+				return
+			}
+		}
+
+		if keyword := strings.Fields(lines[0]); len(keyword) > 0 &&
+			(keyword[0] == "NOTE" || keyword[0] == "STYLE" || keyword[0] == "REGION") {
+			return
+		}
+	}
+
+	// The timing line is preceded by the cue number in SRT, and by an optional identifier in WebVTT.
+	timingIndex := 0
+	if !strings.Contains(lines[0], "-->") {
+		timingIndex = 1
+	}
+
+	if timingIndex >= len(lines) || !strings.Contains(lines[timingIndex], "-->") {
+		p.addProblem(lineNumber, "The cue has no timing line")
+
+		return
+	}
+
+	timingLine := lineNumber + timingIndex
+	startValue, rest, _ := strings.Cut(lines[timingIndex], "-->")
+	endValue := strings.Fields(rest)
+
+	start, ok := p.parseTimestamp(strings.TrimSpace(startValue))
+	if !ok {
+		p.addProblem(timingLine, fmt.Sprintf("The start timestamp %q is invalid", strings.TrimSpace(startValue)))
+
+		return
+	}
+
+	if len(endValue) == 0 {
+		p.addProblem(timingLine, "The cue has no end timestamp")
+
+		return
+	}
+
+	end, ok := p.parseTimestamp(endValue[0])
+	if !ok {
+		p.addProblem(timingLine, fmt.Sprintf("The end timestamp %q is invalid", endValue[0]))
+
+		return
+	}
+
+	cue := SubtitleCue{Start: start, End: end}
+
+	textLines := make([]string, 0, len(lines)-timingIndex-1)
+	for _, line := range lines[timingIndex+1:] {
+		textLines = append(textLines, strings.TrimRight(line, " \t"))
+	}
+
+	cue.Text = strings.Join(textLines, "\n")
+
+	switch {
+	case cue.Text == "":
+		p.addProblem(timingLine, "The cue has no text")
+	case cue.End <= cue.Start:
+		p.addProblem(timingLine, "The cue ends before it starts")
+	case len(p.cues) > 0 && cue.Start < p.cues[len(p.cues)-1].End:
+		p.addProblem(timingLine, "The cue overlaps the previous cue")
+	}
+
+	p.cues = append(p.cues, cue)
+}
+
+// parseTimestamp parses a timestamp in the format of the file.
+func (p *subtitleParser) parseTimestamp(value string) (time.Duration, bool) {
+	matches := subtitleTimestampPattern.FindStringSubmatch(value)
+	if matches == nil ||
+		(p.format == SRTSubtitleFormat && (matches[1] == "" || matches[4] != ",")) ||
+		(p.format == WebVTTSubtitleFormat && matches[4] != ".") {
+		return 0, false
+	}
+
+	hours, _ := strconv.Atoi(matches[1])
+	minutes, _ := strconv.Atoi(matches[2])
+	seconds, _ := strconv.Atoi(matches[3])
+	milliseconds, _ := strconv.Atoi(matches[5])
+
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(milliseconds)*time.Millisecond, true
+}
+
+// ParseSubtitleOffset parses a timing offset given in milliseconds, which can be negative.
+// An empty value is no offset.
+func ParseSubtitleOffset(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	milliseconds, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, ErrInvalidSubtitleOffset
+	}
+
+	return time.Duration(milliseconds) * time.Millisecond, nil
+}
+
+// ShiftSubtitles moves the cues by the given offset. Cues moved before the beginning are cut at
+// zero, or dropped when they end there.
+func ShiftSubtitles(cues []SubtitleCue, offset time.Duration) []SubtitleCue {
+	shifted := make([]SubtitleCue, 0, len(cues))
+
+	for _, cue := range cues {
+		cue.Start = max(cue.Start+offset, 0)
+		cue.End += offset
+
+		if cue.End > 0 {
+			shifted = append(shifted, cue)
+		}
+	}
+
+	return shifted
+}
+
+// WriteSubtitles writes the cues in the given format.
+func WriteSubtitles(w io.Writer, cues []SubtitleCue, format string) error {
+	var buffer bytes.Buffer
+
+	if format == WebVTTSubtitleFormat {
+		buffer.WriteString("WEBVTT\n\n")
+	}
+
+	for i, cue := range cues {
+		if format == SRTSubtitleFormat {
+			fmt.Fprintf(&buffer, "%d\n", i+1)
+		}
+
+		fmt.Fprintf(&buffer, "%s --> %s\n%s\n\n",
+			formatSubtitleTimestamp(cue.Start, format),
+			formatSubtitleTimestamp(cue.End, format),
+			cue.Text)
+	}
+
+	_, err := w.Write(buffer.Bytes())
+
+	return err
+}
+
+// formatSubtitleTimestamp formats a timestamp with the hours, in the given format.
+func formatSubtitleTimestamp(timestamp time.Duration, format string) string {
+	separator := "."
+	if format == SRTSubtitleFormat {
+		separator = ","
+	}
+
+	milliseconds := timestamp.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d",
+		milliseconds/3600000, milliseconds/60000%60, milliseconds/1000%60, separator, milliseconds%1000)
+}
+
+// NormalizeSubtitles returns the cues as the WebVTT file stored for a subtitle track.
+func NormalizeSubtitles(cues []SubtitleCue) string {
+	var buffer bytes.Buffer
+	_ = WriteSubtitles(&buffer, cues, WebVTTSubtitleFormat)
+
+	return buffer.String()
+}
+
+// SaveSubtitleTrack stores the given track, replacing the track of the same show or episode in
+// the same language when there is one. created tells whether the track is new.
+func SaveSubtitleTrack(tx *gorm.DB, subtitle *SubtitleModel) (created bool, err error) {
+	query := tx.Where("show_id = ? AND language = ?", subtitle.ShowID, subtitle.Language)
+	if subtitle.EpisodeID != nil {
+		query = query.Where("episode_id = ?", *subtitle.EpisodeID)
+	} else {
+		query = query.Where("episode_id IS NULL")
+	}
+
+	var existing SubtitleModel
+	if result := query.Select("id", "created_at").Limit(1).Find(&existing); result.Error != nil {
+		return false, result.Error
+	}
+
+	if existing.ID == uuid.Nil {
+		return true, tx.Create(subtitle).Error
+	}
+
+	subtitle.ID = existing.ID
+	subtitle.CreatedAt = existing.CreatedAt
+
+	return false, tx.Model(subtitle).Select("cue_count", "content", "updated_at").Updates(subtitle).Error
+}
@@ -0,0 +1,81 @@
+package showmgt
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/text/language"
+)
+
+// LocalizedShow holds the title and overview of a show in the locale that best matches the client.
+type LocalizedShow struct {
+	Locale   string
+	Title    string
+	Overview *string
+}
+
+// LocalizeShow picks the translation of the given show which best matches the given languages.
+// Languages are checked in order; an exact locale match wins over a match on the base language
+// (e.g. "vi" for "vi-VN"). If no translation matches, the original title and overview are returned.
+func LocalizeShow(show *ShowModel, languages []string) LocalizedShow {
+	for _, lang := range languages {
+		if translation, ok := findTranslation(show.Translations, lang); ok {
+			return LocalizedShow{
+				Locale:   translation.Locale,
+				Title:    translation.Title,
+				Overview: lo.Ternary(translation.Overview == "", nil, &translation.Overview),
+			}
+		}
+	}
+
+	return LocalizedShow{
+		Locale:   show.OriginalLanguage,
+		Title:    show.OriginalTitle,
+		Overview: show.OriginalOverview,
+	}
+}
+
+// findTranslation returns the translation matching the given language, preferring an exact match.
+func findTranslation(translations []ShowTranslationModel, lang string) (*ShowTranslationModel, bool) {
+	for i := range translations {
+		if strings.EqualFold(translations[i].Locale, lang) {
+			return &translations[i], true
+		}
+	}
+
+	base := baseLanguage(lang)
+	for i := range translations {
+		if baseLanguage(translations[i].Locale) == base {
+			return &translations[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// baseLanguage returns the base language of the given BCP 47 tag (e.g. "vi" for "vi-VN").
+func baseLanguage(tag string) string {
+	base, _ := language.Make(tag).Base()
+
+	return base.String()
+}
+
+// localizeKeyword returns the label of the keyword which best matches the given languages,
+// preferring an exact locale match, or its canonical name when no label matches.
+func localizeKeyword(keyword *KeywordModel, languages []string) string {
+	for _, lang := range languages {
+		if label, found := lo.Find(keyword.Labels, func(label KeywordLabelModel) bool {
+			return strings.EqualFold(label.Locale, lang)
+		}); found {
+			return label.Label
+		}
+
+		if label, found := lo.Find(keyword.Labels, func(label KeywordLabelModel) bool {
+			return baseLanguage(label.Locale) == baseLanguage(lang)
+		}); found {
+			return label.Label
+		}
+	}
+
+	return keyword.Name
+}
@@ -0,0 +1,165 @@
+package showmgt
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// Sites hosting the videos.
+const (
+	YouTubeVideoSite = "youtube"
+	VimeoVideoSite   = "vimeo"
+	URLVideoSite     = "url"
+)
+
+// Types of videos.
+const (
+	TrailerVideoType         = "trailer"
+	TeaserVideoType          = "teaser"
+	ClipVideoType            = "clip"
+	FeaturetteVideoType      = "featurette"
+	BehindTheScenesVideoType = "behind_the_scenes"
+)
+
+var (
+	// ErrInvalidVideoKey is returned when the key of a video does not match the format of its site.
+	ErrInvalidVideoKey = errors.New("the video key is invalid for its site")
+
+	// ErrVideoHostNotAllowed is returned when a self-hosted video is not served by an allowed host.
+	ErrVideoHostNotAllowed = errors.New("the video host is not allowed")
+
+	// ErrEpisodeNotFound is returned when the episode of a video or a subtitle track does not exist
+	// or belongs to another show.
+	ErrEpisodeNotFound = errors.New("the episode does not belong to the show")
+)
+
+var (
+	youTubeVideoKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoVideoKeyPattern   = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// VideoRequestBody holds the request body for creating or updating a video.
+type VideoRequestBody struct {
+	// The episode of the show the video belongs to, if any.
+	EpisodeID *uuid.UUID `json:"episodeId"`
+
+	// The site hosting the video.
+	Site string `json:"site" validate:"required,oneof=youtube vimeo url"`
+
+	// The video ID on YouTube or Vimeo, or the URL of a self-hosted video.
+	Key string `json:"key" validate:"required,max=2048"`
+
+	Name       string `json:"name" validate:"max=256"`
+	Language   string `json:"language" validate:"required,bcp47_language_tag"`
+	Type       string `json:"type" validate:"required,oneof=trailer teaser clip featurette behind_the_scenes"`
+	IsOfficial bool   `json:"isOfficial"`
+}
+
+// ValidateVideoKey checks the key against the format of the site. Self-hosted videos must be
+// served over HTTP(S) by one of the allowed hosts.
+func ValidateVideoKey(site string, key string, allowedHosts []string) error {
+	switch site {
+	case YouTubeVideoSite:
+		if !youTubeVideoKeyPattern.MatchString(key) {
+			return ErrInvalidVideoKey
+		}
+	case VimeoVideoSite:
+		if !vimeoVideoKeyPattern.MatchString(key) {
+			return ErrInvalidVideoKey
+		}
+	case URLVideoSite:
+		videoURL, err := url.Parse(key)
+		if err != nil || (videoURL.Scheme != "https" && videoURL.Scheme != "http") || videoURL.Host == "" {
+			return ErrInvalidVideoKey
+		}
+
+		if !slices.Contains(allowedHosts, strings.ToLower(videoURL.Hostname())) {
+			return ErrVideoHostNotAllowed
+		}
+	default:
+		return ErrInvalidVideoKey
+	}
+
+	return nil
+}
+
+// checkShowEpisode checks that the episode of a video or a subtitle track, if any, belongs to its show.
+func checkShowEpisode(tx *gorm.DB, showID uuid.UUID, episodeID *uuid.UUID) error {
+	if episodeID == nil {
+		return nil
+	}
+
+	var count int64
+	if result := tx.Model(&EpisodeModel{}).Where("id = ? AND show_id = ?", *episodeID, showID).Count(&count); result.Error != nil {
+		return result.Error
+	}
+
+	if count == 0 {
+		return ErrEpisodeNotFound
+	}
+
+	return nil
+}
+
+// applyTo copies the fields of the request body to the video. The body must have been validated.
+func (b *VideoRequestBody) applyTo(video *VideoModel) {
+	video.EpisodeID = b.EpisodeID
+	video.Site = b.Site
+	video.Key = b.Key
+	video.Name = strings.TrimSpace(b.Name)
+	video.Language = b.Language
+	video.Type = b.Type
+	video.IsOfficial = b.IsOfficial
+}
+
+// VideoURL returns the URL where the video can be watched.
+func VideoURL(video *VideoModel) string {
+	switch video.Site {
+	case YouTubeVideoSite:
+		return "https://www.youtube.com/watch?v=" + video.Key
+	case VimeoVideoSite:
+		return "https://vimeo.com/" + video.Key
+	default:
+		return video.Key
+	}
+}
+
+// SortVideos sorts the videos by language preference: videos in the first of the given languages
+// come first, an exact locale match ranking before a match on the base language. Videos in the
+// same language are sorted with official videos first, then by creation date.
+func SortVideos(videos []VideoModel, languages []string) {
+	rank := func(video VideoModel) int {
+		if index := slices.IndexFunc(languages, func(lang string) bool {
+			return strings.EqualFold(lang, video.Language)
+		}); index >= 0 {
+			return index * 2
+		}
+
+		if index := slices.IndexFunc(languages, func(lang string) bool {
+			return baseLanguage(lang) == baseLanguage(video.Language)
+		}); index >= 0 {
+			return index*2 + 1
+		}
+
+		return len(languages) * 2
+	}
+
+	slices.SortStableFunc(videos, func(a, b VideoModel) int {
+		if rankA, rankB := rank(a), rank(b); rankA != rankB {
+			return rankA - rankB
+		}
+
+		if a.IsOfficial != b.IsOfficial {
+			return lo.Ternary(a.IsOfficial, -1, 1)
+		}
+
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+}
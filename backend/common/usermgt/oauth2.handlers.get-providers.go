@@ -50,7 +50,7 @@ func (h *getOAuth2ProvidersHandler) IsPrivateRoute() bool {
 func (h *getOAuth2ProvidersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	responseBuilder := core.NewResponseBuilder(r)
 
-	providers, totalRows, err := h.oauth2ProviderRepository.GetMany(r)
+	providers, count, err := h.oauth2ProviderRepository.GetMany(r)
 	if err != nil {
 		h.logger.ErrorContext(r.Context(), "Cannot get providers", core.DetailsLogAttr(err))
 		render.Status(r, http.StatusInternalServerError)
@@ -70,5 +70,5 @@ func (h *getOAuth2ProvidersHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 	})
 
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, responseBuilder.Data(providerDTOs).Pagination(*totalRows).Build())
+	render.JSON(w, r, responseBuilder.Data(providerDTOs).CountedPagination(*count).Build())
 }
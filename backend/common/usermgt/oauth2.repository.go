@@ -24,10 +24,11 @@ type OAuth2ProviderRepository interface {
 	//
 	// Returns:
 	//   - []OAuth2ProviderModel: A slice containing the retrieved OAuth2 provider models.
-	//   - *int64: A pointer to the total count of available records matching the request criteria
-	//     (useful for pagination purposes).
+	//   - *core.RowCount: The total count of available records matching the request criteria
+	//     (useful for pagination purposes), computed as requested with the "count" query
+	//     parameter (see core.CountRows).
 	//   - error: An error object if the retrieval fails; otherwise, nil.
-	GetMany(r *http.Request) ([]OAuth2ProviderModel, *int64, error)
+	GetMany(r *http.Request) ([]OAuth2ProviderModel, *core.RowCount, error)
 
 	// Get retrieves an OAuth2 provider by its name.
 	//
@@ -112,16 +113,16 @@ func NewOAuth2ProviderRepository(params OAuth2ProviderRepositoryParams) *oauth2P
 	}
 }
 
-func (r *oauth2ProviderRepository) GetMany(request *http.Request) ([]OAuth2ProviderModel, *int64, error) {
+func (r *oauth2ProviderRepository) GetMany(request *http.Request) ([]OAuth2ProviderModel, *core.RowCount, error) {
 	var (
-		totalRows      int64
+		count          core.RowCount
 		oauth2Provider []OAuth2ProviderModel
 	)
 
 	err := r.db.WithContext(request.Context()).Transaction(func(tx *gorm.DB) error {
-		if result := tx.Model(&OAuth2ProviderModel{}).
-			Count(&totalRows); result.Error != nil {
-			return result.Error
+		var err error
+		if count, err = core.CountRows(tx.Model(&OAuth2ProviderModel{}), core.GetCountMode(request)); err != nil {
+			return err
 		}
 
 		if result := tx.Omit("ClientID", "ClientSecret", "RedirectURL", "Scopes").
@@ -137,7 +138,7 @@ func (r *oauth2ProviderRepository) GetMany(request *http.Request) ([]OAuth2Provi
 		return nil, nil, err
 	}
 
-	return oauth2Provider, &totalRows, nil
+	return oauth2Provider, &count, nil
 }
 
 func (r *oauth2ProviderRepository) Get(ctx context.Context, name string) (*OAuth2ProviderModel, error) {
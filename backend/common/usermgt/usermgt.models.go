@@ -35,6 +35,9 @@ type UserModel struct {
 	// The user's preferred language or locale (e.g., "en" for English). Defaults to "en" if not specified.
 	Locale string `gorm:"type:string;not null;default:en"`
 
+	// The roles of the user (e.g. core.AdminRole), carried by their access tokens.
+	Roles pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+
 	// A list of OAuth2 providers linked to this user.
 	LinkedProviders []OAuth2UserModel `gorm:"foreignKey:LocalID"`
 }
@@ -135,7 +135,7 @@ func (s *userService) generateAccessToken(user UserModel, nowFn func() time.Time
 		GivenName:         user.FirstName,
 		FamilyName:        user.LastName,
 		Locale:            user.Locale,
-		Roles:             []string{},
+		Roles:             append([]string{}, user.Roles...),
 		Permissions:       []string{},
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   user.ID.String(),
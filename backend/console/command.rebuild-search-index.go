@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+
+	"go.uber.org/fx"
+)
+
+// rebuildSearchIndexCommand is the argument which runs the console as a one-off command
+// rebuilding the search index, instead of starting the web server.
+const rebuildSearchIndexCommand = "rebuild-search-index"
+
+// rebuildSearchIndex rebuilds the search index of the configured backend from scratch, then exits.
+// The Bleve index is locked while the web server runs, so the server must be stopped first.
+func rebuildSearchIndex() {
+	fx.New(
+		core.NewEncryptionModule(),
+		core.NewConfigModule(),
+		core.NewLoggerModuleWithConfig(),
+		core.NewDatabaseModule(),
+		fx.Provide(showmgt.NewSearchIndex),
+		fx.Invoke(func(
+			appLifeCycle fx.Lifecycle,
+			logger *slog.Logger,
+			shutdowner fx.Shutdowner,
+			config core.AppConfig,
+			searchIndex showmgt.SearchIndex,
+		) {
+			appLifeCycle.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					startedAt := time.Now()
+
+					if err := searchIndex.Rebuild(context.Background()); err != nil {
+						return err
+					}
+
+					logger.InfoContext(ctx, "The search index has been rebuilt",
+						slog.String("backend", config.GetSearchConfig().Backend),
+						slog.Duration("duration", time.Since(startedAt)))
+
+					return shutdowner.Shutdown(fx.ExitCode(0))
+				},
+			})
+		}),
+		fx.StartTimeout(time.Hour),
+	).Run()
+}
@@ -3,8 +3,12 @@ package main
 import (
 	"embed"
 	"net/http"
+	"os"
 	"time"
+	"wano-island/common/commentmgt"
 	"wano-island/common/core"
+	"wano-island/common/listmgt"
+	"wano-island/common/notifmgt"
 	"wano-island/common/showmgt"
 	"wano-island/common/usermgt"
 	"wano-island/console/modules/filesystem"
@@ -23,6 +27,12 @@ var resourceFS embed.FS
 func main() {
 	time.Local = time.UTC
 
+	if len(os.Args) > 1 && os.Args[1] == rebuildSearchIndexCommand {
+		rebuildSearchIndex()
+
+		return
+	}
+
 	app := fx.New(
 		// Common
 		core.NewEncryptionModule(),
@@ -33,8 +43,12 @@ func main() {
 		core.NewRequestModule(),
 		core.NewDatabaseModule(),
 		core.NewTranslationModule(),
+		core.NewSchedulerModule(),
 		usermgt.NewUserMgtModule(),
 		showmgt.NewShowMgtModule(),
+		listmgt.NewListMgtModule(),
+		commentmgt.NewCommentMgtModule(),
+		notifmgt.NewNotifMgtModule(),
 
 		// Console
 		filesystem.NewFileSystemModule(staticFiles),
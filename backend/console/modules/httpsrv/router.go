@@ -2,6 +2,7 @@ package httpsrv
 
 import (
 	"log/slog"
+	"maps"
 	"net/http"
 	"slices"
 	"time"
@@ -83,11 +84,13 @@ func NewRouter(params RouteParams) http.Handler {
 
 	// Public routes
 	r.Group(func(r chi.Router) {
-		middlewarePriorities := lo.Keys(middlewares)
+		publicMiddlewares := maps.Clone(middlewares)
+		publicMiddlewares[35] = WithOptionalJwtMiddleware(params.I18nBundle, params.Config, params.Logger)
+		middlewarePriorities := lo.Keys(publicMiddlewares)
 		slices.Sort(middlewarePriorities)
 
 		for _, priority := range middlewarePriorities {
-			r.Use(middlewares[priority])
+			r.Use(publicMiddlewares[priority])
 		}
 
 		r.Use(lo.Values(middlewares)...)
@@ -28,7 +28,7 @@ func WithI18nMiddleware(bundle *i18n.Bundle) func(next http.Handler) http.Handle
 
 			localizer := i18n.NewLocalizer(bundle, languages...)
 
-			next.ServeHTTP(w, core.WithLocalizer(r, localizer))
+			next.ServeHTTP(w, core.WithLanguages(core.WithLocalizer(r, localizer), languages))
 		})
 	}
 }
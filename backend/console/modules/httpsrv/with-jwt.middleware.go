@@ -28,6 +28,40 @@ func extractAccessTokenFromRequest(req *http.Request) string {
 	return strings.TrimSpace(accessToken)
 }
 
+// parseAccessToken verifies the access token and converts its claims into an AuthUser object.
+func parseAccessToken(config core.AppConfig, accessToken string) (*core.AuthenticatedUser, error) {
+	jwtMapClaims := core.JWTCustomClaims{}
+	jwtParser := jwt.NewParser()
+
+	if _, err := jwtParser.ParseWithClaims(accessToken, &jwtMapClaims, func(t *jwt.Token) (interface{}, error) {
+		return config.GetJWTConfig().PublicKey, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &core.AuthenticatedUser{
+		ID:          jwtMapClaims.Subject,
+		Username:    jwtMapClaims.PreferredUsername,
+		Email:       jwtMapClaims.Email,
+		FamilyName:  jwtMapClaims.FamilyName,
+		GivenName:   jwtMapClaims.GivenName,
+		Locale:      lo.Ternary(lo.IsEmpty(&jwtMapClaims.Locale), language.English.String(), jwtMapClaims.Locale),
+		Roles:       jwtMapClaims.Roles,
+		Permissions: jwtMapClaims.Permissions,
+	}, nil
+}
+
+// withAuthUser sets the AuthUser object in the request context. The request is localized with the
+// preferred language of the user, unless it contains the lang query parameter.
+func withAuthUser(bundle *i18n.Bundle, r *http.Request, authUser *core.AuthenticatedUser) *http.Request {
+	if !r.URL.Query().Has("lang") {
+		localizer := i18n.NewLocalizer(bundle, authUser.Locale)
+		r = core.WithLanguages(core.WithLocalizer(r, localizer), []string{authUser.Locale})
+	}
+
+	return core.WithAuthUser(r, authUser)
+}
+
 // WithJwtMiddleware is a middleware function that handles JWT authentication for HTTP requests.
 // It extracts the access token from the request header, verifies it, and converts the claims into an AuthUser object.
 // If the access token is missing or invalid, it returns an appropriate HTTP response.
@@ -49,13 +83,8 @@ func WithJwtMiddleware(
 				return
 			}
 
-			// Parse the access token without verifying it to extract the JWT claims.
-			jwtMapClaims := core.JWTCustomClaims{}
-			jwtParser := jwt.NewParser()
-
-			if _, err := jwtParser.ParseWithClaims(accessToken, &jwtMapClaims, func(t *jwt.Token) (interface{}, error) {
-				return config.GetJWTConfig().PublicKey, nil
-			}); err != nil {
+			authUser, err := parseAccessToken(config, accessToken)
+			if err != nil {
 				logger.ErrorContext(r.Context(), "Cannot parse jwt", core.DetailsLogAttr(err))
 				render.Status(r, http.StatusUnauthorized)
 				render.JSON(w, r, core.NewResponseBuilder(r).MessageID(core.MsgCannotProcessYourLogin).Build())
@@ -63,25 +92,37 @@ func WithJwtMiddleware(
 				return
 			}
 
-			// Convert the JWT claims into an AuthUser object.
-			authUser := &core.AuthenticatedUser{
-				ID:          jwtMapClaims.Subject,
-				Username:    jwtMapClaims.PreferredUsername,
-				Email:       jwtMapClaims.Email,
-				FamilyName:  jwtMapClaims.FamilyName,
-				GivenName:   jwtMapClaims.GivenName,
-				Locale:      lo.Ternary(lo.IsEmpty(&jwtMapClaims.Locale), language.English.String(), jwtMapClaims.Locale),
-				Roles:       jwtMapClaims.Roles,
-				Permissions: jwtMapClaims.Permissions,
+			next.ServeHTTP(w, withAuthUser(bundle, r, authUser))
+		})
+	}
+}
+
+// WithOptionalJwtMiddleware is the middleware of the public routes. It sets the AuthUser object in
+// the request context like WithJwtMiddleware when the request contains a valid access token, and
+// lets the request through anonymously otherwise, so that public routes can adapt to the caller.
+func WithOptionalJwtMiddleware(
+	bundle *i18n.Bundle,
+	config core.AppConfig,
+	logger *slog.Logger,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accessToken := extractAccessTokenFromRequest(r)
+			if accessToken == "" {
+				next.ServeHTTP(w, r)
+
+				return
 			}
 
-			if r.URL.Query().Has("lang") {
-				// Set the AuthUser object in the request context and call the next handler.
-				next.ServeHTTP(w, core.WithAuthUser(r, authUser))
-			} else {
-				localizer := i18n.NewLocalizer(bundle, authUser.Locale)
-				next.ServeHTTP(w, core.WithLocalizer(core.WithAuthUser(r, authUser), localizer))
+			authUser, err := parseAccessToken(config, accessToken)
+			if err != nil {
+				logger.WarnContext(r.Context(), "Ignoring an invalid jwt on a public route", core.DetailsLogAttr(err))
+				next.ServeHTTP(w, r)
+
+				return
 			}
+
+			next.ServeHTTP(w, withAuthUser(bundle, r, authUser))
 		})
 	}
 }
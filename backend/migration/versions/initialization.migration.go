@@ -2,7 +2,10 @@ package versions
 
 import (
 	"log/slog"
+	"wano-island/common/commentmgt"
 	"wano-island/common/core"
+	"wano-island/common/listmgt"
+	"wano-island/common/notifmgt"
 	"wano-island/common/showmgt"
 	"wano-island/common/usermgt"
 	migrationCore "wano-island/migration/core"
@@ -40,6 +43,7 @@ func (m *initializationMigration) BeforeMigrate(tx *gorm.DB) error {
 		"CREATE SCHEMA IF NOT EXISTS internal",
 		"CREATE SCHEMA IF NOT EXISTS public",
 		"CREATE EXTENSION IF NOT EXISTS ltree",
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
 	}
 
 	for _, statement := range statements {
@@ -53,18 +57,48 @@ func (m *initializationMigration) BeforeMigrate(tx *gorm.DB) error {
 
 // Migrate is a method that performs the actual migration process.
 func (m *initializationMigration) Migrate(tx *gorm.DB) error {
-	return tx.AutoMigrate(
+	if err := tx.AutoMigrate(
 		&initialization.DBMigrationModel{},
 		&usermgt.UserModel{},
 		&usermgt.OAuth2ProviderModel{},
 		&usermgt.OAuth2UserModel{},
 		&showmgt.ShowModel{},
 		&showmgt.ShowTranslationModel{},
+		&showmgt.ShowSlugModel{},
 		&showmgt.SeasonModel{},
 		&showmgt.SeasonTranslationModel{},
 		&showmgt.EpisodeModel{},
 		&showmgt.EpisodeTranslationModel{},
-	)
+		&showmgt.ShowSimilarityModel{},
+		&showmgt.KeywordModel{},
+		&showmgt.KeywordLabelModel{},
+		&showmgt.KeywordSynonymModel{},
+		&showmgt.ShowKeywordModel{},
+		&showmgt.VideoModel{},
+		&showmgt.ShowActivityModel{},
+		&showmgt.ShowPopularityModel{},
+		&showmgt.ShowBatchJobModel{},
+		&showmgt.ShowBatchJobItemModel{},
+		&showmgt.ShowRevisionModel{},
+		&listmgt.ListModel{},
+		&listmgt.ListItemModel{},
+		&listmgt.ListCollaboratorModel{},
+		&commentmgt.CommentModel{},
+		&commentmgt.CommentReportModel{},
+		&commentmgt.MutedUserModel{},
+		&notifmgt.ShowFollowModel{},
+		&notifmgt.NotificationModel{},
+		&notifmgt.NotificationPreferenceModel{},
+		&showmgt.DataQualitySnapshotModel{},
+		&showmgt.DataQualityIssueModel{},
+		&showmgt.SubtitleModel{},
+		&showmgt.ShowRelationModel{},
+		&showmgt.CatalogEventModel{},
+	); err != nil {
+		return err
+	}
+
+	return execStatements(tx, titleTrigramIndexStatements)
 }
 
 // AfterMigrate is a method that is called after the migration process is completed.
@@ -79,6 +113,7 @@ func (m *initializationMigration) AfterMigrate(tx *gorm.DB) error {
 		FirstName: "Admin",
 		Email:     "admin@internal.com",
 		Password:  lo.ToPtr(string(encrytedPassword)),
+		Roles:     []string{core.AdminRole},
 		HasCreatedByColumn: core.HasCreatedByColumn{
 			CreatedBy: core.NewSystemUser().GetUsername(),
 		},
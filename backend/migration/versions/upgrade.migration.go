@@ -2,6 +2,12 @@ package versions
 
 import (
 	"log/slog"
+	"wano-island/common/commentmgt"
+	"wano-island/common/core"
+	"wano-island/common/listmgt"
+	"wano-island/common/notifmgt"
+	"wano-island/common/showmgt"
+	"wano-island/common/usermgt"
 	migrationCore "wano-island/migration/core"
 
 	"gorm.io/gorm"
@@ -13,6 +19,9 @@ type upgradeMigration struct {
 	logger *slog.Logger
 }
 
+// backfillBatchSize is the number of shows loaded at once when backfilling data of existing shows.
+const backfillBatchSize = 100
+
 var _ migrationCore.Migration = (*upgradeMigration)(nil)
 
 // NewUpgradeMigration creates a new instance of upgradeMigration.
@@ -22,17 +31,143 @@ func NewUpgradeMigration(logger *slog.Logger) *upgradeMigration {
 	}
 }
 
+// titleTrigramIndexStatements create the trigram indexes used by the typeahead search of show titles.
+var titleTrigramIndexStatements = []string{
+	"CREATE INDEX IF NOT EXISTS idx_shows_original_title_trgm ON public.shows USING gin (original_title gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_show_translations_title_trgm ON public.show_translations USING gin (title gin_trgm_ops)",
+}
+
 // BeforeMigrate is a method that is called before the migration process begins.
-func (m *upgradeMigration) BeforeMigrate(_ *gorm.DB) error {
-	return nil
+func (m *upgradeMigration) BeforeMigrate(tx *gorm.DB) error {
+	return execStatements(tx, []string{"CREATE EXTENSION IF NOT EXISTS pg_trgm"})
 }
 
 // Migrate is a method that performs the actual migration operations.
-func (m *upgradeMigration) Migrate(_ *gorm.DB) error {
-	return nil
+func (m *upgradeMigration) Migrate(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(
+		&usermgt.UserModel{},
+		&showmgt.ShowModel{},
+		&showmgt.ShowTranslationModel{},
+		&showmgt.ShowSlugModel{},
+		&showmgt.SeasonTranslationModel{},
+		&showmgt.EpisodeModel{},
+		&showmgt.EpisodeTranslationModel{},
+		&showmgt.ShowSimilarityModel{},
+		&showmgt.KeywordModel{},
+		&showmgt.KeywordLabelModel{},
+		&showmgt.KeywordSynonymModel{},
+		&showmgt.ShowKeywordModel{},
+		&showmgt.VideoModel{},
+		&showmgt.ShowActivityModel{},
+		&showmgt.ShowPopularityModel{},
+		&showmgt.ShowBatchJobModel{},
+		&showmgt.ShowBatchJobItemModel{},
+		&showmgt.ShowRevisionModel{},
+		&listmgt.ListModel{},
+		&listmgt.ListItemModel{},
+		&listmgt.ListCollaboratorModel{},
+		&commentmgt.CommentModel{},
+		&commentmgt.CommentReportModel{},
+		&commentmgt.MutedUserModel{},
+		&notifmgt.ShowFollowModel{},
+		&notifmgt.NotificationModel{},
+		&notifmgt.NotificationPreferenceModel{},
+		&showmgt.DataQualitySnapshotModel{},
+		&showmgt.DataQualityIssueModel{},
+		&showmgt.SubtitleModel{},
+		&showmgt.ShowRelationModel{},
+		&showmgt.CatalogEventModel{},
+	); err != nil {
+		return err
+	}
+
+	return execStatements(tx, titleTrigramIndexStatements)
 }
 
 // AfterMigrate is a method that is called after the migration process is completed.
-func (m *upgradeMigration) AfterMigrate(_ *gorm.DB) error {
+func (m *upgradeMigration) AfterMigrate(tx *gorm.DB) error {
+	if err := m.backfillAdminRole(tx); err != nil {
+		return err
+	}
+
+	if err := m.backfillSlugs(tx); err != nil {
+		return err
+	}
+
+	if err := m.backfillAnnouncedAirDates(tx); err != nil {
+		return err
+	}
+
+	return m.backfillKeywords(tx)
+}
+
+// backfillAdminRole grants the admin role to the admin user created by the initialization
+// migration, which was created before users had roles.
+func (m *upgradeMigration) backfillAdminRole(tx *gorm.DB) error {
+	return tx.Model(&usermgt.UserModel{}).
+		Where("username = ? AND created_by = ? AND roles = '{}'", "admin", core.NewSystemUser().GetUsername()).
+		Update("roles", gorm.Expr("ARRAY[?::text]", core.AdminRole)).Error
+}
+
+// backfillSlugs generates slugs for the shows and translations created before slugs existed.
+func (m *upgradeMigration) backfillSlugs(tx *gorm.DB) error {
+	var showModels []showmgt.ShowModel
+
+	return tx.Preload("Translations").
+		FindInBatches(&showModels, backfillBatchSize, func(_ *gorm.DB, _ int) error {
+			for i := range showModels {
+				if err := showmgt.AssignShowSlugs(tx, &showModels[i]); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}).Error
+}
+
+// backfillAnnouncedAirDates marks the aired episodes of the shows created before the air dates
+// were announced as announced, so that the aired episodes job does not notify them again.
+func (m *upgradeMigration) backfillAnnouncedAirDates(tx *gorm.DB) error {
+	return tx.Model(&showmgt.ShowModel{}).
+		Where("announced_air_date IS NULL AND last_air_date <= CURRENT_DATE").
+		UpdateColumn("announced_air_date", gorm.Expr("last_air_date")).Error
+}
+
+// backfillKeywords maps the free-form keywords of the shows created before the keyword vocabulary
+// existed to canonical keywords, and rewrites their keywords array with the canonical names.
+func (m *upgradeMigration) backfillKeywords(tx *gorm.DB) error {
+	var showModels []showmgt.ShowModel
+
+	return tx.Where("id NOT IN (?)", tx.Model(&showmgt.ShowKeywordModel{}).Select("show_id")).
+		Where("cardinality(keywords) > 0").
+		FindInBatches(&showModels, backfillBatchSize, func(_ *gorm.DB, _ int) error {
+			for i := range showModels {
+				keywords, err := showmgt.ResolveOrCreateKeywords(tx, showModels[i].Keywords)
+				if err != nil {
+					return err
+				}
+
+				if result := tx.Model(&showModels[i]).
+					UpdateColumn("keywords", showmgt.KeywordNames(keywords)); result.Error != nil {
+					return result.Error
+				}
+
+				if err = showmgt.LinkShowKeywords(tx, &showModels[i], keywords); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}).Error
+}
+
+// execStatements executes the given SQL statements in order, stopping at the first error.
+func execStatements(tx *gorm.DB, statements []string) error {
+	for _, statement := range statements {
+		if result := tx.Exec(statement); result.Error != nil {
+			return result.Error
+		}
+	}
+
 	return nil
 }
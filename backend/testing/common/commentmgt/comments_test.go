@@ -0,0 +1,120 @@
+package commentmgt_test
+
+import (
+	"time"
+	"wano-island/common/commentmgt"
+	"wano-island/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[comments.go]", func() {
+	Context("when looking for banned words", func() {
+		bannedWords := []string{"spoil", "bad word"}
+
+		It("should only match whole words, whatever their case and width", func() {
+			Expect(commentmgt.FindBannedWords("Don't SPOIL it!", bannedWords)).To(Equal([]string{"spoil"}))
+			Expect(commentmgt.FindBannedWords("Ｓｐｏｉｌ", bannedWords)).To(Equal([]string{"spoil"}))
+			Expect(commentmgt.FindBannedWords("It is spoiled", bannedWords)).To(BeEmpty())
+		})
+
+		It("should match banned phrases when their words are in a row", func() {
+			Expect(commentmgt.FindBannedWords("a bad, word", bannedWords)).To(Equal([]string{"bad word"}))
+			Expect(commentmgt.FindBannedWords("a bad good word", bannedWords)).To(BeEmpty())
+		})
+	})
+
+	Context("when editing a comment", func() {
+		var (
+			db       *gorm.DB
+			mockedDB sqlmock.Sqlmock
+		)
+
+		authorID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+		config := &core.CommentConfig{BannedWords: []string{"spoil"}, EditWindow: 15 * time.Minute}
+		body := &commentmgt.UpdateCommentRequestBody{Body: "Great ending"}
+
+		BeforeEach(func() {
+			db, mockedDB = testutils.CreateTestDBInstance()
+		})
+
+		It("should refuse to edit the comment of another user", func() {
+			comment := commentmgt.CommentModel{AuthorID: uuid.New(), PostedAt: time.Now()}
+
+			Expect(commentmgt.UpdateComment(db, &comment, authorID, body, config)).
+				To(MatchError(commentmgt.ErrCommentPermissionDenied))
+		})
+
+		It("should refuse to edit a comment after its edit window", func() {
+			comment := commentmgt.CommentModel{AuthorID: authorID, PostedAt: time.Now().Add(-time.Hour)}
+
+			Expect(commentmgt.UpdateComment(db, &comment, authorID, body, config)).
+				To(MatchError(commentmgt.ErrEditWindowExpired))
+		})
+
+		It("should refuse to edit a comment hidden by a moderator", func() {
+			moderatedAt := time.Now()
+			comment := commentmgt.CommentModel{
+				AuthorID:    authorID,
+				PostedAt:    time.Now(),
+				Status:      commentmgt.HiddenCommentStatus,
+				ModeratedAt: &moderatedAt,
+			}
+
+			Expect(commentmgt.UpdateComment(db, &comment, authorID, body, config)).
+				To(MatchError(commentmgt.ErrEditWindowExpired))
+		})
+
+		It("should publish again a held comment which no longer contains a banned word", func() {
+			comment := commentmgt.CommentModel{
+				Model:     core.Model{ID: uuid.New()},
+				AuthorID:  authorID,
+				Body:      "They spoil everything",
+				PostedAt:  time.Now(),
+				Status:    commentmgt.HiddenCommentStatus,
+				IsFlagged: true,
+			}
+
+			mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."muted_users"`).
+				WithArgs(authorID, testutils.AnyTimeArg{}).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."comments" SET`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+
+			Expect(commentmgt.UpdateComment(db, &comment, authorID, body, config)).To(Succeed())
+			Expect(comment.Status).To(Equal(commentmgt.VisibleCommentStatus))
+			Expect(comment.IsFlagged).To(BeFalse())
+			Expect(comment.EditedAt).NotTo(BeNil())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	It("should nest the replies and drop the hidden comments without visible replies", func() {
+		rootID := uuid.New()
+		hiddenID := uuid.New()
+		roots := []commentmgt.CommentModel{
+			{Model: core.Model{ID: rootID}, Status: commentmgt.HiddenCommentStatus, Body: "hidden root"},
+			{Model: core.Model{ID: uuid.New()}, Status: commentmgt.HiddenCommentStatus},
+		}
+		replies := []commentmgt.CommentModel{
+			{Model: core.Model{ID: hiddenID}, ParentID: &rootID, RootID: &rootID, Status: commentmgt.HiddenCommentStatus},
+			{Model: core.Model{ID: uuid.New()}, ParentID: &rootID, RootID: &rootID, Status: commentmgt.VisibleCommentStatus,
+				Body: "first"},
+		}
+
+		threads := commentmgt.ToCommentThreadDTOs(roots, replies)
+
+		Expect(threads).To(HaveLen(1))
+		Expect(threads[0].ID).To(Equal(rootID))
+		Expect(threads[0].Body).To(BeNil())
+		Expect(threads[0].Replies).To(HaveLen(1))
+		Expect(*threads[0].Replies[0].Body).To(Equal("first"))
+	})
+})
@@ -0,0 +1,110 @@
+package commentmgt_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+	"wano-island/common/commentmgt"
+	"wano-island/common/core"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.create-comment.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	showID := "0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				commentmgt.NewCreateCommentHandler(commentmgt.CreateCommentHandlerParams{
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Config:              config,
+					Validator:           core.NewValidator(core.NewUniversalTranslator()),
+					UniversalTranslator: core.NewUniversalTranslator(),
+				}),
+			}
+		})
+	})
+
+	It("should return 403 if the author has been muted", func() {
+		config.EXPECT().GetCommentConfig().Return(&core.CommentConfig{EditWindow: 15 * time.Minute})
+
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."muted_users" WHERE user_id = \$1 `+
+			`AND \(until IS NULL OR until > \$2\)`).
+			WithArgs(uuid.Nil, testutils.AnyTimeArg{}).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDB.ExpectRollback()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows/"+showID+"/comments",
+			bytes.NewReader([]byte(`{"body": "What a finale!"}`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+		Expect(response.MessageID).To(Equal("E-0027"))
+		Expect(response.Message).To(Equal("You have been muted by a moderator and cannot post or edit comments for now"))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should hold the comment for moderation if it contains a banned word", func() {
+		config.EXPECT().GetCommentConfig().Return(&core.CommentConfig{BannedWords: []string{"spoil"}})
+
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."muted_users"`).
+			WithArgs(uuid.Nil, testutils.AnyTimeArg{}).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."shows" WHERE id = \$1`).
+			WithArgs(showID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDB.ExpectExec(`INSERT INTO "public"."comments"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectCommit()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows/"+showID+"/comments",
+			bytes.NewReader([]byte(`{"body": "Let me spoil the ending", "isSpoiler": true}`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[commentmgt.ModerationCommentDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusAccepted))
+		Expect(response.MessageID).To(Equal("S-0001"))
+		Expect(response.Data.Status).To(Equal("hidden"))
+		Expect(response.Data.IsFlagged).To(BeTrue())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
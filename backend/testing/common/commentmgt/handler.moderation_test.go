@@ -0,0 +1,117 @@
+package commentmgt_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/commentmgt"
+	"wano-island/common/core"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[moderation handlers]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	commentID := "0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"
+	userID := "0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6f"
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		universalTranslator := core.NewUniversalTranslator()
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				commentmgt.NewModerateCommentHandler(commentmgt.ModerateCommentHandlerParams{
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Validator:           core.NewValidator(universalTranslator),
+					UniversalTranslator: universalTranslator,
+				}),
+				commentmgt.NewMuteUserHandler(commentmgt.MuteUserHandlerParams{
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Validator:           core.NewValidator(universalTranslator),
+					UniversalTranslator: universalTranslator,
+				}),
+				commentmgt.NewUnmuteUserHandler(commentmgt.UnmuteUserHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+				commentmgt.NewGetModerationQueueHandler(commentmgt.GetModerationQueueHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+				commentmgt.NewGetMutedUsersHandler(commentmgt.GetMutedUsersHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+			}
+		})
+	})
+
+	DescribeTable("should return 403 to users who are not moderators",
+		func(method string, target string, body string) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(method, target, bytes.NewReader([]byte(body)))
+			router.ServeHTTP(recorder, testutils.WithFakeJWT(request, func(c *core.JWTCustomClaims) {
+				c.Roles = []string{"editor"}
+			}))
+
+			var response core.Response[any]
+			_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+			Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+			Expect(response.MessageID).To(Equal("E-0040"))
+			Expect(response.Message).To(Equal("You don't have permission to use this feature"))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		},
+		Entry("when moderating a comment", http.MethodPut, "/api/v1/moderation/comments/"+commentID, `{"status": "hidden"}`),
+		Entry("when muting a user", http.MethodPost, "/api/v1/moderation/muted-users", `{"userId": "`+userID+`"}`),
+		Entry("when unmuting a user", http.MethodDelete, "/api/v1/moderation/muted-users/"+userID, ""),
+		Entry("when getting the moderation queue", http.MethodGet, "/api/v1/moderation/comments", ""),
+		Entry("when getting the muted users", http.MethodGet, "/api/v1/moderation/muted-users", ""),
+	)
+
+	DescribeTable("should let moderators and admins moderate the comments",
+		func(role string) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPut, "/api/v1/moderation/comments/not-a-uuid",
+				bytes.NewReader([]byte(`{"status": "hidden"}`)))
+			router.ServeHTTP(recorder, testutils.WithFakeJWT(request, func(c *core.JWTCustomClaims) {
+				c.Roles = []string{role}
+			}))
+
+			var response core.Response[any]
+			_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+			Expect(recorder).To(HaveHTTPStatus(http.StatusNotFound))
+			Expect(response.MessageID).To(Equal("E-0024"))
+		},
+		Entry("when the user is a moderator", core.ModeratorRole),
+		Entry("when the user is an admin", core.AdminRole),
+	)
+})
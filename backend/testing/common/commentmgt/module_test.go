@@ -0,0 +1,17 @@
+package commentmgt_test
+
+import (
+	"wano-island/common/commentmgt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+)
+
+var _ = Describe("[module.go]", func() {
+	Context("when initializing the comment management module", func() {
+		It("should return an fx.Option", func() {
+			Expect(commentmgt.NewCommentMgtModule()).To(BeAssignableToTypeOf(fx.Module("")))
+		})
+	})
+})
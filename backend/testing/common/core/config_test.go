@@ -59,6 +59,9 @@ var _ = Describe("[common/core/config.go]", Ordered, func() {
 				"AllowCredentials": BeFalse(),
 				"MaxAge":           BeZero(),
 			})))
+			Expect(appCfg.GetVideoConfig()).To(PointTo(MatchFields(IgnoreMissing, Fields{
+				"AllowedHosts": BeEmpty(),
+			})))
 		})
 	})
 
@@ -225,4 +228,17 @@ var _ = Describe("[common/core/config.go]", Ordered, func() {
 			})))
 		})
 	})
+
+	Context("when user defines the video configuration in the envionment", func() {
+		It("should use the allowed video hosts in the envionment", func() {
+			t := GinkgoT()
+			t.Setenv("APP_VIDEO_ALLOWED_HOSTS", "videos.example.com  CDN.Example.com ")
+
+			appCfg, err := core.NewAppConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appCfg.GetVideoConfig()).To(PointTo(MatchFields(IgnoreMissing, Fields{
+				"AllowedHosts": Equal([]string{"videos.example.com", "cdn.example.com"}),
+			})))
+		})
+	})
 })
@@ -0,0 +1,157 @@
+package core_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+type countedModel struct {
+	core.Model
+	Kind string
+}
+
+func (countedModel) TableName() string {
+	return "public.counted"
+}
+
+var _ = Describe("[common/core/database.count.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+	)
+
+	const (
+		estimateQuery = `SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass\(\$1\)`
+		cappedQuery   = `SELECT count\(\*\) FROM \(SELECT 1 FROM "public"."counted" LIMIT \$1\) AS capped_rows`
+		filteredQuery = `SELECT count\(\*\) FROM \(SELECT 1 FROM "public"."counted" WHERE kind = \$1 LIMIT \$2\) AS capped_rows`
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+	})
+
+	AfterEach(func() {
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	Context("when reading the count mode", func() {
+		DescribeTable("should map the count query parameter to a mode",
+			func(query string, expected core.CountMode) {
+				request := httptest.NewRequest(http.MethodGet, "/"+query, nil)
+
+				Expect(core.GetCountMode(request)).To(Equal(expected))
+			},
+			Entry("without the parameter", "", core.EstimatedCountMode),
+			Entry("with exact", "?count=exact", core.ExactCountMode),
+			Entry("with estimated", "?count=estimated", core.EstimatedCountMode),
+			Entry("with none", "?count=none", core.NoCountMode),
+			Entry("with an unknown mode", "?count=everything", core.EstimatedCountMode),
+		)
+	})
+
+	Context("when the rows are not counted", func() {
+		It("should not query the database", func() {
+			count, err := core.CountRows(db.Model(&countedModel{}), core.NoCountMode)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(core.RowCount{}))
+		})
+	})
+
+	Context("when the rows are counted exactly", func() {
+		It("should count every matching row, even beyond the cap", func() {
+			mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."counted" WHERE kind = \$1$`).
+				WithArgs("tv").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(core.MaxExactCount + 5))
+
+			count, err := core.CountRows(db.Model(&countedModel{}).Where("kind = ?", "tv"), core.ExactCountMode)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(core.RowCount{Total: core.MaxExactCount + 5, IsExact: true}))
+		})
+	})
+
+	Context("when the rows are estimated", func() {
+		It("should use the table statistics of a large unfiltered query", func() {
+			mockedDB.ExpectQuery(estimateQuery).
+				WithArgs("public.counted").
+				WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(52000))
+
+			count, err := core.CountRows(db.Model(&countedModel{}), core.EstimatedCountMode)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(core.RowCount{Total: 52000}))
+		})
+
+		It("should count a small or never analyzed table exactly", func() {
+			mockedDB.ExpectQuery(estimateQuery).
+				WithArgs("public.counted").
+				WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(-1))
+			mockedDB.ExpectQuery(cappedQuery).
+				WithArgs(core.MaxExactCount + 1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+			count, err := core.CountRows(db.Model(&countedModel{}), core.EstimatedCountMode)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(core.RowCount{Total: 42, IsExact: true}))
+		})
+
+		It("should count a filtered query without reading the table statistics", func() {
+			mockedDB.ExpectQuery(filteredQuery).
+				WithArgs("tv", core.MaxExactCount+1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+			count, err := core.CountRows(db.Model(&countedModel{}).Where("kind = ?", "tv"), core.EstimatedCountMode)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(core.RowCount{Total: 7, IsExact: true}))
+		})
+
+		It("should keep the count exact when it is exactly at the cap", func() {
+			mockedDB.ExpectQuery(filteredQuery).
+				WithArgs("tv", core.MaxExactCount+1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(core.MaxExactCount))
+
+			count, err := core.CountRows(db.Model(&countedModel{}).Where("kind = ?", "tv"), core.EstimatedCountMode)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(core.RowCount{Total: core.MaxExactCount, IsExact: true}))
+		})
+
+		It("should return a lower bound when the count goes beyond the cap", func() {
+			mockedDB.ExpectQuery(filteredQuery).
+				WithArgs("tv", core.MaxExactCount+1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(core.MaxExactCount + 1))
+
+			count, err := core.CountRows(db.Model(&countedModel{}).Where("kind = ?", "tv"), core.EstimatedCountMode)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(core.RowCount{Total: core.MaxExactCount}))
+		})
+
+		It("should not change the query it counts", func() {
+			mockedDB.ExpectQuery(filteredQuery).
+				WithArgs("tv", core.MaxExactCount+1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."counted" WHERE kind = \$1$`).
+				WithArgs("tv").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			query := db.Model(&countedModel{}).Where("kind = ?", "tv")
+			_, err := core.CountRows(query, core.EstimatedCountMode)
+			Expect(err).ToNot(HaveOccurred())
+
+			var rows []countedModel
+			Expect(query.Find(&rows).Error).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,93 @@
+package listmgt_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/listmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.create-list.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				listmgt.NewCreateListHandler(listmgt.CreateListHandlerParams{
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Validator:           core.NewValidator(core.NewUniversalTranslator()),
+					UniversalTranslator: core.NewUniversalTranslator(),
+				}),
+			}
+		})
+	})
+
+	It("should return 400 if the visibility is unknown", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/lists",
+			bytes.NewReader([]byte(`{"name":"Best of 2024 anime","visibility":"friends"}`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		Expect(response.MessageID).To(Equal("E-0005"))
+	})
+
+	It("should create a list owned by the user with a share token", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectExec(`INSERT INTO "public"."lists"`).
+			WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, testutils.AnyTimeArg{},
+				uuid.Nil, "Best of 2024 anime", "", "unlisted", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectCommit()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/lists",
+			bytes.NewReader([]byte(`{"name":" Best of 2024 anime ","visibility":"unlisted"}`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[listmgt.ListDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusCreated))
+		Expect(response.Data).To(MatchFields(IgnoreExtras, Fields{
+			"OwnerID":    Equal(uuid.Nil),
+			"Name":       Equal("Best of 2024 anime"),
+			"Visibility": Equal("unlisted"),
+			"ShareToken": PointTo(HaveLen(32)),
+		}))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
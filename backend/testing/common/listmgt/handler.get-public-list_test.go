@@ -0,0 +1,98 @@
+package listmgt_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/listmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.get-public-list.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	listID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000001")
+	createdAt := time.Date(2024, time.October, 1, 10, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				listmgt.NewGetPublicListHandler(listmgt.GetPublicListHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+			}
+		})
+	})
+
+	It("should return an unlisted list with its items by its share token, without authentication", func() {
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."lists" WHERE share_token = \$1 AND visibility IN \(\$2,\$3\)`).
+			WithArgs("secret-token", "public", "unlisted", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "name", "visibility", "share_token"}).
+				AddRow(listID, uuid.Nil, "Best of 2024 anime", "unlisted", "secret-token"))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."list_items" WHERE "list_items"."list_id" = \$1 ORDER BY position`).
+			WithArgs(listID).
+			WillReturnRows(sqlmock.NewRows([]string{"list_id", "show_id", "created_at", "position", "note"}).
+				AddRow(listID, showID, createdAt, 0, "Watch it subbed"))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE "shows"."id" = \$1`).
+			WithArgs(showID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "original_title"}).AddRow(showID, "tv", "Dandadan"))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/public/lists/secret-token", nil)
+		router.ServeHTTP(recorder, request)
+
+		var response core.Response[listmgt.ListDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(response.Data.ShareToken).To(BeNil())
+		Expect(response.Data.Items).To(HaveLen(1))
+		Expect(response.Data.Items[0].Note).To(Equal("Watch it subbed"))
+		Expect(response.Data.Items[0].Show.OriginalTitle).To(Equal("Dandadan"))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should only return public lists by ID", func() {
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."lists" WHERE id = \$1 AND visibility = \$2`).
+			WithArgs(listID, "public", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/public/lists/"+listID.String(), nil)
+		router.ServeHTTP(recorder, request)
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusNotFound))
+		Expect(response.MessageID).To(Equal("E-0014"))
+	})
+})
@@ -0,0 +1,119 @@
+package listmgt_test
+
+import (
+	"wano-island/common/listmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[lists.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+	)
+
+	listID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+	ownerID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-00000000000a")
+	otherUserID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-00000000000b")
+
+	BeforeEach(func() {
+		db, mockedDB = testutils.CreateTestDBInstance()
+	})
+
+	expectList := func(visibility string) {
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."lists" WHERE id = \$1`).
+			WithArgs(listID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "name", "visibility"}).
+				AddRow(listID, ownerID, "Best of 2024 anime", visibility))
+	}
+
+	expectCollaboratorCount := func(count int) {
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."list_collaborators" WHERE list_id = \$1 AND user_id = \$2`).
+			WithArgs(listID, otherUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+
+	Context("when finding the list of a user", func() {
+		It("should give the owner role to the owner", func() {
+			expectList(listmgt.PrivateListVisibility)
+
+			listModel, role, err := listmgt.FindUserList(db, listID, ownerID, listmgt.OwnerListRole)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listModel.ID).To(Equal(listID))
+			Expect(role).To(Equal(listmgt.OwnerListRole))
+		})
+
+		It("should hide private lists from non-members", func() {
+			expectList(listmgt.PrivateListVisibility)
+			expectCollaboratorCount(0)
+
+			_, _, err := listmgt.FindUserList(db, listID, otherUserID, listmgt.NoListRole)
+
+			Expect(err).To(MatchError(gorm.ErrRecordNotFound))
+		})
+
+		It("should hide unlisted lists from non-members", func() {
+			expectList(listmgt.UnlistedListVisibility)
+			expectCollaboratorCount(0)
+
+			_, _, err := listmgt.FindUserList(db, listID, otherUserID, listmgt.NoListRole)
+
+			Expect(err).To(MatchError(gorm.ErrRecordNotFound))
+		})
+
+		It("should not let non-members change public lists", func() {
+			expectList(listmgt.PublicListVisibility)
+			expectCollaboratorCount(0)
+
+			_, role, err := listmgt.FindUserList(db, listID, otherUserID, listmgt.CollaboratorListRole)
+
+			Expect(err).To(MatchError(listmgt.ErrListPermissionDenied))
+			Expect(role).To(Equal(listmgt.NoListRole))
+		})
+
+		It("should not let collaborators do what only the owner can do", func() {
+			expectList(listmgt.PrivateListVisibility)
+			expectCollaboratorCount(1)
+
+			_, role, err := listmgt.FindUserList(db, listID, otherUserID, listmgt.OwnerListRole)
+
+			Expect(err).To(MatchError(listmgt.ErrListPermissionDenied))
+			Expect(role).To(Equal(listmgt.CollaboratorListRole))
+		})
+	})
+
+	Context("when reordering the items of a list", func() {
+		firstShowID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000001")
+		secondShowID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000002")
+
+		BeforeEach(func() {
+			mockedDB.ExpectQuery(`SELECT "show_id" FROM "public"."list_items" WHERE list_id = \$1`).
+				WithArgs(listID).
+				WillReturnRows(sqlmock.NewRows([]string{"show_id"}).AddRow(firstShowID).AddRow(secondShowID))
+		})
+
+		It("should update every position in one statement", func() {
+			mockedDB.ExpectExec(`UPDATE public.list_items SET position = new_order.position - 1`).
+				WithArgs(`{"`+secondShowID.String()+`","`+firstShowID.String()+`"}`, listID).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			Expect(listmgt.ReorderListItems(db, listID, []uuid.UUID{secondShowID, firstShowID})).To(Succeed())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		DescribeTable("should reject orders which are not a permutation of the items",
+			func(showIDs []uuid.UUID) {
+				Expect(listmgt.ReorderListItems(db, listID, showIDs)).To(MatchError(listmgt.ErrInvalidListOrder))
+			},
+			Entry("with a missing show", []uuid.UUID{firstShowID}),
+			Entry("with a duplicated show", []uuid.UUID{firstShowID, firstShowID}),
+			Entry("with an unknown show", []uuid.UUID{firstShowID, listID}),
+		)
+	})
+})
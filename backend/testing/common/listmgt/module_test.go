@@ -0,0 +1,17 @@
+package listmgt_test
+
+import (
+	"wano-island/common/listmgt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+)
+
+var _ = Describe("[module.go]", func() {
+	Context("when initializing the list management module", func() {
+		It("should return an fx.Option", func() {
+			Expect(listmgt.NewListMgtModule()).To(BeAssignableToTypeOf(fx.Module("")))
+		})
+	})
+})
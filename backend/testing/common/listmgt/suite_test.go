@@ -0,0 +1,18 @@
+package listmgt_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+)
+
+var _ = BeforeSuite(func() {
+	IgnoreGinkgoParallelClient()
+})
+
+func TestListManagement(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "common/listmgt package")
+}
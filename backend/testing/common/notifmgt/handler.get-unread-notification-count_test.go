@@ -0,0 +1,80 @@
+package notifmgt_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/notifmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.get-unread-notification-count.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				notifmgt.NewGetUnreadNotificationCountHandler(notifmgt.GetUnreadNotificationCountHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+			}
+		})
+	})
+
+	It("should return 401 if the user is not signed in", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/notifications/unread-count", nil)
+		router.ServeHTTP(recorder, request)
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusUnauthorized))
+		Expect(response.MessageID).To(Equal("E-0002"))
+	})
+
+	It("should return the number of unread notifications of the current user", func() {
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."notifications" WHERE user_id = \$1 AND read_at IS NULL`).
+			WithArgs(uuid.Nil).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/notifications/unread-count", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[notifmgt.UnreadNotificationCountDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(response.Data.Count).To(Equal(int64(4)))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
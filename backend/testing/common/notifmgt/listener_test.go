@@ -0,0 +1,73 @@
+package notifmgt_test
+
+import (
+	"context"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/notifmgt"
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[listener.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		listener showmgt.CatalogEventListener
+	)
+
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+	followerID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6f")
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+		listener = notifmgt.NewNotificationListener(notifmgt.NotificationListenerParams{
+			Logger: core.NewNoopLogger(),
+			DB:     db,
+		})
+	})
+
+	It("should notify the followers who have not turned off the type of the event", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT "show_follows"."user_id" FROM "public"."show_follows" `+
+			`LEFT JOIN public.notification_preferences AS preferences `+
+			`ON preferences.user_id = show_follows.user_id AND preferences.type = \$1 `+
+			`WHERE show_follows.show_id = \$2 AND COALESCE\(preferences.in_app, TRUE\)`).
+			WithArgs(showmgt.ShowReleasedCatalogEvent, showID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(followerID))
+		mockedDB.ExpectExec(`INSERT INTO "public"."notifications"`).
+			WithArgs(sqlmock.AnyArg(), testutils.AnyTimeArg{}, followerID, showID, showmgt.ShowReleasedCatalogEvent,
+				nil, testutils.AnyTimeArg{}, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectCommit()
+
+		err := listener.OnCatalogEvents(context.Background(), []showmgt.CatalogEvent{
+			{Type: showmgt.ShowReleasedCatalogEvent, ShowID: showID, OccurredAt: time.Now()},
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should not insert anything when the show has no follower", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT "show_follows"."user_id" FROM "public"."show_follows"`).
+			WithArgs(showmgt.NewEpisodeCatalogEvent, showID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+		mockedDB.ExpectCommit()
+
+		err := listener.OnCatalogEvents(context.Background(), []showmgt.CatalogEvent{
+			{Type: showmgt.NewEpisodeCatalogEvent, ShowID: showID, OccurredAt: time.Now()},
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
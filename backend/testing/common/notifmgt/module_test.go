@@ -0,0 +1,17 @@
+package notifmgt_test
+
+import (
+	"wano-island/common/notifmgt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+)
+
+var _ = Describe("[module.go]", func() {
+	Context("when initializing the notification management module", func() {
+		It("should return an fx.Option", func() {
+			Expect(notifmgt.NewNotifMgtModule()).To(BeAssignableToTypeOf(fx.Module("")))
+		})
+	})
+})
@@ -0,0 +1,96 @@
+package notifmgt_test
+
+import (
+	"time"
+	"wano-island/common/notifmgt"
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[notifications.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+	)
+
+	userID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6f")
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+	})
+
+	Context("when following a show", func() {
+		It("should return ErrShowNotFound if the show does not exist", func() {
+			showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+
+			mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."shows" WHERE id = \$1`).
+				WithArgs(showID).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			Expect(notifmgt.FollowShow(db, userID, showID)).To(MatchError(notifmgt.ErrShowNotFound))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when marking notifications as read", func() {
+		It("should only mark the given unread notifications", func() {
+			notificationID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d70")
+
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."notifications" SET "read_at"=\$1 `+
+				`WHERE \(user_id = \$2 AND read_at IS NULL\) AND id IN \(\$3\)`).
+				WithArgs(testutils.AnyTimeArg{}, userID, notificationID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+
+			count, err := notifmgt.MarkNotificationsRead(db, userID, []uuid.UUID{notificationID}, time.Now())
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should mark every unread notification when none is given", func() {
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."notifications" SET "read_at"=\$1 `+
+				`WHERE user_id = \$2 AND read_at IS NULL$`).
+				WithArgs(testutils.AnyTimeArg{}, userID).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+			mockedDB.ExpectCommit()
+
+			count, err := notifmgt.MarkNotificationsRead(db, userID, nil, time.Now())
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(int64(3)))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when getting the notification preferences", func() {
+		It("should return the default preference for the types the user has not chosen", func() {
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."notification_preferences" WHERE user_id = \$1`).
+				WithArgs(userID).
+				WillReturnRows(sqlmock.NewRows([]string{"user_id", "type", "in_app", "email"}).
+					AddRow(userID, showmgt.ShowReleasedCatalogEvent, false, true))
+
+			preferences, err := notifmgt.GetNotificationPreferences(db, userID)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preferences).To(HaveLen(2))
+			Expect(preferences[0].Type).To(Equal(showmgt.NewEpisodeCatalogEvent))
+			Expect(preferences[0].InApp).To(BeTrue())
+			Expect(preferences[0].Email).To(BeFalse())
+			Expect(preferences[1].Type).To(Equal(showmgt.ShowReleasedCatalogEvent))
+			Expect(preferences[1].InApp).To(BeFalse())
+			Expect(preferences[1].Email).To(BeTrue())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,365 @@
+package showmgt_test
+
+import (
+	"context"
+	"errors"
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[batch.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+	)
+
+	jobID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6f")
+
+	BeforeEach(func() {
+		db, mockedDB = testutils.CreateTestDBInstance()
+	})
+
+	It("should report the progress of a batch job", func() {
+		Expect(showmgt.ToShowBatchJobDTO(&showmgt.ShowBatchJobModel{Total: 4, Processed: 1}).Progress).
+			To(Equal(0.25))
+		Expect(showmgt.ToShowBatchJobDTO(&showmgt.ShowBatchJobModel{}).Progress).To(Equal(1.0))
+	})
+
+	Context("when cancelling a batch job", func() {
+		It("should refuse to cancel a finished job", func() {
+			job := showmgt.ShowBatchJobModel{Status: showmgt.CompletedBatchJobStatus}
+
+			Expect(showmgt.CancelBatchJob(db, &job)).To(MatchError(showmgt.ErrBatchJobFinished))
+		})
+
+		It("should flag a running job for the worker", func() {
+			job := showmgt.ShowBatchJobModel{Status: showmgt.RunningBatchJobStatus}
+			job.ID = jobID
+
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "cancel_requested"=\$1,"updated_at"=\$2 WHERE "id" = \$3`).
+				WithArgs(true, testutils.AnyTimeArg{}, jobID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+
+			Expect(showmgt.CancelBatchJob(db, &job)).To(Succeed())
+			Expect(job.CancelRequested).To(BeTrue())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should cancel a pending job and its shows right away", func() {
+			job := showmgt.ShowBatchJobModel{Status: showmgt.PendingBatchJobStatus}
+			job.ID = jobID
+
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."show_batch_job_items" SET "status"=\$1 WHERE job_id = \$2 AND status = \$3`).
+				WithArgs("cancelled", jobID, "pending").
+				WillReturnResult(sqlmock.NewResult(0, 3))
+			mockedDB.ExpectCommit()
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "updated_at"=\$1,"status"=\$2,"finished_at"=\$3 WHERE "id" = \$4`).
+				WithArgs(testutils.AnyTimeArg{}, "cancelled", testutils.AnyTimeArg{}, jobID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+
+			Expect(showmgt.CancelBatchJob(db, &job)).To(Succeed())
+			Expect(job.Status).To(Equal(showmgt.CancelledBatchJobStatus))
+			Expect(job.FinishedAt).NotTo(BeNil())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	// expectHeartbeat expects the renewal of the claim of the worker on the job.
+	expectHeartbeat := func(worker string, rowsAffected int64) {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "heartbeat_at"=\$1,"updated_at"=\$2 `+
+			`WHERE worker = \$3 AND "id" = \$4`).
+			WithArgs(testutils.AnyTimeArg{}, testutils.AnyTimeArg{}, worker, jobID).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		mockedDB.ExpectCommit()
+	}
+
+	// expectAttempt expects the counting of an attempt to run the operation on the show.
+	expectAttempt := func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectExec(`UPDATE "public"."show_batch_job_items" SET "attempts"=attempts \+ 1 `+
+			`WHERE status = \$1 AND "job_id" = \$2 AND "show_id" = \$3`).
+			WithArgs("pending", jobID, showID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectCommit()
+	}
+
+	It("should claim the oldest claimable job for the worker in a single statement", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`UPDATE "public"."show_batch_jobs" SET "heartbeat_at"=\$1,"started_at"=COALESCE\(started_at, \$2\),`+
+			`"status"=\$3,"worker"=\$4,"updated_at"=\$5 WHERE id = \(SELECT "id" FROM "public"."show_batch_jobs" `+
+			`WHERE status = \$6 OR \(status = \$7 AND \(heartbeat_at IS NULL OR heartbeat_at < \$8\)\) `+
+			`ORDER BY id LIMIT \$9 FOR UPDATE SKIP LOCKED\) RETURNING \*`).
+			WithArgs(testutils.AnyTimeArg{}, testutils.AnyTimeArg{}, "running", "worker-1", testutils.AnyTimeArg{},
+				"pending", "running", testutils.AnyTimeArg{}, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "worker"}).AddRow(jobID, "running", "worker-1"))
+		mockedDB.ExpectCommit()
+
+		job, err := showmgt.ClaimBatchJob(db, "worker-1")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.ID).To(Equal(jobID))
+		Expect(*job.Worker).To(Equal("worker-1"))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should not claim any job when none can be run", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`UPDATE "public"."show_batch_jobs" .* RETURNING \*`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockedDB.ExpectCommit()
+
+		Expect(showmgt.ClaimBatchJob(db, "worker-1")).To(BeNil())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should stop running a job which has been claimed by another worker", func() {
+		worker := "worker-1"
+		job := showmgt.ShowBatchJobModel{Status: showmgt.RunningBatchJobStatus, Worker: &worker}
+		job.ID = jobID
+
+		expectHeartbeat(worker, 0)
+
+		Expect(showmgt.RunBatchJob(context.Background(), db, &job)).To(MatchError(showmgt.ErrBatchJobLost))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should skip the shows which already match the result of the operation", func() {
+		isReleased := true
+		worker := "worker-1"
+		job := showmgt.ShowBatchJobModel{
+			Operation:  showmgt.SetReleasedBatchOperation,
+			IsReleased: &isReleased,
+			Status:     showmgt.RunningBatchJobStatus,
+			Total:      1,
+			Worker:     &worker,
+		}
+		job.ID = jobID
+
+		expectHeartbeat(worker, 1)
+		mockedDB.ExpectQuery(`SELECT "cancel_requested" FROM "public"."show_batch_jobs" WHERE "show_batch_jobs"."id" = \$1`).
+			WithArgs(jobID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"cancel_requested"}).AddRow(false))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_batch_job_items" WHERE job_id = \$1 AND status = \$2 `+
+			`ORDER BY position LIMIT \$3`).
+			WithArgs(jobID, "pending", 25).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "show_id", "position", "status"}).
+				AddRow(jobID, showID, 0, "pending"))
+		expectAttempt()
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE id = \$1 .* FOR UPDATE`).
+			WithArgs(showID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "is_released"}).AddRow(showID, true))
+		mockedDB.ExpectExec(`UPDATE "public"."show_batch_job_items" SET "status"=\$1,"error"=\$2,"processed_at"=\$3 `+
+			`WHERE status = \$4 AND "job_id" = \$5 AND "show_id" = \$6`).
+			WithArgs("skipped", nil, testutils.AnyTimeArg{}, "pending", jobID, showID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "processed"=processed \+ 1,"skipped"=skipped \+ 1,`+
+			`"updated_at"=\$1 WHERE "id" = \$2`).
+			WithArgs(testutils.AnyTimeArg{}, jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectCommit()
+		expectHeartbeat(worker, 1)
+		mockedDB.ExpectQuery(`SELECT "cancel_requested" FROM "public"."show_batch_jobs"`).
+			WithArgs(jobID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"cancel_requested"}).AddRow(false))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_batch_job_items"`).
+			WithArgs(jobID, "pending", 25).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "show_id", "position", "status"}))
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "updated_at"=\$1,"status"=\$2,"finished_at"=\$3`).
+			WithArgs(testutils.AnyTimeArg{}, "completed", testutils.AnyTimeArg{}, jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectCommit()
+
+		Expect(showmgt.RunBatchJob(context.Background(), db, &job)).To(Succeed())
+		Expect(job.Status).To(Equal(showmgt.CompletedBatchJobStatus))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should record a revision of every changed show", func() {
+		isReleased := false
+		worker := "worker-1"
+		job := showmgt.ShowBatchJobModel{
+			Operation:  showmgt.SetReleasedBatchOperation,
+			IsReleased: &isReleased,
+			Status:     showmgt.RunningBatchJobStatus,
+			Total:      1,
+			Worker:     &worker,
+		}
+		job.ID = jobID
+		job.CreatedBy = "editor@wano.island"
+
+		expectHeartbeat(worker, 1)
+		mockedDB.ExpectQuery(`SELECT "cancel_requested" FROM "public"."show_batch_jobs"`).
+			WithArgs(jobID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"cancel_requested"}).AddRow(false))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_batch_job_items"`).
+			WithArgs(jobID, "pending", 25).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "show_id", "position", "status"}).
+				AddRow(jobID, showID, 0, "pending"))
+		expectAttempt()
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE id = \$1 .* FOR UPDATE`).
+			WithArgs(showID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "is_released"}).AddRow(showID, true))
+		mockedDB.ExpectExec(`UPDATE "public"."shows" SET "is_released"=\$1,"updated_at"=\$2 WHERE "id" = \$3`).
+			WithArgs(false, testutils.AnyTimeArg{}, showID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectExec(`INSERT INTO "public"."show_revisions" \("id","created_at","created_by","show_id",`+
+			`"batch_job_id","field","old_value","new_value"\)`).
+			WithArgs(sqlmock.AnyArg(), testutils.AnyTimeArg{}, "editor@wano.island", showID, jobID,
+				"isReleased", "true", "false").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectExec(`UPDATE "public"."show_batch_job_items" SET "status"=\$1`).
+			WithArgs("succeeded", nil, testutils.AnyTimeArg{}, "pending", jobID, showID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "processed"=processed \+ 1,"succeeded"=succeeded \+ 1`).
+			WithArgs(testutils.AnyTimeArg{}, jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectCommit()
+		expectHeartbeat(worker, 1)
+		mockedDB.ExpectQuery(`SELECT "cancel_requested" FROM "public"."show_batch_jobs"`).
+			WithArgs(jobID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"cancel_requested"}).AddRow(false))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_batch_job_items"`).
+			WithArgs(jobID, "pending", 25).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "show_id", "position", "status"}))
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "updated_at"=\$1,"status"=\$2,"finished_at"=\$3`).
+			WithArgs(testutils.AnyTimeArg{}, "completed", testutils.AnyTimeArg{}, jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectCommit()
+
+		Expect(showmgt.RunBatchJob(context.Background(), db, &job)).To(Succeed())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	Context("when the operation cannot be applied to a show", func() {
+		var job showmgt.ShowBatchJobModel
+
+		worker := "worker-1"
+
+		BeforeEach(func() {
+			isReleased := true
+			job = showmgt.ShowBatchJobModel{
+				Operation:  showmgt.SetReleasedBatchOperation,
+				IsReleased: &isReleased,
+				Status:     showmgt.RunningBatchJobStatus,
+				Total:      1,
+				Worker:     &worker,
+			}
+			job.ID = jobID
+		})
+
+		// expectItem expects the loading of the item of the show, which has already been tried the
+		// given number of times, and the counting of the new attempt.
+		expectItem := func(attempts int) {
+			expectHeartbeat(worker, 1)
+			mockedDB.ExpectQuery(`SELECT "cancel_requested" FROM "public"."show_batch_jobs"`).
+				WithArgs(jobID, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"cancel_requested"}).AddRow(false))
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_batch_job_items"`).
+				WithArgs(jobID, "pending", 25).
+				WillReturnRows(sqlmock.NewRows([]string{"job_id", "show_id", "position", "status", "attempts"}).
+					AddRow(jobID, showID, 0, "pending", attempts))
+			expectAttempt()
+			mockedDB.ExpectBegin()
+		}
+
+		// expectCompletion expects the job to find no pending item left, and to complete.
+		expectCompletion := func() {
+			expectHeartbeat(worker, 1)
+			mockedDB.ExpectQuery(`SELECT "cancel_requested" FROM "public"."show_batch_jobs"`).
+				WithArgs(jobID, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"cancel_requested"}).AddRow(false))
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_batch_job_items"`).
+				WithArgs(jobID, "pending", 25).
+				WillReturnRows(sqlmock.NewRows([]string{"job_id", "show_id", "position", "status"}))
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "updated_at"=\$1,"status"=\$2,"finished_at"=\$3`).
+				WithArgs(testutils.AnyTimeArg{}, "completed", testutils.AnyTimeArg{}, jobID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+		}
+
+		It("should fail the item of a show which has been deleted", func() {
+			expectItem(0)
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE id = \$1 .* FOR UPDATE`).
+				WithArgs(showID, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mockedDB.ExpectRollback()
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."show_batch_job_items" SET "status"=\$1,"error"=\$2,"processed_at"=\$3`).
+				WithArgs("failed", showmgt.ErrShowNotFound.Error(), testutils.AnyTimeArg{}, "pending", jobID, showID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "failed"=failed \+ 1,"processed"=processed \+ 1`).
+				WithArgs(testutils.AnyTimeArg{}, jobID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+			expectCompletion()
+
+			Expect(showmgt.RunBatchJob(context.Background(), db, &job)).To(Succeed())
+			Expect(job.Status).To(Equal(showmgt.CompletedBatchJobStatus))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should leave the item pending when the database fails", func() {
+			expectItem(0)
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE id = \$1 .* FOR UPDATE`).
+				WithArgs(showID, 1).
+				WillReturnError(errors.New("connection reset by peer"))
+			mockedDB.ExpectRollback()
+
+			Expect(showmgt.RunBatchJob(context.Background(), db, &job)).To(MatchError("connection reset by peer"))
+			Expect(job.Status).To(Equal(showmgt.RunningBatchJobStatus))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should fail the item of a show which fails on every attempt, and complete the job", func() {
+			for attempts := 0; attempts < 3; attempts++ {
+				expectItem(attempts)
+				mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE id = \$1 .* FOR UPDATE`).
+					WithArgs(showID, 1).
+					WillReturnError(errors.New("deadlock detected"))
+				mockedDB.ExpectRollback()
+			}
+
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."show_batch_job_items" SET "status"=\$1,"error"=\$2,"processed_at"=\$3`).
+				WithArgs("failed", "deadlock detected", testutils.AnyTimeArg{}, "pending", jobID, showID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."show_batch_jobs" SET "failed"=failed \+ 1,"processed"=processed \+ 1`).
+				WithArgs(testutils.AnyTimeArg{}, jobID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mockedDB.ExpectCommit()
+			expectCompletion()
+
+			// The job is resumed after each failure, as the worker does once the lease has expired.
+			for attempts := 1; attempts < 3; attempts++ {
+				Expect(showmgt.RunBatchJob(context.Background(), db, &job)).To(MatchError("deadlock detected"))
+				Expect(job.Status).To(Equal(showmgt.RunningBatchJobStatus))
+			}
+
+			Expect(showmgt.RunBatchJob(context.Background(), db, &job)).To(Succeed())
+			Expect(job.Status).To(Equal(showmgt.CompletedBatchJobStatus))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+})
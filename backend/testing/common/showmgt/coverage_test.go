@@ -0,0 +1,74 @@
+package showmgt_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[coverage.go]", func() {
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000001")
+	seasonID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000002")
+	episodeID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000003")
+
+	editedAt := time.Date(2024, time.October, 2, 10, 0, 0, 0, time.UTC)
+	translatedAt := time.Date(2024, time.October, 1, 10, 0, 0, 0, time.UTC)
+
+	items := []showmgt.TranslatableItem{
+		{Kind: showmgt.ShowTranslatable, ID: showID, ShowID: showID, ShowTitle: "One Piece", OriginalLanguage: "ja", UpdatedAt: translatedAt},
+		{Kind: showmgt.SeasonTranslatable, ID: seasonID, ShowID: showID, ShowTitle: "One Piece", Order: 1, OriginalLanguage: "ja", UpdatedAt: editedAt},
+		{Kind: showmgt.EpisodeTranslatable, ID: episodeID, ShowID: showID, ShowTitle: "One Piece", Order: 1, OriginalLanguage: "ja", UpdatedAt: translatedAt},
+	}
+
+	translations := []showmgt.TranslationStamp{
+		{ItemID: showID, Locale: "en", UpdatedAt: editedAt},
+		{ItemID: seasonID, Locale: "en", UpdatedAt: translatedAt},
+	}
+
+	It("should report missing and outdated translations with coverage percentages", func() {
+		report := showmgt.BuildTranslationCoverage([]string{"en"}, items, translations)
+
+		Expect(report.Locales).To(HaveLen(1))
+
+		coverage := report.Locales[0]
+		Expect(coverage.Locale).To(Equal("en"))
+		Expect(coverage.Coverage).To(Equal(33.33))
+		Expect(coverage.Shows).To(Equal(showmgt.KindCoverage{Total: 1, Translated: 1, UpToDate: 1, Coverage: 100}))
+		Expect(coverage.Seasons).To(Equal(showmgt.KindCoverage{Total: 1, Translated: 1, UpToDate: 0, Coverage: 0}))
+		Expect(coverage.Episodes).To(Equal(showmgt.KindCoverage{Total: 1, Translated: 0, UpToDate: 0, Coverage: 0}))
+
+		Expect(coverage.Missing).To(HaveLen(1))
+		Expect(coverage.Missing[0].ID).To(Equal(episodeID))
+
+		Expect(coverage.Outdated).To(HaveLen(1))
+		Expect(coverage.Outdated[0].ID).To(Equal(seasonID))
+		Expect(*coverage.Outdated[0].TranslationUpdatedAt).To(Equal(translatedAt))
+	})
+
+	It("should not expect translations in the original language", func() {
+		report := showmgt.BuildTranslationCoverage([]string{"ja-JP"}, items, translations)
+
+		Expect(report.Locales[0].Coverage).To(Equal(float64(100)))
+		Expect(report.Locales[0].Shows.Total).To(BeZero())
+		Expect(report.Locales[0].Missing).To(BeEmpty())
+	})
+
+	It("should export one CSV line per missing or outdated translation", func() {
+		report := showmgt.BuildTranslationCoverage([]string{"en"}, items, translations)
+
+		var body bytes.Buffer
+		Expect(report.WriteCSV(&body)).To(Succeed())
+
+		lines := strings.Split(strings.TrimSpace(body.String()), "\n")
+		Expect(lines).To(Equal([]string{
+			"locale,locale_coverage,status,kind,id,show_id,show_title,order,updated_at,translation_updated_at",
+			"en,33.33,missing,episode," + episodeID.String() + "," + showID.String() + ",One Piece,1,2024-10-01T10:00:00Z,",
+			"en,33.33,outdated,season," + seasonID.String() + "," + showID.String() + ",One Piece,1,2024-10-02T10:00:00Z,2024-10-01T10:00:00Z",
+		}))
+	})
+})
@@ -0,0 +1,81 @@
+package showmgt_test
+
+import (
+	"context"
+	"time"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[events.go]", func() {
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+	now := time.Now()
+	airDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	nextAirDate := airDate.AddDate(0, 0, 7)
+
+	Context("when detecting the catalog events of a change", func() {
+		It("should detect the release of the show", func() {
+			before := showmgt.ShowModel{}
+			after := showmgt.ShowModel{IsReleased: true}
+			after.ID = showID
+
+			Expect(showmgt.DetectCatalogEvents(&before, &after, now)).To(Equal([]showmgt.CatalogEvent{
+				{Type: showmgt.ShowReleasedCatalogEvent, ShowID: showID, OccurredAt: now},
+			}))
+		})
+
+		It("should detect a new episode when the last air date moves forward", func() {
+			before := showmgt.ShowModel{IsReleased: true, LastAirDate: &airDate}
+			after := showmgt.ShowModel{IsReleased: true, LastAirDate: &nextAirDate}
+			after.ID = showID
+
+			Expect(showmgt.DetectCatalogEvents(&before, &after, now)).To(Equal([]showmgt.CatalogEvent{
+				{Type: showmgt.NewEpisodeCatalogEvent, ShowID: showID, OccurredAt: now, AirDate: &nextAirDate},
+			}))
+		})
+
+		It("should record the new last air date as announced", func() {
+			before := showmgt.ShowModel{IsReleased: true, LastAirDate: &airDate, AnnouncedAirDate: &airDate}
+			after := showmgt.ShowModel{IsReleased: true, LastAirDate: &nextAirDate, AnnouncedAirDate: &airDate}
+
+			Expect(showmgt.DetectCatalogEvents(&before, &after, now)).To(HaveLen(1))
+			Expect(after.AnnouncedAirDate).To(Equal(&nextAirDate))
+		})
+
+		It("should not detect anything when the last air date moves backward or the show is unreleased", func() {
+			before := showmgt.ShowModel{IsReleased: true, LastAirDate: &nextAirDate, AnnouncedAirDate: &nextAirDate}
+			after := showmgt.ShowModel{IsReleased: false, LastAirDate: &airDate, AnnouncedAirDate: &nextAirDate}
+
+			Expect(showmgt.DetectCatalogEvents(&before, &after, now)).To(BeEmpty())
+		})
+
+		It("should not detect a new episode until its air date is reached", func() {
+			futureAirDate := now.AddDate(0, 0, 7)
+			before := showmgt.ShowModel{IsReleased: true, LastAirDate: &airDate, AnnouncedAirDate: &airDate}
+			after := showmgt.ShowModel{IsReleased: true, LastAirDate: &futureAirDate, AnnouncedAirDate: &airDate}
+
+			Expect(showmgt.DetectCatalogEvents(&before, &after, now)).To(BeEmpty())
+			Expect(after.AnnouncedAirDate).To(Equal(&airDate))
+
+			event, ok := showmgt.AnnounceAiredEpisode(&after, futureAirDate)
+
+			Expect(ok).To(BeTrue())
+			Expect(event).To(Equal(showmgt.CatalogEvent{
+				Type: showmgt.NewEpisodeCatalogEvent, ShowID: after.ID, OccurredAt: futureAirDate, AirDate: &futureAirDate,
+			}))
+		})
+	})
+
+	Context("when publishing catalog events", func() {
+		It("should do nothing without a dispatcher", func() {
+			var events *showmgt.CatalogEvents
+
+			Expect(func() {
+				events.Dispatch(context.Background(), showmgt.CatalogEvent{Type: showmgt.ShowReleasedCatalogEvent})
+			}).ToNot(Panic())
+		})
+	})
+})
@@ -0,0 +1,164 @@
+package showmgt_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("[exports.go]", func() {
+	var (
+		showModel    *showmgt.ShowModel
+		seasonModel  showmgt.SeasonModel
+		episodeModel showmgt.EpisodeModel
+	)
+
+	BeforeEach(func() {
+		seasonModel = showmgt.SeasonModel{
+			Model: core.Model{ID: uuid.MustParse("22222222-2222-2222-2222-222222222222")},
+			Order: 1,
+			Translations: []showmgt.SeasonTranslationModel{
+				{Locale: "fr-FR", Title: "East Blue", Overview: "Luffy part en mer."},
+			},
+		}
+
+		episodeModel = showmgt.EpisodeModel{
+			Model:    core.Model{ID: uuid.MustParse("33333333-3333-3333-3333-333333333333")},
+			Order:    1,
+			Title:    "Romance Dawn",
+			Overview: "Luffy meets Coby.",
+		}
+
+		showModel = &showmgt.ShowModel{
+			Model:            core.Model{ID: uuid.MustParse("11111111-1111-1111-1111-111111111111")},
+			Kind:             "tv",
+			OriginalLanguage: "ja",
+			OriginalTitle:    "One Piece",
+			Slug:             lo.ToPtr("one-piece"),
+			OriginalOverview: lo.ToPtr("Monkey D. Luffy sets off on an adventure."),
+			Keywords:         []string{"pirates", "adventure"},
+			Status:           lo.ToPtr(showmgt.ReturningShowStatus),
+			FirstAirDate:     lo.ToPtr(time.Date(1999, time.October, 20, 0, 0, 0, 0, time.UTC)),
+			OriginCountries:  []string{"JP"},
+			Seasons:          []showmgt.SeasonModel{seasonModel},
+			Translations: []showmgt.ShowTranslationModel{
+				{Locale: "fr-FR", Title: "One Piece (VF)", Overview: "Luffy part à l'aventure."},
+			},
+			Videos: []showmgt.VideoModel{
+				{Site: showmgt.YouTubeVideoSite, Key: "S8_YwFLCh4U", Name: "Trailer", Type: showmgt.TrailerVideoType, Language: "ja"},
+			},
+		}
+	})
+
+	It("should render a TV show as a Kodi NFO document in the requested locale", func() {
+		exporter := &showmgt.CatalogExporter{Format: showmgt.NFOExportFormat, Languages: []string{"fr"}}
+
+		body, err := exporter.ExportShow(showModel, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(HavePrefix(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n<tvshow>"))
+		Expect(string(body)).To(ContainSubstring("<title>One Piece (VF)</title>"))
+		Expect(string(body)).To(ContainSubstring("<originaltitle>One Piece</originaltitle>"))
+		Expect(string(body)).To(ContainSubstring("<plot>Luffy part à l&#39;aventure.</plot>"))
+		Expect(string(body)).To(ContainSubstring("<premiered>1999-10-20</premiered>"))
+		Expect(string(body)).To(ContainSubstring("<status>Continuing</status>"))
+		Expect(string(body)).To(ContainSubstring("<tag>pirates</tag>\n  <tag>adventure</tag>"))
+		Expect(string(body)).To(ContainSubstring("<trailer>plugin://plugin.video.youtube/play/?video_id=S8_YwFLCh4U</trailer>"))
+		Expect(string(body)).To(ContainSubstring(
+			`<uniqueid type="wano-island" default="true">11111111-1111-1111-1111-111111111111</uniqueid>`))
+	})
+
+	It("should render a movie as schema.org JSON-LD", func() {
+		showModel.Kind = "movie"
+		showModel.Runtime = lo.ToPtr(115)
+		exporter := &showmgt.CatalogExporter{Format: showmgt.JSONLDExportFormat, Languages: []string{"en"}, BaseURL: "https://example.com"}
+
+		body, err := exporter.ExportShow(showModel, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		var document map[string]any
+		Expect(json.Unmarshal(body, &document)).To(Succeed())
+		Expect(document).To(MatchKeys(IgnoreExtras, Keys{
+			"@context":        Equal("https://schema.org"),
+			"@type":           Equal("Movie"),
+			"@id":             Equal("https://example.com/api/v1/shows/11111111-1111-1111-1111-111111111111"),
+			"name":            Equal("One Piece"),
+			"description":     Equal("Monkey D. Luffy sets off on an adventure."),
+			"inLanguage":      Equal("ja"),
+			"datePublished":   Equal("1999-10-20"),
+			"duration":        Equal("PT115M"),
+			"keywords":        Equal("pirates, adventure"),
+			"countryOfOrigin": ConsistOf(HaveKeyWithValue("name", "JP")),
+			"trailer":         HaveKeyWithValue("embedUrl", "https://www.youtube.com/watch?v=S8_YwFLCh4U"),
+		}))
+		Expect(document).ToNot(HaveKey("containsSeason"))
+	})
+
+	It("should render a TV series with its seasons as schema.org JSON-LD", func() {
+		exporter := &showmgt.CatalogExporter{Format: showmgt.JSONLDExportFormat, Languages: []string{"de", "fr"}}
+
+		body, err := exporter.ExportShow(showModel, 12)
+		Expect(err).ToNot(HaveOccurred())
+
+		var document map[string]any
+		Expect(json.Unmarshal(body, &document)).To(Succeed())
+		Expect(document).To(MatchKeys(IgnoreExtras, Keys{
+			"@type":            Equal("TVSeries"),
+			"name":             Equal("One Piece (VF)"),
+			"alternateName":    Equal("One Piece"),
+			"startDate":        Equal("1999-10-20"),
+			"numberOfSeasons":  BeEquivalentTo(1),
+			"numberOfEpisodes": BeEquivalentTo(12),
+			"containsSeason": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+				"@type":        Equal("TVSeason"),
+				"name":         Equal("East Blue"),
+				"seasonNumber": BeEquivalentTo(1),
+			})),
+		}))
+	})
+
+	It("should fall back to the original texts of the seasons and episodes", func() {
+		exporter := &showmgt.CatalogExporter{Format: showmgt.NFOExportFormat, Languages: []string{"en"}}
+
+		body, err := exporter.ExportSeason(showModel, &seasonModel)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("<title>Season 1</title>\n  <showtitle>One Piece</showtitle>\n  <seasonnumber>1</seasonnumber>"))
+
+		body, err = exporter.ExportEpisode(showModel, &episodeModel)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("<episodedetails>\n  <title>Romance Dawn</title>"))
+		Expect(string(body)).To(ContainSubstring("<episode>1</episode>\n  <plot>Luffy meets Coby.</plot>"))
+	})
+
+	It("should write a folder per show in the archives", func() {
+		var buffer bytes.Buffer
+		archive := zip.NewWriter(&buffer)
+
+		exporter := &showmgt.CatalogExporter{Format: showmgt.NFOExportFormat, Languages: []string{"en"}}
+		Expect(exporter.WriteArchive(archive, showModel, []showmgt.EpisodeModel{episodeModel})).To(Succeed())
+
+		exporter.Format = showmgt.JSONLDExportFormat
+		Expect(exporter.WriteArchive(archive, showModel, []showmgt.EpisodeModel{episodeModel})).To(Succeed())
+		Expect(archive.Close()).To(Succeed())
+
+		reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lo.Map(reader.File, func(file *zip.File, _ int) string { return file.Name })).To(Equal([]string{
+			"one-piece/tvshow.nfo",
+			"one-piece/Season 01/season.nfo",
+			"one-piece/episode-0001.nfo",
+			"one-piece/show.jsonld",
+			"one-piece/season-01.jsonld",
+			"one-piece/episode-0001.jsonld",
+		}))
+	})
+})
@@ -0,0 +1,108 @@
+package showmgt_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.create-batch-job.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewCreateBatchJobHandler(showmgt.CreateBatchJobHandlerParams{
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Validator:           core.NewValidator(core.NewUniversalTranslator()),
+					UniversalTranslator: core.NewUniversalTranslator(),
+				}),
+			}
+		})
+	})
+
+	It("should return 400 if the shows are selected both with IDs and a filter", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows/batch", bytes.NewReader([]byte(`{
+            "showIds": ["0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"],
+            "filter": {"kind": "tv"},
+            "operation": "set_released",
+            "isReleased": true
+        }`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		Expect(response.MessageID).To(Equal("E-0005"))
+	})
+
+	It("should return 400 if the keyword of a keyword operation is missing", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows/batch", bytes.NewReader([]byte(`{
+            "filter": {"kind": "tv"},
+            "operation": "add_keyword"
+        }`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		Expect(response.MessageID).To(Equal("E-0005"))
+	})
+
+	It("should return 404 if the keyword to remove does not exist", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."keywords" WHERE name = \$1 OR id IN `+
+			`\(SELECT "keyword_id" FROM "public"."keyword_synonyms" WHERE synonym = \$2\)`).
+			WithArgs("time travel", "time travel", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockedDB.ExpectRollback()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows/batch", bytes.NewReader([]byte(`{
+            "showIds": ["0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"],
+            "operation": "remove_keyword",
+            "keyword": " Time  Travel"
+        }`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusNotFound))
+		Expect(response.MessageID).To(Equal("E-0009"))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
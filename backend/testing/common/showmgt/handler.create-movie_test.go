@@ -40,12 +40,16 @@ var _ = Describe("[handler.create-movie.go]", func() {
 		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
 		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
 
+		universalTranslator := core.NewUniversalTranslator()
+
 		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
 			rp.Config = config
 			rp.Routes = []core.HTTPRoute{
 				showmgt.NewCreateMovieHandler(showmgt.CreateShowHandlerParams{
-					Logger: core.NewNoopLogger(),
-					DB:     db,
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Validator:           core.NewValidator(universalTranslator),
+					UniversalTranslator: universalTranslator,
 				}),
 			}
 		})
@@ -73,8 +77,86 @@ var _ = Describe("[handler.create-movie.go]", func() {
 		}))
 	})
 
+	It("should return an error if the request body is invalid", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows", bytes.NewReader([]byte(`
+        {
+            "kind": "movie",
+            "originalLanguage": "ja",
+            "originalTitle": "Naruto - Title",
+            "status": "cancelled",
+            "firstAirDate": "17/07/2004",
+            "homepage": "not-a-url",
+            "originCountries": ["Japan"]
+        }`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[map[string]string]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		Expect(response.MessageID).To(Equal("E-0005"))
+		Expect(response.Data).To(HaveKey("status"))
+		Expect(response.Data).To(HaveKey("firstAirDate"))
+		Expect(response.Data).To(HaveKey("homepage"))
+		Expect(response.Data).To(HaveKey("originCountries[0]"))
+	})
+
+	DescribeTable("should reject an unknown kind or an invalid original language",
+		func(kind string, originalLanguage string, invalidField string) {
+			body, _ := json.Marshal(map[string]any{
+				"kind":             kind,
+				"originalLanguage": originalLanguage,
+				"originalTitle":    "Naruto - Title",
+			})
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/shows", bytes.NewReader(body))
+			router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+			var response core.Response[map[string]string]
+			_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+			Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+			Expect(response.MessageID).To(Equal("E-0005"))
+			Expect(response.Data).To(HaveLen(1))
+			Expect(response.Data).To(HaveKey(invalidField))
+		},
+		Entry("with a kind which is not supported", "series", "ja", "kind"),
+		Entry("with a kind in uppercase", "TV", "ja", "kind"),
+		Entry("with a language name", "movie", "Japanese", "originalLanguage"),
+		Entry("with an unknown language subtag", "movie", "xx-invalid-tag", "originalLanguage"),
+		Entry("with a malformed language tag", "tv", "ja--jp", "originalLanguage"),
+	)
+
+	It("should reject a last air date before the first air date", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows", bytes.NewReader([]byte(`
+        {
+            "kind": "tv",
+            "originalLanguage": "ja",
+            "originalTitle": "Naruto - Title",
+            "firstAirDate": "2007-02-15",
+            "lastAirDate": "2004-07-17"
+        }`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[map[string]string]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		Expect(response.MessageID).To(Equal("E-0005"))
+		Expect(response.Data).To(Equal(map[string]string{
+			"lastAirDate": "lastAirDate must be on or after FirstAirDate",
+		}))
+	})
+
 	It("should return an error if cannot create a show", func() {
 		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT name AS value, id AS keyword_id, name FROM public.keywords`).
+			WithArgs("naruto", "naruto").
+			WillReturnRows(sqlmock.NewRows([]string{"value", "keyword_id", "name"}).
+				AddRow("naruto", "0192f5a4-7a5e-7c6a-9d1e-000000000001", "naruto"))
 		mockedDB.ExpectExec(`INSERT INTO "public"."shows"`).
 			WithArgs(
 				// id
@@ -89,12 +171,30 @@ var _ = Describe("[handler.create-movie.go]", func() {
 				"ja",
 				// original_title
 				"Naruto - Title",
+				// slug
+				nil,
 				// original_overview
 				"Naruto - Overview",
+				// tagline
+				nil,
 				// keywords
 				`{"naruto"}`,
 				// is_released
 				true,
+				// status
+				"ended",
+				// runtime
+				110,
+				// first_air_date
+				time.Date(2004, time.July, 17, 0, 0, 0, 0, time.UTC),
+				// last_air_date
+				nil,
+				// announced_air_date
+				nil,
+				// homepage
+				nil,
+				// origin_countries
+				`{"JP"}`,
 			).
 			WillReturnError(errors.New("something went wrong"))
 		mockedDB.ExpectRollback()
@@ -109,7 +209,11 @@ var _ = Describe("[handler.create-movie.go]", func() {
             "keywords": [
                 "naruto"
             ],
-            "isReleased": true
+            "isReleased": true,
+            "status": "ended",
+            "runtime": 110,
+            "firstAirDate": "2004-07-17",
+            "originCountries": ["JP"]
         }`)))
 
 		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
@@ -129,8 +233,68 @@ var _ = Describe("[handler.create-movie.go]", func() {
 		}))
 	})
 
+	It("should add the keywords which are not in the vocabulary yet", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT name AS value, id AS keyword_id, name FROM public.keywords`).
+			WithArgs("naruto", "ninja", "naruto", "ninja").
+			WillReturnRows(sqlmock.NewRows([]string{"value", "keyword_id", "name"}).
+				AddRow("naruto", "0192f5a4-7a5e-7c6a-9d1e-000000000001", "naruto"))
+		mockedDB.ExpectExec(`INSERT INTO "public"."keywords" \("id","created_at","updated_at","name"\) VALUES \(\$1,\$2,\$3,\$4\) `+
+			`ON CONFLICT DO NOTHING`).
+			WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, testutils.AnyTimeArg{}, "ninja").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectQuery(`SELECT name AS value, id AS keyword_id, name FROM public.keywords`).
+			WithArgs("naruto", "ninja", "naruto", "ninja").
+			WillReturnRows(sqlmock.NewRows([]string{"value", "keyword_id", "name"}).
+				AddRow("naruto", "0192f5a4-7a5e-7c6a-9d1e-000000000001", "naruto").
+				AddRow("ninja", "0192f5a4-7a5e-7c6a-9d1e-000000000002", "ninja"))
+		mockedDB.ExpectExec(`INSERT INTO "public"."shows"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectExec(`DELETE FROM "public"."show_keywords" WHERE show_id = \$1`).
+			WithArgs(testutils.AnyUUIDArg{}).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDB.ExpectExec(`INSERT INTO "public"."show_keywords"`).
+			WithArgs(testutils.AnyUUIDArg{}, "0192f5a4-7a5e-7c6a-9d1e-000000000001", 0,
+				testutils.AnyUUIDArg{}, "0192f5a4-7a5e-7c6a-9d1e-000000000002", 1).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		mockedDB.ExpectQuery(`SELECT "slug" FROM "public"."show_slugs"`).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_slugs"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockedDB.ExpectExec(`INSERT INTO "public"."show_slugs"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectExec(`UPDATE "public"."shows" SET "slug"=\$1 WHERE "id" = \$2`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectExec(`INSERT INTO "public"."catalog_events"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectCommit()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows", bytes.NewReader([]byte(`
+        {
+            "kind": "movie",
+            "originalLanguage": "ja",
+            "originalTitle": "Naruto - Title",
+            "keywords": ["naruto", "Ninja"],
+            "isReleased": true
+        }`)))
+
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[showmgt.ShowDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusCreated))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		Expect(response.Data.Keywords).To(Equal([]string{"naruto", "ninja"}))
+	})
+
 	It("should create a show successfully", func() {
 		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT name AS value, id AS keyword_id, name FROM public.keywords`).
+			WithArgs("naruto", "naruto").
+			WillReturnRows(sqlmock.NewRows([]string{"value", "keyword_id", "name"}).
+				AddRow("naruto", "0192f5a4-7a5e-7c6a-9d1e-000000000001", "naruto"))
 		mockedDB.ExpectExec(`INSERT INTO "public"."shows"`).
 			WithArgs(
 				// id
@@ -145,14 +309,54 @@ var _ = Describe("[handler.create-movie.go]", func() {
 				"ja",
 				// original_title
 				"Naruto - Title",
+				// slug
+				nil,
 				// original_overview
 				"Naruto - Overview",
+				// tagline
+				nil,
 				// keywords
 				`{"naruto"}`,
 				// is_released
 				true,
+				// status
+				"ended",
+				// runtime
+				110,
+				// first_air_date
+				time.Date(2004, time.July, 17, 0, 0, 0, 0, time.UTC),
+				// last_air_date
+				nil,
+				// announced_air_date
+				nil,
+				// homepage
+				nil,
+				// origin_countries
+				`{"JP"}`,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectExec(`DELETE FROM "public"."show_keywords" WHERE show_id = \$1`).
+			WithArgs(testutils.AnyUUIDArg{}).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDB.ExpectExec(`INSERT INTO "public"."show_keywords"`).
+			WithArgs(testutils.AnyUUIDArg{}, "0192f5a4-7a5e-7c6a-9d1e-000000000001", 0).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectQuery(`SELECT "slug" FROM "public"."show_slugs"`).
+			WithArgs("naruto-title-2004", "naruto-title-2004-%", testutils.AnyUUIDArg{}).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("naruto-title-2004"))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_slugs"`).
+			WithArgs(testutils.AnyUUIDArg{}, "naruto-title-2004-2", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockedDB.ExpectExec(`INSERT INTO "public"."show_slugs"`).
+			WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, testutils.AnyUUIDArg{}, "", "naruto-title-2004-2").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectExec(`UPDATE "public"."shows" SET "slug"=\$1 WHERE "id" = \$2`).
+			WithArgs("naruto-title-2004-2", testutils.AnyUUIDArg{}).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectExec(`INSERT INTO "public"."catalog_events"`).
+			WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, "show_changed", testutils.AnyUUIDArg{},
+				testutils.AnyTimeArg{}, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
 		mockedDB.ExpectCommit()
 
 		recorder := httptest.NewRecorder()
@@ -163,9 +367,14 @@ var _ = Describe("[handler.create-movie.go]", func() {
             "originalTitle": "Naruto - Title",
             "originalOverview": "Naruto - Overview",
             "keywords": [
+                " Naruto ",
                 "naruto"
             ],
-            "isReleased": true
+            "isReleased": true,
+            "status": "ended",
+            "runtime": 110,
+            "firstAirDate": "2004-07-17",
+            "originCountries": ["JP"]
         }`)))
 
 		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
@@ -182,11 +391,23 @@ var _ = Describe("[handler.create-movie.go]", func() {
 				"Kind":             Equal("movie"),
 				"OriginalLanguage": Equal("ja"),
 				"OriginalTitle":    Equal("Naruto - Title"),
+				"Slug":             Equal("naruto-title-2004-2"),
 				"OriginalOverview": PointTo(Equal("Naruto - Overview")),
 				"Keywords":         Equal([]string{"naruto"}),
+				"Tagline":          BeNil(),
 				"IsReleased":       Equal(true),
+				"Status":           PointTo(Equal("ended")),
+				"Runtime":          PointTo(Equal(110)),
+				"FirstAirDate":     PointTo(Equal("2004-07-17")),
+				"LastAirDate":      BeNil(),
+				"ReleaseYear":      PointTo(Equal(2004)),
+				"Homepage":         BeNil(),
+				"OriginCountries":  Equal([]string{"JP"}),
 				"CreatedAt":        BeTemporally("~", time.Now(), time.Minute),
 				"UpdatedAt":        BeTemporally("~", time.Now(), time.Minute),
+				"Translations":     BeNil(),
+				"Seasons":          BeNil(),
+				"Episodes":         BeNil(),
 			}),
 			"Pagination": BeNil(),
 			"Timestamp":  BeTemporally("~", time.Now(), time.Minute),
@@ -0,0 +1,130 @@
+package showmgt_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.create-video.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewCreateVideoHandler(showmgt.CreateVideoHandlerParams{
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Config:              config,
+					Validator:           core.NewValidator(core.NewUniversalTranslator()),
+					UniversalTranslator: core.NewUniversalTranslator(),
+				}),
+			}
+		})
+	})
+
+	It("should return 400 if the host of a self-hosted video is not allowed", func() {
+		config.EXPECT().GetVideoConfig().Return(&core.VideoConfig{AllowedHosts: []string{"videos.example.com"}})
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows/0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e/videos",
+			bytes.NewReader([]byte(`{"site":"url","key":"https://evil.example.com/op.mp4","language":"en","type":"trailer"}`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		Expect(response.MessageID).To(Equal("E-0011"))
+	})
+
+	It("should return 404 if the episode belongs to another show", func() {
+		config.EXPECT().GetVideoConfig().Return(&core.VideoConfig{})
+
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT "id" FROM "public"."shows" WHERE id = \$1`).
+			WithArgs("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"))
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."episodes" WHERE id = \$1 AND show_id = \$2`).
+			WithArgs("0192f5a4-7a5e-7c6a-9d1e-000000000001", "0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDB.ExpectRollback()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows/0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e/videos",
+			bytes.NewReader([]byte(`{"episodeId":"0192f5a4-7a5e-7c6a-9d1e-000000000001",`+
+				`"site":"youtube","key":"dQw4w9WgXcQ","language":"en","type":"clip"}`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusNotFound))
+		Expect(response.MessageID).To(Equal("E-0013"))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should create the video and return its URL", func() {
+		config.EXPECT().GetVideoConfig().Return(&core.VideoConfig{})
+
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT "id" FROM "public"."shows" WHERE id = \$1`).
+			WithArgs("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"))
+		mockedDB.ExpectExec(`INSERT INTO "public"."videos"`).
+			WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, testutils.AnyTimeArg{},
+				"0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", nil, "youtube", "dQw4w9WgXcQ", "Official Trailer", "ja", "trailer", true).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectCommit()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/shows/0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e/videos",
+			bytes.NewReader([]byte(`{"site":"youtube","key":"dQw4w9WgXcQ","name":" Official Trailer ",`+
+				`"language":"ja","type":"trailer","isOfficial":true}`)))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[showmgt.VideoDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusCreated))
+		Expect(response.Data).To(MatchFields(IgnoreExtras, Fields{
+			"ShowID":     Equal(uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")),
+			"Name":       Equal("Official Trailer"),
+			"URL":        Equal("https://www.youtube.com/watch?v=dQw4w9WgXcQ"),
+			"IsOfficial": BeTrue(),
+			"CreatedAt":  BeTemporally("~", time.Now(), time.Minute),
+		}))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,85 @@
+package showmgt_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.get-data-quality-report.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		params := showmgt.GetDataQualityReportHandlerParams{
+			Logger: core.NewNoopLogger(),
+			DB:     db,
+		}
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewGetDataQualityReportHandler(params),
+				showmgt.NewExportDataQualityReportHandler(params),
+			}
+		})
+	})
+
+	DescribeTable("should return 403 to users who are not admins",
+		func(target string) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, target, nil)
+			router.ServeHTTP(recorder, testutils.WithFakeJWT(request, func(c *core.JWTCustomClaims) {
+				c.Roles = []string{core.ModeratorRole}
+			}))
+
+			var response core.Response[any]
+			_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+			Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+			Expect(response.MessageID).To(Equal("E-0040"))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		},
+		Entry("when getting the report", "/api/v1/reports/data-quality"),
+		Entry("when exporting the report", "/api/v1/reports/data-quality.csv"),
+	)
+
+	It("should let admins get the report", func() {
+		mockedDB.ExpectQuery(`SELECT`).WillReturnError(errors.New("the database is down"))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/reports/data-quality", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request, func(c *core.JWTCustomClaims) {
+			c.Roles = []string{core.AdminRole}
+		}))
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusInternalServerError))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,89 @@
+package showmgt_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.get-public-show.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewGetPublicShowHandler(showmgt.GetPublicShowHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+			}
+		})
+	})
+
+	It("should return a released show by its ID, without authentication", func() {
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE is_released = \$1 AND id = \$2`).
+			WithArgs(true, showID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "original_title", "is_released"}).
+				AddRow(showID, "tv", "ワンピース", true))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_translations" WHERE "show_translations"."show_id" = \$1`).
+			WithArgs(showID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "show_id", "locale", "title"}).
+				AddRow("0192f5a4-7a5e-7c6a-9d1e-000000000001", showID, "en", "One Piece"))
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/public/shows/"+showID.String(), nil))
+
+		var response core.Response[showmgt.ShowDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(response.Data.OriginalTitle).To(Equal("ワンピース"))
+		Expect(*response.Data.Translations).To(HaveLen(1))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should return 404 when no released show has the slug", func() {
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE is_released = \$1 AND slug = \$2`).
+			WithArgs(true, "one-piece", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/public/shows/one-piece", nil))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusNotFound))
+		Expect(response.MessageID).To(Equal(core.MsgShowNotFound))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,162 @@
+package showmgt_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.get-shows-feed.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	createdAt := time.Date(2024, time.October, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2024, time.October, 2, 10, 0, 0, 0, time.UTC)
+
+	expectShowsQueryWithFirstAirDate := func(firstAirDate any) {
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE is_released = \$1 AND kind = \$2`).
+			WithArgs(true, "tv", 50).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "created_at", "updated_at", "kind", "original_language", "original_title",
+				"original_overview", "keywords", "is_released", "first_air_date",
+			}).AddRow(
+				"0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", createdAt, updatedAt, "tv", "ja", "ワンピース",
+				nil, `{"pirate"}`, true, firstAirDate,
+			))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_translations" WHERE "show_translations"."show_id" = \$1`).
+			WithArgs("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e").
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "created_at", "updated_at", "show_id", "locale", "title", "overview",
+			}).AddRow(
+				"0192f5a4-7a5e-7c6a-9d1e-000000000001", createdAt, createdAt,
+				"0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", "en", "One Piece", "Pirates",
+			))
+	}
+
+	expectShowsQuery := func() {
+		expectShowsQueryWithFirstAirDate(nil)
+	}
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		params := showmgt.GetShowsFeedHandlerParams{
+			Logger: core.NewNoopLogger(),
+			DB:     db,
+		}
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewGetShowsAtomFeedHandler(params),
+				showmgt.NewGetShowsRSSFeedHandler(params),
+			}
+		})
+	})
+
+	It("should render an Atom feed using the requested locale", func() {
+		expectShowsQuery()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/feeds/shows.atom?kind=tv&lang=en", nil)
+		router.ServeHTTP(recorder, request)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Content-Type", "application/atom+xml; charset=utf-8"))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Last-Modified", "Wed, 02 Oct 2024 10:00:00 GMT"))
+		Expect(recorder.Header().Get("ETag")).NotTo(BeEmpty())
+		Expect(recorder.Body.String()).To(SatisfyAll(
+			ContainSubstring(`<feed xmlns="http://www.w3.org/2005/Atom">`),
+			ContainSubstring(`<author>
+    <name>Wano Island</name>
+  </author>`),
+			ContainSubstring(`<title>One Piece</title>`),
+			ContainSubstring(`<summary>Pirates</summary>`),
+			ContainSubstring(`<category term="pirate"></category>`),
+			ContainSubstring(`<link rel="alternate" href="http://example.com/api/v1/public/shows/0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"></link>`),
+		))
+	})
+
+	It("should render an RSS feed with the original title when there is no translation", func() {
+		expectShowsQuery()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/feeds/shows.rss?kind=tv&lang=vi", nil)
+		router.ServeHTTP(recorder, request)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Content-Type", "application/rss+xml; charset=utf-8"))
+		Expect(recorder.Body.String()).To(SatisfyAll(
+			ContainSubstring(`<rss version="2.0">`),
+			ContainSubstring(`<title>ワンピース</title>`),
+			ContainSubstring(`<language>vi</language>`),
+			ContainSubstring(`<pubDate>Tue, 01 Oct 2024 10:00:00 +0000</pubDate>`),
+		))
+	})
+
+	It("should publish the shows when they were added to the catalog, not on their first air date", func() {
+		expectShowsQueryWithFirstAirDate(time.Date(1999, time.October, 20, 0, 0, 0, 0, time.UTC))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/feeds/shows.atom?kind=tv", nil)
+		router.ServeHTTP(recorder, request)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(recorder).To(HaveHTTPHeaderWithValue("Last-Modified", "Wed, 02 Oct 2024 10:00:00 GMT"))
+		Expect(recorder.Body.String()).To(SatisfyAll(
+			ContainSubstring(`<published>2024-10-01T10:00:00Z</published>`),
+			Not(ContainSubstring(`1999-10-20`)),
+		))
+	})
+
+	It("should return 304 when the client already has the feed", func() {
+		expectShowsQuery()
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/feeds/shows.atom?kind=tv", nil))
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+
+		expectShowsQuery()
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/feeds/shows.atom?kind=tv", nil)
+		request.Header.Set("If-None-Match", recorder.Header().Get("ETag"))
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusNotModified))
+		Expect(recorder.Body.String()).To(BeEmpty())
+	})
+
+	It("should return 304 when the feed has not been modified since the given date", func() {
+		expectShowsQuery()
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/feeds/shows.atom?kind=tv", nil)
+		request.Header.Set("If-Modified-Since", "Wed, 02 Oct 2024 10:00:00 GMT")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusNotModified))
+	})
+})
@@ -0,0 +1,279 @@
+package showmgt_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"gorm.io/gorm"
+)
+
+// showListResponse is the response of the show list with its facet buckets.
+type showListResponse struct {
+	core.Response[[]showmgt.ShowDTO]
+	Facets map[showmgt.ShowFacet][]showmgt.FacetBucket `json:"facets"`
+}
+
+var _ = Describe("[handler.get-shows.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	createdAt := time.Date(2024, time.October, 1, 10, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewGetShowsHandler(showmgt.GetShowsHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+			}
+		})
+	})
+
+	// expectSmallTableCount expects the count of the shows table when it is estimated too small
+	// for its estimate to be used.
+	expectSmallTableCount := func(total int) {
+		mockedDB.ExpectQuery(`SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass\(\$1\)`).
+			WithArgs("public.shows").
+			WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(-1))
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM \(SELECT 1 FROM "public"."shows" LIMIT \$1\) AS capped_rows`).
+			WithArgs(core.MaxExactCount + 1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(total))
+	}
+
+	It("should return the filtered shows with facets computed without their own filter", func() {
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM \(SELECT 1 FROM "public"."shows" WHERE kind = \$1 AND is_released = \$2 LIMIT \$3\) AS capped_rows`).
+			WithArgs("tv", true, core.MaxExactCount+1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE kind = \$1 AND is_released = \$2`).
+			WithArgs("tv", true, 10).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "created_at", "updated_at", "kind", "original_language", "original_title", "keywords", "is_released",
+			}).AddRow(
+				"0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", createdAt, createdAt, "tv", "ja", "One Piece", `{"pirate"}`, true,
+			))
+		mockedDB.ExpectQuery(`SELECT value, COUNT\(\*\) AS count FROM \(SELECT kind AS value FROM "public"."shows" `+
+			`WHERE is_released = \$1\) AS facet_values GROUP BY "value" ORDER BY count DESC, value LIMIT \$2`).
+			WithArgs(true, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow("tv", 1).AddRow("movie", 3))
+		mockedDB.ExpectQuery(`SELECT value, COUNT\(\*\) AS count FROM \(SELECT CASE WHEN is_released `+
+			`THEN 'released' ELSE 'unreleased' END AS value FROM "public"."shows" WHERE kind = \$1\) AS facet_values`).
+			WithArgs("tv", 20).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow("released", 1).AddRow("unreleased", 2))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows?kind=tv&released=true&facets=kind,released,genre", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response showListResponse
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(response.Data).To(HaveLen(1))
+		Expect(response.Pagination.TotalRows).To(BeEquivalentTo(1))
+		Expect(response.Pagination.IsExact).To(BeTrue())
+		Expect(response.Facets).To(Equal(map[showmgt.ShowFacet][]showmgt.FacetBucket{
+			showmgt.KindFacet: {
+				{Value: "tv", Count: 1},
+				{Value: "movie", Count: 3},
+			},
+			showmgt.ReleasedFacet: {
+				{Value: "released", Count: 1},
+				{Value: "unreleased", Count: 2},
+			},
+		}))
+	})
+
+	It("should filter by release decade and count the decades of the shows with a first air date", func() {
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM \(SELECT 1 FROM "public"."shows" WHERE first_air_date >= make_date\(\$1, 1, 1\) `+
+			`AND first_air_date < make_date\(\$2, 1, 1\) LIMIT \$3\) AS capped_rows`).
+			WithArgs(1990, 2000, core.MaxExactCount+1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE first_air_date >= make_date\(\$1, 1, 1\)`).
+			WithArgs(1990, 2000, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockedDB.ExpectQuery(`SELECT value, COUNT\(\*\) AS count FROM \(SELECT to_char\(date_trunc\('decade', first_air_date\), 'YYYY'\) AS value ` +
+			`FROM "public"."shows" WHERE first_air_date IS NOT NULL\) AS facet_values GROUP BY "value" ORDER BY count DESC, value LIMIT \$1`).
+			WithArgs(20).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow("1990", 2).AddRow("2000", 1))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows?decade=1999&facets=decade", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response showListResponse
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(response.Data).To(BeEmpty())
+		Expect(response.Facets).To(Equal(map[showmgt.ShowFacet][]showmgt.FacetBucket{
+			showmgt.DecadeFacet: {
+				{Value: "1990", Count: 2},
+				{Value: "2000", Count: 1},
+			},
+		}))
+	})
+
+	It("should return the shows as a list when no facets are requested", func() {
+		expectSmallTableCount(1)
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "original_title"}).
+				AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", "tv", "One Piece"))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response map[string]any
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(response["data"]).To(HaveLen(1))
+		Expect(response).ToNot(HaveKey("facets"))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should expand the included relations with one query per relation for the whole page", func() {
+		firstShowID := "0192f5a4-7a5e-7c6a-9d1e-000000000001"
+		secondShowID := "0192f5a4-7a5e-7c6a-9d1e-000000000002"
+		seasonID := "0192f5a4-7a5e-7c6a-9d1e-000000000003"
+
+		expectSmallTableCount(2)
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "original_title"}).
+				AddRow(firstShowID, "tv", "One Piece").
+				AddRow(secondShowID, "movie", "One Piece Film: Red"))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."seasons" WHERE "seasons"."show_id" IN \(\$1,\$2\) ORDER BY "order"`).
+			WithArgs(firstShowID, secondShowID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "show_id", "order"}).AddRow(seasonID, firstShowID, 1))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."season_translations" WHERE "season_translations"."season_id" = \$1 ORDER BY locale`).
+			WithArgs(seasonID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "locale", "title", "overview"}).
+				AddRow("0192f5a4-7a5e-7c6a-9d1e-000000000004", seasonID, "en", "East Blue", "Luffy sets sail."))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows?include=seasons.translations,credits", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[[]map[string]any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		Expect(response.Data).To(HaveLen(2))
+		Expect(response.Data[0]["seasons"]).To(ConsistOf(MatchKeys(IgnoreExtras, Keys{
+			"order": BeEquivalentTo(1),
+			"translations": ConsistOf(map[string]any{
+				"locale": "en", "title": "East Blue", "overview": "Luffy sets sail.",
+			}),
+		})))
+		Expect(response.Data[1]["seasons"]).To(BeEmpty())
+		Expect(response.Data[1]).ToNot(HaveKey("translations"))
+	})
+
+	It("should estimate the total of large unfiltered lists from the table statistics", func() {
+		mockedDB.ExpectQuery(`SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass\(\$1\)`).
+			WithArgs("public.shows").
+			WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(52000))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[[]showmgt.ShowDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		Expect(*response.Pagination).To(Equal(core.Pagination{
+			Page: 1, PageSize: 10, TotalRows: 52000, TotalPages: 5200, IsExact: false,
+		}))
+	})
+
+	It("should cap the count of filtered lists", func() {
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM \(SELECT 1 FROM "public"."shows" WHERE kind = \$1 LIMIT \$2\) AS capped_rows`).
+			WithArgs("tv", core.MaxExactCount+1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(core.MaxExactCount + 1))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE kind = \$1`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows?kind=tv", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[[]showmgt.ShowDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		Expect(response.Pagination.TotalRows).To(BeEquivalentTo(core.MaxExactCount))
+		Expect(response.Pagination.IsExact).To(BeFalse())
+	})
+
+	DescribeTable("should count as requested with the count query parameter",
+		func(mode string, expectCount func(), expectedPagination core.Pagination) {
+			expectCount()
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/shows?count="+mode, nil)
+			router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+			var response core.Response[[]showmgt.ShowDTO]
+			_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+			Expect(*response.Pagination).To(Equal(expectedPagination))
+		},
+		Entry("exact", "exact", func() {
+			mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."shows"$`).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(52000))
+		}, core.Pagination{Page: 1, PageSize: 10, TotalRows: 52000, TotalPages: 5200, IsExact: true}),
+		Entry("none", "none", func() {}, core.Pagination{Page: 1, PageSize: 10}),
+	)
+
+	It("should order the shows by popularity when requested", func() {
+		expectSmallTableCount(0)
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" ORDER BY \(SELECT popularity FROM public.show_popularities ` +
+			`WHERE show_popularities.show_id = shows.id\) DESC NULLS LAST,created_at DESC`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows?sort=popularity", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
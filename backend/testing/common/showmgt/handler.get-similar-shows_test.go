@@ -0,0 +1,135 @@
+package showmgt_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.get-similar-shows.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	showID := "0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"
+	similarShowID := "0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6f"
+	createdAt := time.Date(2024, time.October, 1, 10, 0, 0, 0, time.UTC)
+
+	expectSimilarShows := func(countQuery, findQuery string, args ...driver.Value) {
+		mockedDB.ExpectQuery(`SELECT "id" FROM "public"."shows" WHERE id = \$1`).
+			WithArgs(showID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(showID))
+		mockedDB.ExpectQuery(countQuery).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDB.ExpectQuery(findQuery).
+			WithArgs(append(args, 10)...).
+			WillReturnRows(sqlmock.NewRows([]string{"show_id", "similar_show_id", "score"}).
+				AddRow(showID, similarShowID, 0.75))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE "shows"."id" = \$1`).
+			WithArgs(similarShowID).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "created_at", "updated_at", "kind", "original_language", "original_title", "is_released",
+			}).AddRow(similarShowID, createdAt, createdAt, "tv", "ja", "ナルト", true))
+	}
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewGetSimilarShowsHandler(showmgt.GetSimilarShowsHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+			}
+		})
+	})
+
+	It("should return the similar shows to anonymous users", func() {
+		expectSimilarShows(
+			`SELECT count\(\*\) FROM "public"."show_similarities" WHERE show_id = \$1$`,
+			`SELECT \* FROM "public"."show_similarities" WHERE show_id = \$1 ORDER BY score DESC LIMIT \$2$`,
+			showID,
+		)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows/"+showID+"/similar", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		var response struct {
+			Data []struct {
+				ID    string  `json:"id"`
+				Score float64 `json:"score"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Data).To(HaveLen(1))
+		Expect(response.Data[0].ID).To(Equal(similarShowID))
+		Expect(response.Data[0].Score).To(Equal(0.75))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should leave out the shows already in the lists of the logged in user", func() {
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		expectSimilarShows(
+			`SELECT count\(\*\) FROM "public"."show_similarities" WHERE show_id = \$1 AND similar_show_id NOT IN `+
+				`\(SELECT list_items.show_id FROM "public"."list_items" JOIN public.lists ON lists.id = list_items.list_id `+
+				`WHERE lists.owner_id = \$2\)`,
+			`SELECT \* FROM "public"."show_similarities" WHERE show_id = \$1 AND similar_show_id NOT IN `+
+				`\(SELECT list_items.show_id FROM "public"."list_items" JOIN public.lists ON lists.id = list_items.list_id `+
+				`WHERE lists.owner_id = \$2\) ORDER BY score DESC LIMIT \$3`,
+			showID, uuid.Nil,
+		)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows/"+showID+"/similar", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should serve anonymous users when the access token is invalid", func() {
+		expectSimilarShows(
+			`SELECT count\(\*\) FROM "public"."show_similarities" WHERE show_id = \$1$`,
+			`SELECT \* FROM "public"."show_similarities" WHERE show_id = \$1 ORDER BY score DESC LIMIT \$2$`,
+			showID,
+		)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows/"+showID+"/similar", nil)
+		request.Header.Add(core.AuthorizationHeader, "Bearer invalid")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,78 @@
+package showmgt_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.get-trending-shows.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewGetTrendingShowsHandler(showmgt.GetTrendingShowsHandlerParams{
+					Logger: core.NewNoopLogger(),
+					DB:     db,
+				}),
+			}
+		})
+	})
+
+	DescribeTable("should rank the shows by the score of the requested window",
+		func(query string, column string, expectedScore float64) {
+			mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."show_popularities" WHERE ` + column + ` > 0`).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_popularities" WHERE ` + column + ` > 0 ORDER BY ` + column + ` DESC`).
+				WillReturnRows(sqlmock.NewRows([]string{"show_id", "popularity", "trending_day", "trending_week"}).
+					AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", 40.5, 3.25, 12.75))
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE "shows"."id" = \$1`).
+				WithArgs("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "original_title"}).
+					AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", "tv", "One Piece"))
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/shows/trending"+query, nil)
+			router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+			var response core.Response[[]showmgt.TrendingShowDTO]
+			_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			Expect(response.Data).To(HaveLen(1))
+			Expect(response.Data[0].OriginalTitle).To(Equal("One Piece"))
+			Expect(response.Data[0].Score).To(Equal(expectedScore))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		},
+		Entry("with the day window by default", "", "trending_day", 3.25),
+		Entry("with the week window", "?window=week", "trending_week", 12.75),
+	)
+})
@@ -0,0 +1,92 @@
+package showmgt_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.suggest-shows.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewSuggestShowsHandler(showmgt.SuggestShowsHandlerParams{
+					Logger:      core.NewNoopLogger(),
+					DB:          db,
+					SearchIndex: showmgt.NewPostgresSearchIndex(db),
+				}),
+			}
+		})
+	})
+
+	It("should return the matched locale and title of each suggestion", func() {
+		mockedDB.ExpectQuery(`SELECT matches.show_id, matches.locale, matches.title, matches.score`).
+			WithArgs("one pece", "one pece", "one pece", "one pece", 5).
+			WillReturnRows(sqlmock.NewRows([]string{"show_id", "locale", "title", "score"}).
+				AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", "en", "One Piece", 0.62))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE id IN \(\$1\)`).
+			WithArgs("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "kind", "original_title"}).
+				AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", "wanpisu", "tv", "ワンピース"))
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows/suggest?q=+one+pece+&limit=5", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[[]showmgt.ShowSuggestionDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(response.Data).To(Equal([]showmgt.ShowSuggestionDTO{{
+			ID:            uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"),
+			Slug:          "wanpisu",
+			Kind:          "tv",
+			OriginalTitle: "ワンピース",
+			MatchedLocale: "en",
+			MatchedTitle:  "One Piece",
+			Score:         0.62,
+		}}))
+	})
+
+	It("should not query the database when the search text is empty", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/shows/suggest?q=+", nil)
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[[]showmgt.ShowSuggestionDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		Expect(response.Data).To(BeEmpty())
+	})
+})
@@ -0,0 +1,93 @@
+package showmgt_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.update-show.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	requestBody := []byte(`
+    {
+        "kind": "tv",
+        "originalLanguage": "ja",
+        "originalTitle": "One Piece",
+        "status": "returning",
+        "homepage": "https://example.com/one-piece"
+    }`)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewUpdateShowHandler(showmgt.UpdateShowHandlerParams{
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Validator:           core.NewValidator(core.NewUniversalTranslator()),
+					UniversalTranslator: core.NewUniversalTranslator(),
+				}),
+			}
+		})
+	})
+
+	It("should return 404 if the ID is not a valid show ID", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPut, "/api/v1/shows/one-piece", bytes.NewReader(requestBody))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusNotFound))
+		Expect(response.MessageID).To(Equal("E-0008"))
+	})
+
+	It("should return 404 if the show does not exist", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."shows" WHERE id = \$1`).
+			WithArgs("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockedDB.ExpectRollback()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPut, "/api/v1/shows/0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e",
+			bytes.NewReader(requestBody))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusNotFound))
+		Expect(response.MessageID).To(Equal("E-0008"))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,108 @@
+package showmgt_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/console/modules/httpsrv"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[handler.upload-subtitles.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		router   http.Handler
+		config   *mockcore.MockAppConfig
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		config = mockcore.NewMockAppConfig(GinkgoT())
+		config.EXPECT().GetAppVersion().Return("1.0.0")
+		config.EXPECT().GetRevision().Return("testing")
+		config.EXPECT().GetMode().Return(core.TestingMode)
+		config.EXPECT().IsTesting().Return(true)
+		config.EXPECT().GetJWTConfig().Return(testutils.GetJWTConfig())
+		config.EXPECT().GetCorsConfig().Return(&core.CorsConfig{})
+
+		router = testutils.CreateRouter(func(rp *httpsrv.RouteParams) {
+			rp.Config = config
+			rp.Routes = []core.HTTPRoute{
+				showmgt.NewUploadSubtitlesHandler(showmgt.UploadSubtitlesHandlerParams{
+					Logger:              core.NewNoopLogger(),
+					DB:                  db,
+					Validator:           core.NewValidator(core.NewUniversalTranslator()),
+					UniversalTranslator: core.NewUniversalTranslator(),
+				}),
+			}
+		})
+	})
+
+	It("should return the problems of an invalid file", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPut, "/api/v1/shows/0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e/subtitles/en",
+			strings.NewReader("1\n00:00:02,000 --> 00:00:01,000\nBackwards\n"))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[showmgt.SubtitleProblems]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		Expect(response.MessageID).To(Equal("E-0031"))
+		Expect(response.Data).To(Equal(showmgt.SubtitleProblems{{Line: 2, Message: "The cue ends before it starts"}}))
+	})
+
+	It("should return 400 if the language is invalid", func() {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPut, "/api/v1/shows/0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e/subtitles/english!",
+			strings.NewReader("1\n00:00:01,000 --> 00:00:02,000\nHello\n"))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[any]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		Expect(response.MessageID).To(Equal("E-0005"))
+	})
+
+	It("should store an SRT file as normalized WebVTT", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT "id" FROM "public"."shows" WHERE id = \$1`).
+			WithArgs("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e"))
+		mockedDB.ExpectQuery(`SELECT "id","created_at" FROM "public"."subtitles" WHERE \(show_id = \$1 AND language = \$2\) AND episode_id IS NULL LIMIT \$3`).
+			WithArgs("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", "pt-BR", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+		mockedDB.ExpectExec(`INSERT INTO "public"."subtitles"`).
+			WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, testutils.AnyTimeArg{},
+				"0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", nil, "pt-BR", 1,
+				"WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nOlá\n\n").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDB.ExpectCommit()
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPut, "/api/v1/shows/0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e/subtitles/pt-br",
+			strings.NewReader("1\r\n00:00:01,000 --> 00:00:02,000\r\nOlá\r\n"))
+		router.ServeHTTP(recorder, testutils.WithFakeJWT(request))
+
+		var response core.Response[showmgt.SubtitleDTO]
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		Expect(recorder).To(HaveHTTPStatus(http.StatusCreated))
+		Expect(response.Data.Language).To(Equal("pt-BR"))
+		Expect(response.Data.CueCount).To(Equal(1))
+		Expect(response.Data.EpisodeID).To(BeNil())
+	})
+})
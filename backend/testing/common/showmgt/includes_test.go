@@ -0,0 +1,29 @@
+package showmgt_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/showmgt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[includes.go]", func() {
+	DescribeTable("GetShowIncludes",
+		func(query string, expectedIncludes []showmgt.ShowInclude) {
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/shows?include="+query, nil)
+
+			Expect(showmgt.GetShowIncludes(request)).To(Equal(expectedIncludes))
+		},
+		Entry("should ignore an empty parameter", "", []showmgt.ShowInclude{}),
+		Entry("should read the supported includes", "translations,%20episodes",
+			[]showmgt.ShowInclude{showmgt.EpisodesShowInclude, showmgt.TranslationsShowInclude}),
+		Entry("should expand the parents of nested includes", "seasons.translations,seasons",
+			[]showmgt.ShowInclude{showmgt.SeasonsShowInclude, showmgt.SeasonTranslationsShowInclude}),
+		Entry("should ignore unknown includes", "credits,seasons.episodes,videos",
+			[]showmgt.ShowInclude{}),
+		Entry("should ignore includes nested too deeply", "seasons.translations.show",
+			[]showmgt.ShowInclude{}),
+	)
+})
@@ -0,0 +1,63 @@
+package showmgt_test
+
+import (
+	"context"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[job.aired-episodes.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		job      core.ScheduledJob
+	)
+
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+	airDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		db, mockedDB = testutils.CreateTestDBInstance()
+		job = showmgt.NewAiredEpisodesJob(showmgt.AiredEpisodesJobParams{
+			Logger: core.NewNoopLogger(),
+			DB:     db,
+		})
+	})
+
+	It("should publish the air dates which have been reached since they were set", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(hashtext\(\$1\)\)`).
+			WithArgs("aired-episodes").
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+		mockedDB.ExpectQuery(`UPDATE "public"."shows" SET "announced_air_date"=last_air_date ` +
+			`WHERE last_air_date <= \$1 AND \(announced_air_date IS NULL OR last_air_date > announced_air_date\) ` +
+			`RETURNING "id","last_air_date"`).
+			WithArgs(testutils.AnyTimeArg{}).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "last_air_date"}).AddRow(showID, airDate))
+		mockedDB.ExpectExec(`INSERT INTO "public"."catalog_events" \("id","created_at","type","show_id","occurred_at","air_date"\)`).
+			WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, "new_episode", showID, testutils.AnyTimeArg{}, airDate).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectCommit()
+
+		Expect(job.Run(context.Background())).To(Succeed())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should not publish anything when another replica holds the lock", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT pg_try_advisory_xact_lock`).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+		mockedDB.ExpectCommit()
+
+		Expect(job.Run(context.Background())).To(Succeed())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -0,0 +1,87 @@
+package showmgt_test
+
+import (
+	"context"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+// recordingCatalogEventListener records the catalog events it receives.
+type recordingCatalogEventListener struct {
+	events []showmgt.CatalogEvent
+}
+
+func (l *recordingCatalogEventListener) Name() string {
+	return "recording"
+}
+
+func (l *recordingCatalogEventListener) OnCatalogEvents(_ context.Context, events []showmgt.CatalogEvent) error {
+	l.events = append(l.events, events...)
+
+	return nil
+}
+
+var _ = Describe("[job.catalog-events.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+		listener *recordingCatalogEventListener
+		job      core.ScheduledJob
+	)
+
+	eventID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000001")
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+	occurredAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		db, mockedDB = testutils.CreateTestDBInstance()
+		listener = &recordingCatalogEventListener{}
+		job = showmgt.NewCatalogEventsJob(showmgt.CatalogEventsJobParams{
+			DB: db,
+			Events: showmgt.NewCatalogEvents(showmgt.CatalogEventsParams{
+				Logger:    core.NewNoopLogger(),
+				Listeners: []showmgt.CatalogEventListener{listener},
+			}),
+		})
+	})
+
+	It("should deliver the published events to the listeners and delete them", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(hashtext\(\$1\)\)`).
+			WithArgs("catalog-events").
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."catalog_events" ORDER BY id LIMIT \$1`).
+			WithArgs(500).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "type", "show_id", "occurred_at"}).
+				AddRow(eventID, "show_released", showID, occurredAt))
+		mockedDB.ExpectExec(`DELETE FROM "public"."catalog_events" WHERE "catalog_events"."id" = \$1`).
+			WithArgs(eventID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDB.ExpectCommit()
+
+		Expect(job.Run(context.Background())).To(Succeed())
+		Expect(listener.events).To(Equal([]showmgt.CatalogEvent{
+			{Type: showmgt.ShowReleasedCatalogEvent, ShowID: showID, OccurredAt: occurredAt},
+		}))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should not deliver anything when another replica holds the lock", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT pg_try_advisory_xact_lock`).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+		mockedDB.ExpectCommit()
+
+		Expect(job.Run(context.Background())).To(Succeed())
+		Expect(listener.events).To(BeEmpty())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
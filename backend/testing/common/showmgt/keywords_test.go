@@ -0,0 +1,105 @@
+package showmgt_test
+
+import (
+	"errors"
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[keywords.go]", func() {
+	DescribeTable("NormalizeKeyword",
+		func(value string, expectedKeyword string) {
+			Expect(showmgt.NormalizeKeyword(value)).To(Equal(expectedKeyword))
+		},
+		Entry("should lowercase letters", "Anime", "anime"),
+		Entry("should collapse whitespace", "  Anime \t Movie ", "anime movie"),
+		Entry("should fold full-width characters", "ＡＮＩＭＥ", "anime"),
+		Entry("should fold half-width katakana", "ｱﾆﾒ", "アニメ"),
+	)
+
+	It("should drop empty and duplicated keywords", func() {
+		Expect(showmgt.NormalizeKeywords([]string{"Anime", " ", "anime", "Pirate"})).
+			To(Equal([]string{"anime", "pirate"}))
+	})
+
+	Context("when resolving keywords", func() {
+		var (
+			db       *gorm.DB
+			mockedDB sqlmock.Sqlmock
+		)
+
+		BeforeEach(func() {
+			db, mockedDB = testutils.CreateTestDBInstance()
+		})
+
+		expectMatches := func() {
+			mockedDB.ExpectQuery(`SELECT name AS value, id AS keyword_id, name FROM public.keywords`).
+				WithArgs("anime", "アニメ", "pirate", "anime", "アニメ", "pirate").
+				WillReturnRows(sqlmock.NewRows([]string{"value", "keyword_id", "name"}).
+					AddRow("anime", "0192f5a4-7a5e-7c6a-9d1e-000000000001", "anime").
+					AddRow("アニメ", "0192f5a4-7a5e-7c6a-9d1e-000000000001", "anime"))
+		}
+
+		It("should map synonyms to canonical keywords and reject unknown keywords", func() {
+			expectMatches()
+
+			keywords, err := showmgt.ResolveKeywords(db, []string{"Anime", "アニメ", "Pirate"})
+
+			var unknownKeywordsErr *showmgt.UnknownKeywordsError
+			Expect(errors.As(err, &unknownKeywordsErr)).To(BeTrue())
+			Expect(unknownKeywordsErr.Values).To(Equal([]string{"pirate"}))
+			Expect(keywords).To(BeNil())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should create unknown keywords when importing values, reusing the ones created concurrently", func() {
+			expectMatches()
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`INSERT INTO "public"."keywords" .* ON CONFLICT DO NOTHING`).
+				WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, testutils.AnyTimeArg{}, "pirate").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mockedDB.ExpectCommit()
+			mockedDB.ExpectQuery(`SELECT name AS value, id AS keyword_id, name FROM public.keywords`).
+				WithArgs("anime", "アニメ", "pirate", "anime", "アニメ", "pirate").
+				WillReturnRows(sqlmock.NewRows([]string{"value", "keyword_id", "name"}).
+					AddRow("anime", "0192f5a4-7a5e-7c6a-9d1e-000000000001", "anime").
+					AddRow("アニメ", "0192f5a4-7a5e-7c6a-9d1e-000000000001", "anime").
+					AddRow("pirate", "0192f5a4-7a5e-7c6a-9d1e-000000000002", "pirate"))
+
+			keywords, err := showmgt.ResolveOrCreateKeywords(db, []string{"Anime", "アニメ", "Pirate"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(showmgt.KeywordNames(keywords)).To(BeEquivalentTo([]string{"anime", "pirate"}))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should not query the database when there are no keywords", func() {
+			keywords, err := showmgt.ResolveKeywords(db, []string{" "})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keywords).To(BeEmpty())
+		})
+	})
+
+	Context("when creating a keyword", func() {
+		It("should report a conflict when the name has been taken concurrently", func() {
+			db, mockedDB := testutils.CreateTestDBInstance()
+			mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."keywords"`).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`INSERT INTO "public"."keywords" .* ON CONFLICT DO NOTHING`).
+				WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, testutils.AnyTimeArg{}, "anime").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mockedDB.ExpectCommit()
+
+			Expect(showmgt.SaveKeyword(db, &showmgt.KeywordModel{}, &showmgt.KeywordRequestBody{Name: "Anime"})).
+				To(MatchError(showmgt.ErrKeywordConflict))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+})
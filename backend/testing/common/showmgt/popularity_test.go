@@ -0,0 +1,102 @@
+package showmgt_test
+
+import (
+	"context"
+	"errors"
+	"time"
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[popularity.go]", func() {
+	var (
+		db       *gorm.DB
+		mockedDB sqlmock.Sqlmock
+	)
+
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+
+	BeforeEach(func() {
+		db, mockedDB = testutils.CreateTestDBInstance()
+	})
+
+	Context("when recording show activity", func() {
+		var recorder *showmgt.ShowActivityRecorder
+
+		BeforeEach(func() {
+			recorder = showmgt.NewShowActivityRecorder(showmgt.ShowActivityRecorderParams{DB: db})
+		})
+
+		It("should write the buffered counts with a single upsert", func() {
+			recorder.Record(showID, showmgt.ViewShowActivity)
+			recorder.Record(showID, showmgt.ViewShowActivity)
+			recorder.Record(showID, showmgt.ViewShowActivity)
+
+			mockedDB.ExpectExec(`INSERT INTO public.show_activities \(show_id, bucket, kind, count\)\s+SELECT .+\s+`+
+				`FROM \(VALUES \(\$1::uuid, \$2::timestamptz, \$3, \$4::bigint\)\) AS activities \(show_id, bucket, kind, count\)\s+`+
+				`JOIN public.shows ON shows.id = activities.show_id\s+`+
+				`ON CONFLICT \(show_id, bucket, kind\) DO UPDATE SET count = show_activities.count \+ excluded.count`).
+				WithArgs(showID, testutils.AnyTimeArg{}, "view", 3).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			Expect(recorder.Flush(context.Background())).To(Succeed())
+			Expect(recorder.Flush(context.Background())).To(Succeed())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should keep the counts for the next flush when the write fails", func() {
+			recorder.Record(showID, showmgt.ListAddShowActivity)
+
+			mockedDB.ExpectExec(`INSERT INTO public.show_activities`).
+				WithArgs(showID, testutils.AnyTimeArg{}, "list_add", 1).
+				WillReturnError(errors.New("connection lost"))
+
+			Expect(recorder.Flush(context.Background())).NotTo(Succeed())
+
+			recorder.Record(showID, showmgt.ListAddShowActivity)
+
+			mockedDB.ExpectExec(`INSERT INTO public.show_activities`).
+				WithArgs(showID, testutils.AnyTimeArg{}, "list_add", 2).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			Expect(recorder.Flush(context.Background())).To(Succeed())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	It("should replace the scores and prune the activity older than the popularity window", func() {
+		now := time.Date(2024, time.October, 8, 12, 0, 0, 0, time.UTC)
+
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectExec(`DELETE FROM "public"."show_popularities" WHERE 1 = 1`).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mockedDB.ExpectExec(`INSERT INTO public.show_popularities \(show_id, popularity, trending_day, trending_week, computed_at\) ` +
+			`SELECT activities.show_id, COALESCE\(SUM\(.+\) FILTER \(WHERE activities.bucket > .+\), 0\) AS popularity, ` +
+			`.+ FROM \(SELECT .+ FROM "public"."show_activities" ` +
+			`JOIN \(SELECT \* FROM \(VALUES .+\) AS weights \(kind, weight\)\) AS weights ON weights.kind = show_activities.kind ` +
+			`WHERE show_activities.bucket > .+\) AS activities GROUP BY "activities"."show_id"`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockedDB.ExpectExec(`DELETE FROM "public"."show_activities" WHERE bucket <= \$1`).
+			WithArgs(now.Add(-90 * 24 * time.Hour)).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+		mockedDB.ExpectCommit()
+
+		var scoredShows int64
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			scoredShows, err = showmgt.ComputeShowPopularities(tx, now)
+
+			return err
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scoredShows).To(BeEquivalentTo(2))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
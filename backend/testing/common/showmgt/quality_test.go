@@ -0,0 +1,155 @@
+package showmgt_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+)
+
+var _ = Describe("[quality.go]", func() {
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000001")
+	translationID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000002")
+	videoID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000003")
+
+	generatedAt := time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC)
+	overview := "A young pirate sets sail to find the legendary treasure known as the One Piece."
+
+	// newShow returns a show without any data quality issue.
+	newShow := func() showmgt.ShowModel {
+		return showmgt.ShowModel{
+			Model:            core.Model{ID: showID},
+			Kind:             "tv",
+			OriginalLanguage: "ja",
+			OriginalTitle:    "ワンピース",
+			OriginalOverview: lo.ToPtr(overview),
+			Keywords:         pq.StringArray{"pirates"},
+			Translations: []showmgt.ShowTranslationModel{
+				{Model: core.Model{ID: translationID}, ShowID: showID, Locale: "en", Title: "One Piece", Overview: overview},
+			},
+			Seasons: []showmgt.SeasonModel{{ShowID: showID, Order: 1}, {ShowID: showID, Order: 2}},
+		}
+	}
+
+	episodes := []showmgt.EpisodeModel{{ShowID: showID, Order: 1}, {ShowID: showID, Order: 2}}
+	vocabulary := []string{"pirates"}
+
+	// checks returns the check and detail of every issue of the report, the most urgent first.
+	checks := func(report *showmgt.DataQualityReport) []string {
+		result := []string{}
+		for _, group := range report.Groups {
+			for _, issue := range group.Issues {
+				result = append(result, issue.Check+": "+issue.Detail)
+			}
+		}
+
+		return result
+	}
+
+	It("should not report a show without issues", func() {
+		report := showmgt.BuildDataQualityReport([]showmgt.ShowModel{newShow()}, episodes, nil, vocabulary, generatedAt)
+
+		Expect(report.Total).To(BeZero())
+		Expect(report.GeneratedAt).To(Equal(generatedAt))
+		Expect(lo.Map(report.Groups, func(group showmgt.DataQualityGroup, _ int) showmgt.DataQualitySeverity {
+			return group.Severity
+		})).To(Equal(showmgt.DataQualitySeverities))
+	})
+
+	It("should group the issues by severity", func() {
+		show := newShow()
+		show.OriginalLanguage = "not a language"
+		show.OriginalOverview = lo.ToPtr("Pirates.")
+		show.Keywords = append(show.Keywords, "piracy")
+		show.Translations[0].Overview = " "
+		show.Seasons = append(show.Seasons, showmgt.SeasonModel{ShowID: showID, Order: 2})
+
+		report := showmgt.BuildDataQualityReport(
+			[]showmgt.ShowModel{show},
+			append(episodes, showmgt.EpisodeModel{ShowID: showID, Order: 5}),
+			[]showmgt.VideoModel{{Model: core.Model{ID: videoID}, ShowID: showID, Name: "Trailer", Language: "xx_123"}},
+			vocabulary,
+			generatedAt,
+		)
+
+		Expect(report.Total).To(Equal(7))
+		Expect(report.Groups[0].Count).To(Equal(3))
+		Expect(report.Groups[1].Count).To(Equal(2))
+		Expect(report.Groups[2].Count).To(Equal(2))
+		Expect(checks(report)).To(Equal([]string{
+			`invalid_language: The original language "not a language" is invalid`,
+			"duplicate_season_order: The season order 2 is used by 2 seasons",
+			`invalid_language: The video "Trailer" has the invalid language "xx_123"`,
+			`missing_overview: The "en" overview is missing`,
+			"episode_order_gap: The episodes 3 to 4 are missing",
+			"short_overview: The original overview has only 8 characters",
+			`unknown_keyword: The keyword "piracy" is not in the vocabulary`,
+		}))
+
+		Expect(report.Groups[1].Issues[0].EntityKind).To(Equal(showmgt.ShowTranslationDataQualityEntity))
+		Expect(report.Groups[1].Issues[0].EntityID).To(Equal(translationID))
+		Expect(report.Groups[1].Issues[0].Link).To(Equal("/api/v1/shows/" + showID.String()))
+		Expect(report.Groups[0].Issues[2].Link).To(Equal("/api/v1/shows/" + showID.String() + "/videos"))
+	})
+
+	It("should only expect seasons from TV shows", func() {
+		tvShow := newShow()
+		tvShow.Seasons = nil
+		tvShow.Translations = nil
+
+		movie := newShow()
+		movie.Kind = "movie"
+		movie.Seasons = nil
+
+		report := showmgt.BuildDataQualityReport([]showmgt.ShowModel{tvShow, movie}, nil, nil, vocabulary, generatedAt)
+
+		Expect(checks(report)).To(Equal([]string{
+			"no_translations: The show has no translations",
+			`no_seasons: The "tv" show has no seasons`,
+		}))
+	})
+
+	It("should export one CSV line per issue", func() {
+		show := newShow()
+		show.OriginalOverview = nil
+
+		report := showmgt.BuildDataQualityReport([]showmgt.ShowModel{show}, episodes, nil, vocabulary, generatedAt)
+
+		var body bytes.Buffer
+		Expect(report.WriteCSV(&body)).To(Succeed())
+
+		lines := strings.Split(strings.TrimSpace(body.String()), "\n")
+		Expect(lines).To(Equal([]string{
+			"severity,check,entity_kind,entity_id,show_id,show_title,detail,link",
+			"warning,missing_overview,show," + showID.String() + "," + showID.String() +
+				",ワンピース,The original overview is missing,/api/v1/shows/" + showID.String(),
+		}))
+	})
+
+	It("should restore the report from its snapshot", func() {
+		show := newShow()
+		show.OriginalOverview = nil
+		show.Keywords = pq.StringArray{"piracy"}
+
+		report := showmgt.BuildDataQualityReport([]showmgt.ShowModel{show}, episodes, nil, vocabulary, generatedAt)
+
+		snapshot := showmgt.NewDataQualitySnapshot(report)
+		snapshot.ID = uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000004")
+
+		Expect(snapshot.Issues).To(HaveLen(2))
+		Expect(snapshot.Issues[1].Position).To(Equal(1))
+
+		restored := snapshot.Report()
+		Expect(*restored.SnapshotID).To(Equal(snapshot.ID))
+
+		restored.SnapshotID = nil
+		Expect(restored).To(Equal(report))
+	})
+})
@@ -0,0 +1,95 @@
+package showmgt_test
+
+import (
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[relations.go]", func() {
+	showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000001")
+	relatedShowID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000002")
+	otherShowID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000003")
+
+	DescribeTable("NewShowRelation",
+		func(relationType string, expectedShowID uuid.UUID, expectedType string, expectedInverseType string) {
+			relation := showmgt.NewShowRelation(showID, relatedShowID, relationType)
+
+			Expect(relation.ShowID).To(Equal(expectedShowID))
+			Expect(relation.Type).To(Equal(expectedType))
+			Expect(relation.TypeFrom(showID)).To(Equal(relationType))
+			Expect(relation.TypeFrom(relatedShowID)).To(Equal(expectedInverseType))
+		},
+		Entry("should store sequels as they are", "sequel_of", showID, "sequel_of", "prequel_of"),
+		Entry("should store prequels as sequels of the related show", "prequel_of", relatedShowID, "sequel_of", "sequel_of"),
+		Entry("should derive the inverse of spin-offs", "spin_off_of", showID, "spin_off_of", "has_spin_off"),
+		Entry("should derive the inverse of remakes", "remake_of", showID, "remake_of", "remade_as"),
+		Entry("should derive the inverse of side stories", "side_story_of", showID, "side_story_of", "has_side_story"),
+	)
+
+	Context("when creating a relation", func() {
+		var (
+			db       *gorm.DB
+			mockedDB sqlmock.Sqlmock
+		)
+
+		BeforeEach(func() {
+			db, mockedDB = testutils.CreateTestDBInstance()
+		})
+
+		expectShowsAndDuplicates := func(relationType string, duplicates int) {
+			mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."shows" WHERE id IN \(\$1,\$2\)`).
+				WithArgs(showID, relatedShowID).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."show_relations" WHERE show_id = \$1 AND related_show_id = \$2 AND type = \$3`).
+				WithArgs(showID, relatedShowID, relationType).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(duplicates))
+		}
+
+		It("should reject relations of a show to itself", func() {
+			Expect(showmgt.CreateShowRelation(db, showmgt.NewShowRelation(showID, showID, "sequel_of"))).
+				To(MatchError(showmgt.ErrShowRelatedToItself))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should reject relations which already exist", func() {
+			expectShowsAndDuplicates("sequel_of", 1)
+
+			Expect(showmgt.CreateShowRelation(db, showmgt.NewShowRelation(showID, relatedShowID, "sequel_of"))).
+				To(MatchError(showmgt.ErrShowRelationExists))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should reject sequels closing a cycle", func() {
+			expectShowsAndDuplicates("sequel_of", 0)
+			mockedDB.ExpectQuery(`SELECT "related_show_id" FROM "public"."show_relations" WHERE show_id IN \(\$1\) AND type = \$2`).
+				WithArgs(relatedShowID, "sequel_of").
+				WillReturnRows(sqlmock.NewRows([]string{"related_show_id"}).AddRow(otherShowID))
+			mockedDB.ExpectQuery(`SELECT "related_show_id" FROM "public"."show_relations" WHERE show_id IN \(\$1\) AND type = \$2`).
+				WithArgs(otherShowID, "sequel_of").
+				WillReturnRows(sqlmock.NewRows([]string{"related_show_id"}).AddRow(showID))
+
+			Expect(showmgt.CreateShowRelation(db, showmgt.NewShowRelation(showID, relatedShowID, "sequel_of"))).
+				To(MatchError(showmgt.ErrShowRelationCycle))
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should allow side stories of one another", func() {
+			expectShowsAndDuplicates("side_story_of", 0)
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`INSERT INTO "public"."show_relations"`).
+				WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, showID, relatedShowID, "side_story_of").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mockedDB.ExpectCommit()
+
+			Expect(showmgt.CreateShowRelation(db, showmgt.NewShowRelation(showID, relatedShowID, "side_story_of"))).
+				To(Succeed())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,208 @@
+package showmgt_test
+
+import (
+	"context"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gormPostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// searchFixtures are the shows searched by both backends.
+var searchFixtures = []showmgt.ShowModel{
+	{
+		Model:            core.Model{ID: uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000001")},
+		Kind:             "tv",
+		OriginalLanguage: "ja",
+		OriginalTitle:    "ワンピース",
+		Translations: []showmgt.ShowTranslationModel{
+			{Locale: "en", Title: "One Piece"},
+			{Locale: "vi", Title: "Đảo Hải Tặc"},
+		},
+	},
+	{
+		Model:            core.Model{ID: uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000002")},
+		Kind:             "tv",
+		OriginalLanguage: "ja",
+		OriginalTitle:    "進撃の巨人",
+		Translations: []showmgt.ShowTranslationModel{
+			{Locale: "en", Title: "Attack on Titan"},
+			{Locale: "fr", Title: "L'Attaque des Titans"},
+		},
+	},
+	{
+		Model:            core.Model{ID: uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-000000000003")},
+		Kind:             "movie",
+		OriginalLanguage: "ja",
+		OriginalTitle:    "千と千尋の神隠し",
+		Translations: []showmgt.ShowTranslationModel{
+			{Locale: "en", Title: "Spirited Away"},
+		},
+	},
+}
+
+// searchCase is a search and the show and title it must return first.
+type searchCase struct {
+	text   string
+	showID uuid.UUID
+	locale string
+	title  string
+}
+
+// sharedSearchCases must pass with every backend.
+var sharedSearchCases = []searchCase{
+	{text: "One Piece", showID: searchFixtures[0].ID, locale: "en", title: "One Piece"},
+	{text: "ワンピース", showID: searchFixtures[0].ID, locale: "ja", title: "ワンピース"},
+	{text: "Đảo Hải Tặc", showID: searchFixtures[0].ID, locale: "vi", title: "Đảo Hải Tặc"},
+	{text: "進撃の巨人", showID: searchFixtures[1].ID, locale: "ja", title: "進撃の巨人"},
+	{text: "Atack on Titan", showID: searchFixtures[1].ID, locale: "en", title: "Attack on Titan"},
+	{text: "spirited away", showID: searchFixtures[2].ID, locale: "en", title: "Spirited Away"},
+}
+
+// expectSearchCases runs the search cases against the index returned by getIndex.
+func expectSearchCases(getIndex func() showmgt.SearchIndex, cases []searchCase) {
+	for _, c := range cases {
+		It("should find \""+c.text+"\"", func(ctx SpecContext) {
+			hits, err := getIndex().Search(ctx, c.text, 5)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hits).ToNot(BeEmpty())
+			Expect(hits[0].ShowID).To(Equal(c.showID))
+			Expect(hits[0].Locale).To(Equal(c.locale))
+			Expect(hits[0].Title).To(Equal(c.title))
+		})
+	}
+}
+
+// expectShowsLoaded expects the query loading the given shows with their translations.
+func expectShowsLoaded(mockedDB sqlmock.Sqlmock, query string, showModels []showmgt.ShowModel) {
+	showRows := sqlmock.NewRows([]string{"id", "kind", "original_language", "original_title"})
+	translationRows := sqlmock.NewRows([]string{"id", "show_id", "locale", "title"})
+
+	for _, showModel := range showModels {
+		showRows.AddRow(showModel.ID, showModel.Kind, showModel.OriginalLanguage, showModel.OriginalTitle)
+
+		for _, translation := range showModel.Translations {
+			translationRows.AddRow(uuid.New(), showModel.ID, translation.Locale, translation.Title)
+		}
+	}
+
+	mockedDB.ExpectQuery(query).WillReturnRows(showRows)
+
+	if len(showModels) > 0 {
+		mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_translations" WHERE "show_translations"."show_id" (IN|=)`).
+			WillReturnRows(translationRows)
+	}
+}
+
+// newBleveSearchIndex returns an in-memory Bleve index of the search fixtures.
+func newBleveSearchIndex(db *gorm.DB, mockedDB sqlmock.Sqlmock) showmgt.SearchIndex {
+	index, created, err := showmgt.OpenBleveSearchIndex(db, "")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(created).To(BeTrue())
+
+	DeferCleanup(index.Close)
+
+	expectShowsLoaded(mockedDB, `SELECT \* FROM "public"."shows" ORDER BY "shows"."id" LIMIT`, searchFixtures)
+	Expect(index.Rebuild(context.Background())).To(Succeed())
+
+	return index
+}
+
+var _ = Describe("[search.go]", func() {
+	Context("with the Bleve backend", func() {
+		var index showmgt.SearchIndex
+
+		BeforeEach(func() {
+			testutils.DetectLeakyGoroutines()
+			db, mockedDB := testutils.CreateTestDBInstance()
+			index = newBleveSearchIndex(db, mockedDB)
+		})
+
+		expectSearchCases(func() showmgt.SearchIndex { return index }, sharedSearchCases)
+	})
+
+	Context("with the PostgreSQL backend", Ordered, func() {
+		var index showmgt.SearchIndex
+
+		BeforeAll(func(ctx SpecContext) {
+			testutils.SkipIfDockerIsNotRunning(ctx)
+
+			postgresContainer := testutils.CreatePostgresContainer(ctx)
+			db := testutils.CreateDBInstance(func(postgresCfg *gormPostgres.Config, gormCfg *gorm.Config) {
+				postgresCfg.DSN = postgresContainer.MustConnectionString(ctx)
+			})
+
+			Expect(db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error).To(Succeed())
+			Expect(db.AutoMigrate(&showmgt.ShowModel{}, &showmgt.ShowTranslationModel{})).To(Succeed())
+			Expect(db.Create(searchFixtures).Error).To(Succeed())
+
+			index = showmgt.NewPostgresSearchIndex(db)
+		})
+
+		expectSearchCases(func() showmgt.SearchIndex { return index }, sharedSearchCases)
+	})
+
+	Context("when a show changes", func() {
+		It("should only reindex the shows which have changed", func(ctx SpecContext) {
+			testutils.DetectLeakyGoroutines()
+			db, mockedDB := testutils.CreateTestDBInstance()
+			index := newBleveSearchIndex(db, mockedDB)
+
+			listener := showmgt.NewSearchIndexListener(showmgt.SearchIndexListenerParams{Index: index})
+
+			Expect(listener.OnCatalogEvents(ctx, []showmgt.CatalogEvent{
+				{Type: showmgt.ShowReleasedCatalogEvent, ShowID: searchFixtures[0].ID},
+			})).To(Succeed())
+
+			renamedShow := searchFixtures[0]
+			renamedShow.Translations = []showmgt.ShowTranslationModel{{Locale: "en", Title: "Straw Hat Pirates"}}
+			expectShowsLoaded(mockedDB, `SELECT \* FROM "public"."shows" WHERE id IN`,
+				[]showmgt.ShowModel{renamedShow})
+
+			Expect(listener.OnCatalogEvents(ctx, []showmgt.CatalogEvent{
+				{Type: showmgt.ShowChangedCatalogEvent, ShowID: searchFixtures[0].ID},
+			})).To(Succeed())
+
+			hits, err := index.Search(ctx, "One Piece", 5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hits).To(BeEmpty())
+
+			hits, err = index.Search(ctx, "straw hat", 5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hits).To(HaveLen(1))
+			Expect(hits[0].ShowID).To(Equal(searchFixtures[0].ID))
+		})
+	})
+})
+
+var _ = Describe("[search.bleve.go]", func() {
+	var index showmgt.SearchIndex
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB := testutils.CreateTestDBInstance()
+		index = newBleveSearchIndex(db, mockedDB)
+	})
+
+	// pg_trgm compares whole words, and CJK titles have no spaces, so PostgreSQL misses these.
+	expectSearchCases(func() showmgt.SearchIndex { return index }, []searchCase{
+		{text: "巨人", showID: searchFixtures[1].ID, locale: "ja", title: "進撃の巨人"},
+		{text: "神隠し", showID: searchFixtures[2].ID, locale: "ja", title: "千と千尋の神隠し"},
+		{text: "ワンピ", showID: searchFixtures[0].ID, locale: "ja", title: "ワンピース"},
+	})
+
+	It("should return each show once", func(ctx SpecContext) {
+		hits, err := index.Search(ctx, "titan", 5)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hits).To(HaveLen(1))
+		Expect(hits[0].ShowID).To(Equal(searchFixtures[1].ID))
+	})
+})
@@ -0,0 +1,160 @@
+package showmgt_test
+
+import (
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/samber/lo"
+)
+
+var _ = Describe("[similarity.go]", func() {
+	newShow := func(language string, keywords ...string) showmgt.ShowModel {
+		return showmgt.ShowModel{
+			Model:            core.Model{ID: uuid.New()},
+			OriginalLanguage: language,
+			Keywords:         pq.StringArray(keywords),
+		}
+	}
+
+	Context("when scoring two shows", func() {
+		It("should return 1 for shows with the same keywords and language", func() {
+			a := newShow("ja", "anime", "pirate")
+			b := newShow("ja", "Pirate", " anime ")
+
+			Expect(showmgt.ScoreSimilarity(&a, &b)).To(BeNumerically("~", 1))
+		})
+
+		It("should weight the keyword overlap", func() {
+			a := newShow("ja", "anime", "pirate")
+			b := newShow("en", "anime", "ninja")
+
+			Expect(showmgt.ScoreSimilarity(&a, &b)).To(BeNumerically("~", 0.25))
+		})
+
+		It("should return 0 for unrelated shows", func() {
+			a := newShow("ja", "anime")
+			b := newShow("en", "western")
+
+			Expect(showmgt.ScoreSimilarity(&a, &b)).To(BeZero())
+		})
+	})
+
+	Context("when computing similar shows", func() {
+		It("should keep only positive scores ordered by score", func() {
+			onePiece := newShow("ja", "anime", "pirate")
+			naruto := newShow("ja", "anime", "ninja")
+			blackPearl := newShow("en", "pirate")
+			western := newShow("en", "western")
+			computedAt := time.Now()
+
+			similarities := showmgt.ComputeSimilarShows(
+				[]showmgt.ShowModel{onePiece, naruto, blackPearl, western},
+				computedAt,
+			)
+
+			onePieceSimilarities := []showmgt.ShowSimilarityModel{}
+			for _, similarity := range similarities {
+				Expect(similarity.ShowID).NotTo(Equal(similarity.SimilarShowID))
+				Expect(similarity.Score).To(BeNumerically(">", 0))
+
+				if similarity.ShowID == onePiece.ID {
+					onePieceSimilarities = append(onePieceSimilarities, similarity)
+				}
+			}
+
+			Expect(onePieceSimilarities).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"SimilarShowID": Equal(naruto.ID),
+					"ComputedAt":    Equal(computedAt),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"SimilarShowID": Equal(blackPearl.ID),
+				}),
+			))
+		})
+	})
+
+	Context("when computing the similar shows affected by changed shows", func() {
+		var (
+			onePiece   showmgt.ShowModel
+			naruto     showmgt.ShowModel
+			blackPearl showmgt.ShowModel
+			western    showmgt.ShowModel
+			computedAt time.Time
+		)
+
+		BeforeEach(func() {
+			onePiece = newShow("ja", "anime", "pirate")
+			naruto = newShow("ja", "anime", "ninja")
+			blackPearl = newShow("en", "pirate")
+			western = newShow("en", "western")
+			computedAt = time.Now()
+		})
+
+		stored := func(showID uuid.UUID, similarShowIDs ...uuid.UUID) []showmgt.ShowSimilarityModel {
+			return lo.Map(similarShowIDs, func(similarShowID uuid.UUID, _ int) showmgt.ShowSimilarityModel {
+				return showmgt.ShowSimilarityModel{ShowID: showID, SimilarShowID: similarShowID, Score: 0.5}
+			})
+		}
+
+		similarShowIDs := func(similarities []showmgt.ShowSimilarityModel) []uuid.UUID {
+			return lo.Map(similarities, func(similarity showmgt.ShowSimilarityModel, _ int) uuid.UUID {
+				return similarity.SimilarShowID
+			})
+		}
+
+		It("should merge a new show into the stored similar shows of the shows it resembles", func() {
+			bleach := newShow("ja", "anime")
+			var loadedIDs []uuid.UUID
+
+			affected, err := showmgt.ComputeChangedSimilarShows(
+				[]showmgt.ShowModel{onePiece, naruto, blackPearl, western, bleach},
+				[]uuid.UUID{bleach.ID},
+				nil,
+				func(showIDs []uuid.UUID) ([]showmgt.ShowSimilarityModel, error) {
+					loadedIDs = showIDs
+					return append(stored(onePiece.ID, naruto.ID, blackPearl.ID), stored(naruto.ID, onePiece.ID)...), nil
+				},
+				computedAt,
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loadedIDs).To(ConsistOf(onePiece.ID, naruto.ID))
+			Expect(affected).To(HaveLen(3))
+			Expect(similarShowIDs(affected[bleach.ID])).To(ConsistOf(onePiece.ID, naruto.ID))
+			Expect(similarShowIDs(affected[onePiece.ID])).To(HaveExactElements(bleach.ID, naruto.ID, blackPearl.ID))
+			Expect(similarShowIDs(affected[naruto.ID])).To(HaveExactElements(bleach.ID, onePiece.ID))
+			Expect(lo.Flatten(lo.Values(affected))).To(HaveEach(HaveField("ComputedAt", Equal(computedAt))))
+		})
+
+		It("should fully recompute the shows whose stored similar shows include a changed show", func() {
+			onePiece.OriginalLanguage = "en"
+			onePiece.Keywords = pq.StringArray{"western"}
+			var loadedIDs []uuid.UUID
+
+			affected, err := showmgt.ComputeChangedSimilarShows(
+				[]showmgt.ShowModel{onePiece, naruto, blackPearl, western},
+				[]uuid.UUID{onePiece.ID},
+				[]uuid.UUID{naruto.ID, blackPearl.ID},
+				func(showIDs []uuid.UUID) ([]showmgt.ShowSimilarityModel, error) {
+					loadedIDs = showIDs
+					return nil, nil
+				},
+				computedAt,
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loadedIDs).To(Equal([]uuid.UUID{western.ID}))
+			Expect(affected).To(HaveKeyWithValue(naruto.ID, BeEmpty()))
+			Expect(similarShowIDs(affected[blackPearl.ID])).To(ConsistOf(onePiece.ID, western.ID))
+			Expect(similarShowIDs(affected[onePiece.ID])).To(HaveExactElements(western.ID, blackPearl.ID))
+			Expect(similarShowIDs(affected[western.ID])).To(Equal([]uuid.UUID{onePiece.ID}))
+		})
+	})
+})
@@ -0,0 +1,168 @@
+package showmgt_test
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"wano-island/common/core"
+	"wano-island/common/showmgt"
+	mockcore "wano-island/testing/mocks/common/core"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+var _ = Describe("[slugs.go]", func() {
+	DescribeTable("Slugify",
+		func(text string, expectedSlug string) {
+			Expect(showmgt.Slugify(text)).To(Equal(expectedSlug))
+		},
+		Entry("should lowercase and hyphenate words", "One Piece", "one-piece"),
+		Entry("should collapse punctuation and spaces", "  Naruto: Shippūden!! (2007) ", "naruto-shippuden-2007"),
+		Entry("should transliterate Vietnamese letters", "Đảo Hải Tặc", "dao-hai-tac"),
+		Entry("should transliterate full-width characters", "ＡＢＣ１２３", "abc123"),
+		Entry("should romanize katakana", "ワンピース", "wanpisu"),
+		Entry("should romanize hiragana with contracted and doubled sounds", "ちょっと", "chotto"),
+		Entry("should drop characters which cannot be transliterated", "進撃の巨人", "no"),
+		Entry("should return an empty slug when nothing can be transliterated", "進撃", ""),
+	)
+
+	It("should cut long slugs on a word boundary", func() {
+		slug := showmgt.Slugify(strings.Repeat("word ", 30))
+
+		Expect(len(slug)).To(BeNumerically("<=", 80))
+		Expect(slug).To(HaveSuffix("word"))
+	})
+
+	Context("when building the slug of a show", func() {
+		It("should prefer the original title", func() {
+			Expect(showmgt.ShowSlugBase(&showmgt.ShowModel{
+				OriginalTitle: "One Piece",
+				Translations: []showmgt.ShowTranslationModel{
+					{Locale: "vi", Title: "Đảo Hải Tặc"},
+				},
+			})).To(Equal("one-piece"))
+		})
+
+		It("should fall back to the English title, then to other translations", func() {
+			Expect(showmgt.ShowSlugBase(&showmgt.ShowModel{
+				OriginalTitle: "進撃",
+				Translations: []showmgt.ShowTranslationModel{
+					{Locale: "vi", Title: "Đại chiến Titan"},
+					{Locale: "en", Title: "Attack on Titan"},
+				},
+			})).To(Equal("attack-on-titan"))
+		})
+
+		It("should add the year of the first air date", func() {
+			Expect(showmgt.ShowSlugBase(&showmgt.ShowModel{
+				OriginalTitle: "One Piece",
+				FirstAirDate:  lo.ToPtr(time.Date(1999, time.October, 20, 0, 0, 0, 0, time.UTC)),
+			})).To(Equal("one-piece-1999"))
+		})
+
+		It("should not repeat the year when the title already ends with it", func() {
+			Expect(showmgt.ShowSlugBase(&showmgt.ShowModel{
+				OriginalTitle: "Dune 2021",
+				FirstAirDate:  lo.ToPtr(time.Date(2021, time.September, 3, 0, 0, 0, 0, time.UTC)),
+			})).To(Equal("dune-2021"))
+		})
+
+		It("should fall back to the show ID when no title can be transliterated", func() {
+			Expect(showmgt.ShowSlugBase(&showmgt.ShowModel{
+				Model:         core.Model{ID: uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")},
+				OriginalTitle: "進撃",
+			})).To(Equal("show-0192f5a4"))
+		})
+	})
+
+	Context("when assigning the slugs of a show", func() {
+		showID := uuid.MustParse("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e")
+
+		It("should skip the slugs used by other shows in any locale and the reserved path segments", func() {
+			db, mockedDB := testutils.CreateTestDBInstance()
+			show := &showmgt.ShowModel{
+				Model:         core.Model{ID: showID},
+				OriginalTitle: "Trending",
+			}
+
+			mockedDB.ExpectQuery(`SELECT "slug" FROM "public"."show_slugs" WHERE \(slug = \$1 OR slug LIKE \$2\) AND show_id <> \$3`).
+				WithArgs("trending", "trending-%", showID).
+				WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("trending-2"))
+			mockedDB.ExpectQuery(`SELECT \* FROM "public"."show_slugs"`).
+				WithArgs(showID, "trending-3", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`INSERT INTO "public"."show_slugs"`).
+				WithArgs(testutils.AnyUUIDArg{}, testutils.AnyTimeArg{}, showID, "", "trending-3").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mockedDB.ExpectCommit()
+			mockedDB.ExpectBegin()
+			mockedDB.ExpectExec(`UPDATE "public"."shows" SET "slug"=\$1 WHERE "id" = \$2`).
+				WithArgs("trending-3", showID).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mockedDB.ExpectCommit()
+
+			Expect(showmgt.AssignShowSlugs(db, show)).To(Succeed())
+			Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+			Expect(show.Slug).To(HaveValue(Equal("trending-3")))
+		})
+	})
+
+	Context("when reserving the path segments of the show routes", func() {
+		It("should reserve every static segment registered under /api/v1/shows/", func() {
+			db, _ := testutils.CreateTestDBInstance()
+			config := mockcore.NewMockAppConfig(GinkgoT())
+			config.EXPECT().GetSearchConfig().Return(&core.SearchConfig{}).Maybe()
+			universalTranslator := core.NewUniversalTranslator()
+
+			var routes []core.HTTPRoute
+
+			app := fxtest.New(GinkgoT(),
+				showmgt.NewShowMgtModule(),
+				fx.Supply(db, core.NewNoopLogger(), universalTranslator, core.NewValidator(universalTranslator)),
+				fx.Provide(func() core.AppConfig { return config }),
+				fx.Invoke(fx.Annotate(func(registered []core.HTTPRoute) {
+					routes = registered
+				}, fx.ParamTags(`group:"http_routes"`))),
+			)
+			app.RequireStart().RequireStop()
+
+			segments := showmgt.ShowRouteSegments(routes)
+
+			Expect(segments).To(ContainElements("batch", "suggest", "trending"))
+			for _, segment := range segments {
+				Expect(showmgt.IsReservedShowSlug(segment)).To(BeTrue(), "%q must be a reserved show slug", segment)
+			}
+		})
+
+		It("should only keep the static segments which directly follow /api/v1/shows/", func() {
+			routes := []core.HTTPRoute{
+				fakeRoute("GET /api/v1/shows"),
+				fakeRoute("GET /api/v1/shows/{id}/similar"),
+				fakeRoute("POST /api/v1/shows/batch/{id}/cancel"),
+				fakeRoute("GET /api/v1/shows/batch/{id}"),
+				fakeRoute("GET /api/v1/feeds/shows.atom"),
+				fakeRoute("/api/v1/shows/upcoming"),
+			}
+
+			Expect(showmgt.ShowRouteSegments(routes)).To(Equal([]string{"batch", "upcoming"}))
+			Expect(showmgt.IsReservedShowSlug("feed")).To(BeFalse())
+		})
+	})
+})
+
+// fakeRoute is a route which only has a pattern.
+type fakeRoute string
+
+func (r fakeRoute) Pattern() string { return string(r) }
+
+func (r fakeRoute) IsPrivateRoute() bool { return true }
+
+func (r fakeRoute) ServeHTTP(http.ResponseWriter, *http.Request) {}
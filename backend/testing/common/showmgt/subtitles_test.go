@@ -0,0 +1,81 @@
+package showmgt_test
+
+import (
+	"bytes"
+	"time"
+	"wano-island/common/showmgt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[subtitles.go]", func() {
+	srtFile := "\ufeff1\r\n00:00:01,000 --> 00:00:03,500\r\nI'm going to be\r\nKing of the Pirates!\r\n\r\n" +
+		"2\r\n00:00:04,000 --> 00:00:05,000\r\n<i>Gomu Gomu no...</i>\r\n"
+
+	vttFile := "WEBVTT - One Piece\n\nNOTE translated by the community\n\n" +
+		"intro\n00:01.000 --> 00:03.500 align:start\nI'm going to be\nKing of the Pirates!\n\n" +
+		"00:00:04.000 --> 00:00:05.000\n<i>Gomu Gomu no...</i>\n"
+
+	cues := []showmgt.SubtitleCue{
+		{Start: time.Second, End: 3500 * time.Millisecond, Text: "I'm going to be\nKing of the Pirates!"},
+		{Start: 4 * time.Second, End: 5 * time.Second, Text: "<i>Gomu Gomu no...</i>"},
+	}
+
+	It("should detect the format of the files", func() {
+		Expect(showmgt.DetectSubtitleFormat([]byte(srtFile))).To(Equal(showmgt.SRTSubtitleFormat))
+		Expect(showmgt.DetectSubtitleFormat([]byte(vttFile))).To(Equal(showmgt.WebVTTSubtitleFormat))
+	})
+
+	It("should parse SRT and WebVTT files into the same cues", func() {
+		Expect(showmgt.ParseSubtitles([]byte(srtFile), showmgt.SRTSubtitleFormat)).To(Equal(cues))
+		Expect(showmgt.ParseSubtitles([]byte(vttFile), showmgt.WebVTTSubtitleFormat)).To(Equal(cues))
+	})
+
+	It("should report the line of every invalid cue", func() {
+		_, err := showmgt.ParseSubtitles([]byte(
+			"1\n00:00:01,000 --> 00:00:03,000\nHello\n\n"+
+				"2\n00:00:02,000 --> 00:00:04,000\nOverlapping\n\n"+
+				"3\n00:00:06,000 --> 00:00:05,000\nBackwards\n\n"+
+				"4\n00:00:07.000 --> 00:00:08,000\nWrong separator\n\n"+
+				"5\nNo timing\n"), showmgt.SRTSubtitleFormat)
+
+		Expect(err).To(Equal(showmgt.SubtitleProblems{
+			{Line: 6, Message: "The cue overlaps the previous cue"},
+			{Line: 10, Message: "The cue ends before it starts"},
+			{Line: 14, Message: `The start timestamp "00:00:07.000" is invalid`},
+			{Line: 17, Message: "The cue has no timing line"},
+		}))
+	})
+
+	It("should reject WebVTT files without header and files without cues", func() {
+		_, err := showmgt.ParseSubtitles([]byte("00:01.000 --> 00:02.000\nHello\n"), showmgt.WebVTTSubtitleFormat)
+		Expect(err).To(Equal(showmgt.SubtitleProblems{{Line: 1, Message: `The WebVTT file must start with "WEBVTT"`}}))
+
+		_, err = showmgt.ParseSubtitles([]byte("\n\n"), showmgt.SRTSubtitleFormat)
+		Expect(err).To(Equal(showmgt.SubtitleProblems{{Line: 1, Message: "The file has no cues"}}))
+	})
+
+	It("should convert the cues to both formats", func() {
+		var srt bytes.Buffer
+		Expect(showmgt.WriteSubtitles(&srt, cues, showmgt.SRTSubtitleFormat)).To(Succeed())
+		Expect(srt.String()).To(Equal("1\n00:00:01,000 --> 00:00:03,500\nI'm going to be\nKing of the Pirates!\n\n" +
+			"2\n00:00:04,000 --> 00:00:05,000\n<i>Gomu Gomu no...</i>\n\n"))
+
+		Expect(showmgt.NormalizeSubtitles(cues)).To(Equal("WEBVTT\n\n" +
+			"00:00:01.000 --> 00:00:03.500\nI'm going to be\nKing of the Pirates!\n\n" +
+			"00:00:04.000 --> 00:00:05.000\n<i>Gomu Gomu no...</i>\n\n"))
+	})
+
+	It("should shift the cues and drop those ending before the beginning", func() {
+		offset, err := showmgt.ParseSubtitleOffset("-4250")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(showmgt.ShiftSubtitles(cues, offset)).To(Equal([]showmgt.SubtitleCue{
+			{Start: 0, End: 750 * time.Millisecond, Text: "<i>Gomu Gomu no...</i>"},
+		}))
+
+		_, err = showmgt.ParseSubtitleOffset("1.5s")
+		Expect(err).To(MatchError(showmgt.ErrInvalidSubtitleOffset))
+	})
+})
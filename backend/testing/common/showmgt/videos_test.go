@@ -0,0 +1,59 @@
+package showmgt_test
+
+import (
+	"time"
+	"wano-island/common/showmgt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+)
+
+var _ = Describe("[videos.go]", func() {
+	allowedHosts := []string{"videos.example.com"}
+
+	DescribeTable("ValidateVideoKey",
+		func(site string, key string, expectedErr error) {
+			err := showmgt.ValidateVideoKey(site, key, allowedHosts)
+
+			if expectedErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(expectedErr))
+			}
+		},
+		Entry("should accept a YouTube video ID", "youtube", "dQw4w9WgXcQ", nil),
+		Entry("should reject a YouTube URL", "youtube", "https://youtu.be/dQw4w9WgXcQ", showmgt.ErrInvalidVideoKey),
+		Entry("should accept a Vimeo video ID", "vimeo", "76979871", nil),
+		Entry("should reject a non numeric Vimeo video ID", "vimeo", "abc", showmgt.ErrInvalidVideoKey),
+		Entry("should accept a URL of an allowed host", "url", "https://VIDEOS.example.com/op.mp4", nil),
+		Entry("should reject a URL of another host", "url", "https://evil.example.com/op.mp4", showmgt.ErrVideoHostNotAllowed),
+		Entry("should reject a URL without an HTTP scheme", "url", "ftp://videos.example.com/op.mp4", showmgt.ErrInvalidVideoKey),
+		Entry("should reject an unknown site", "dailymotion", "x8abc", showmgt.ErrInvalidVideoKey),
+	)
+
+	It("should sort videos by language preference, then official videos first, then by creation date", func() {
+		createdAt := time.Date(2024, time.October, 1, 10, 0, 0, 0, time.UTC)
+		newVideo := func(name string, language string, isOfficial bool, age time.Duration) showmgt.VideoModel {
+			video := showmgt.VideoModel{Name: name, Language: language, IsOfficial: isOfficial}
+			video.CreatedAt = createdAt.Add(-age)
+
+			return video
+		}
+
+		videos := []showmgt.VideoModel{
+			newVideo("ja", "ja", true, 0),
+			newVideo("en-unofficial", "en", false, time.Hour),
+			newVideo("fr-ca", "fr-CA", true, 0),
+			newVideo("fr-recent", "fr", true, 0),
+			newVideo("fr-old", "fr", true, time.Hour),
+			newVideo("en", "en", true, 0),
+		}
+
+		showmgt.SortVideos(videos, []string{"fr-CA", "en"})
+
+		Expect(lo.Map(videos, func(video showmgt.VideoModel, _ int) string {
+			return video.Name
+		})).To(Equal([]string{"fr-ca", "fr-old", "fr-recent", "en", "en-unofficial", "ja"}))
+	})
+})
@@ -0,0 +1,86 @@
+package usermgt_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"wano-island/common/core"
+	"wano-island/common/usermgt"
+	"wano-island/testing/testutils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("[oauth2.repository.go]", func() {
+	var (
+		db         *gorm.DB
+		mockedDB   sqlmock.Sqlmock
+		repository usermgt.OAuth2ProviderRepository
+	)
+
+	BeforeEach(func() {
+		testutils.DetectLeakyGoroutines()
+		db, mockedDB = testutils.CreateTestDBInstance()
+
+		repository = usermgt.NewOAuth2ProviderRepository(usermgt.OAuth2ProviderRepositoryParams{
+			DB:              db,
+			AESGCMEncryptor: core.NewNoopEncryptor(),
+		})
+	})
+
+	expectProviders := func() {
+		mockedDB.ExpectQuery(`SELECT "oauth2_providers"."id","oauth2_providers"."created_at",.* FROM "public"."oauth2_providers" LIMIT \$1`).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "is_enabled"}).
+				AddRow("0192f5a4-7a5e-7c6a-9d1e-1f2a3b4c5d6e", "google", true))
+	}
+
+	It("should return the providers with their estimated count", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass\(\$1\)`).
+			WithArgs("public.oauth2_providers").
+			WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(-1))
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM \(SELECT 1 FROM "public"."oauth2_providers" LIMIT \$1\) AS capped_rows`).
+			WithArgs(core.MaxExactCount + 1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		expectProviders()
+		mockedDB.ExpectCommit()
+
+		providers, count, err := repository.GetMany(httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(providers).To(HaveLen(1))
+		Expect(providers[0].Provider).To(Equal("google"))
+		Expect(count).To(Equal(&core.RowCount{Total: 1, IsExact: true}))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should not count the providers when the count is skipped", func() {
+		mockedDB.ExpectBegin()
+		expectProviders()
+		mockedDB.ExpectCommit()
+
+		providers, count, err := repository.GetMany(httptest.NewRequest(http.MethodGet, "/?count=none", nil))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(providers).To(HaveLen(1))
+		Expect(count).To(Equal(&core.RowCount{}))
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should return the error of the count", func() {
+		mockedDB.ExpectBegin()
+		mockedDB.ExpectQuery(`SELECT count\(\*\) FROM "public"."oauth2_providers"`).
+			WillReturnError(gorm.ErrInvalidDB)
+		mockedDB.ExpectRollback()
+
+		providers, count, err := repository.GetMany(httptest.NewRequest(http.MethodGet, "/?count=exact", nil))
+
+		Expect(err).To(MatchError(gorm.ErrInvalidDB))
+		Expect(providers).To(BeNil())
+		Expect(count).To(BeNil())
+		Expect(mockedDB.ExpectationsWereMet()).To(Succeed())
+	})
+})
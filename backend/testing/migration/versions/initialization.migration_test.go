@@ -59,6 +59,7 @@ var _ = Describe("[migration.versions.initialization]", func() {
 			sqlMock.ExpectExec("CREATE SCHEMA IF NOT EXISTS internal").WillReturnResult(sqlmock.NewResult(1, 1))
 			sqlMock.ExpectExec("CREATE SCHEMA IF NOT EXISTS public").WillReturnResult(sqlmock.NewResult(1, 1))
 			sqlMock.ExpectExec("CREATE EXTENSION IF NOT EXISTS ltree").WillReturnResult(sqlmock.NewResult(1, 1))
+			sqlMock.ExpectExec("CREATE EXTENSION IF NOT EXISTS pg_trgm").WillReturnResult(sqlmock.NewResult(1, 1))
 			sqlMock.ExpectExec(`CREATE TABLE "internal"."db_migrations"`).WillReturnError(gorm.ErrPrimaryKeyRequired)
 			sqlMock.ExpectRollback()
 
@@ -66,6 +66,53 @@ func (_c *MockAppConfig_GetAppVersion_Call) RunAndReturn(run func() string) *Moc
 	return _c
 }
 
+// GetCommentConfig provides a mock function with given fields:
+func (_m *MockAppConfig) GetCommentConfig() *core.CommentConfig {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentConfig")
+	}
+
+	var r0 *core.CommentConfig
+	if rf, ok := ret.Get(0).(func() *core.CommentConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.CommentConfig)
+		}
+	}
+
+	return r0
+}
+
+// MockAppConfig_GetCommentConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentConfig'
+type MockAppConfig_GetCommentConfig_Call struct {
+	*mock.Call
+}
+
+// GetCommentConfig is a helper method to define mock.On call
+func (_e *MockAppConfig_Expecter) GetCommentConfig() *MockAppConfig_GetCommentConfig_Call {
+	return &MockAppConfig_GetCommentConfig_Call{Call: _e.mock.On("GetCommentConfig")}
+}
+
+func (_c *MockAppConfig_GetCommentConfig_Call) Run(run func()) *MockAppConfig_GetCommentConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAppConfig_GetCommentConfig_Call) Return(_a0 *core.CommentConfig) *MockAppConfig_GetCommentConfig_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAppConfig_GetCommentConfig_Call) RunAndReturn(run func() *core.CommentConfig) *MockAppConfig_GetCommentConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCompatibleVersion provides a mock function with given fields:
 func (_m *MockAppConfig) GetCompatibleVersion() string {
 	ret := _m.Called()
@@ -342,6 +389,53 @@ func (_c *MockAppConfig_GetRevision_Call) RunAndReturn(run func() string) *MockA
 	return _c
 }
 
+// GetSearchConfig provides a mock function with given fields:
+func (_m *MockAppConfig) GetSearchConfig() *core.SearchConfig {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchConfig")
+	}
+
+	var r0 *core.SearchConfig
+	if rf, ok := ret.Get(0).(func() *core.SearchConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SearchConfig)
+		}
+	}
+
+	return r0
+}
+
+// MockAppConfig_GetSearchConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSearchConfig'
+type MockAppConfig_GetSearchConfig_Call struct {
+	*mock.Call
+}
+
+// GetSearchConfig is a helper method to define mock.On call
+func (_e *MockAppConfig_Expecter) GetSearchConfig() *MockAppConfig_GetSearchConfig_Call {
+	return &MockAppConfig_GetSearchConfig_Call{Call: _e.mock.On("GetSearchConfig")}
+}
+
+func (_c *MockAppConfig_GetSearchConfig_Call) Run(run func()) *MockAppConfig_GetSearchConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAppConfig_GetSearchConfig_Call) Return(_a0 *core.SearchConfig) *MockAppConfig_GetSearchConfig_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAppConfig_GetSearchConfig_Call) RunAndReturn(run func() *core.SearchConfig) *MockAppConfig_GetSearchConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSecretKey provides a mock function with given fields:
 func (_m *MockAppConfig) GetSecretKey() []byte {
 	ret := _m.Called()
@@ -389,6 +483,53 @@ func (_c *MockAppConfig_GetSecretKey_Call) RunAndReturn(run func() []byte) *Mock
 	return _c
 }
 
+// GetVideoConfig provides a mock function with given fields:
+func (_m *MockAppConfig) GetVideoConfig() *core.VideoConfig {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVideoConfig")
+	}
+
+	var r0 *core.VideoConfig
+	if rf, ok := ret.Get(0).(func() *core.VideoConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.VideoConfig)
+		}
+	}
+
+	return r0
+}
+
+// MockAppConfig_GetVideoConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVideoConfig'
+type MockAppConfig_GetVideoConfig_Call struct {
+	*mock.Call
+}
+
+// GetVideoConfig is a helper method to define mock.On call
+func (_e *MockAppConfig_Expecter) GetVideoConfig() *MockAppConfig_GetVideoConfig_Call {
+	return &MockAppConfig_GetVideoConfig_Call{Call: _e.mock.On("GetVideoConfig")}
+}
+
+func (_c *MockAppConfig_GetVideoConfig_Call) Run(run func()) *MockAppConfig_GetVideoConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAppConfig_GetVideoConfig_Call) Return(_a0 *core.VideoConfig) *MockAppConfig_GetVideoConfig_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAppConfig_GetVideoConfig_Call) RunAndReturn(run func() *core.VideoConfig) *MockAppConfig_GetVideoConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsDevelopment provides a mock function with given fields:
 func (_m *MockAppConfig) IsDevelopment() bool {
 	ret := _m.Called()
@@ -8,6 +8,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	core "wano-island/common/core"
+
 	usermgt "wano-island/common/usermgt"
 )
 
@@ -143,7 +145,7 @@ func (_c *MockOAuth2ProviderRepository_Get_Call) RunAndReturn(run func(context.C
 }
 
 // GetMany provides a mock function with given fields: r
-func (_m *MockOAuth2ProviderRepository) GetMany(r *http.Request) ([]usermgt.OAuth2ProviderModel, *int64, error) {
+func (_m *MockOAuth2ProviderRepository) GetMany(r *http.Request) ([]usermgt.OAuth2ProviderModel, *core.RowCount, error) {
 	ret := _m.Called(r)
 
 	if len(ret) == 0 {
@@ -151,9 +153,9 @@ func (_m *MockOAuth2ProviderRepository) GetMany(r *http.Request) ([]usermgt.OAut
 	}
 
 	var r0 []usermgt.OAuth2ProviderModel
-	var r1 *int64
+	var r1 *core.RowCount
 	var r2 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]usermgt.OAuth2ProviderModel, *int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]usermgt.OAuth2ProviderModel, *core.RowCount, error)); ok {
 		return rf(r)
 	}
 	if rf, ok := ret.Get(0).(func(*http.Request) []usermgt.OAuth2ProviderModel); ok {
@@ -164,11 +166,11 @@ func (_m *MockOAuth2ProviderRepository) GetMany(r *http.Request) ([]usermgt.OAut
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) *int64); ok {
+	if rf, ok := ret.Get(1).(func(*http.Request) *core.RowCount); ok {
 		r1 = rf(r)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*int64)
+			r1 = ret.Get(1).(*core.RowCount)
 		}
 	}
 
@@ -199,12 +201,12 @@ func (_c *MockOAuth2ProviderRepository_GetMany_Call) Run(run func(r *http.Reques
 	return _c
 }
 
-func (_c *MockOAuth2ProviderRepository_GetMany_Call) Return(_a0 []usermgt.OAuth2ProviderModel, _a1 *int64, _a2 error) *MockOAuth2ProviderRepository_GetMany_Call {
+func (_c *MockOAuth2ProviderRepository_GetMany_Call) Return(_a0 []usermgt.OAuth2ProviderModel, _a1 *core.RowCount, _a2 error) *MockOAuth2ProviderRepository_GetMany_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockOAuth2ProviderRepository_GetMany_Call) RunAndReturn(run func(*http.Request) ([]usermgt.OAuth2ProviderModel, *int64, error)) *MockOAuth2ProviderRepository_GetMany_Call {
+func (_c *MockOAuth2ProviderRepository_GetMany_Call) RunAndReturn(run func(*http.Request) ([]usermgt.OAuth2ProviderModel, *core.RowCount, error)) *MockOAuth2ProviderRepository_GetMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,6 +2,7 @@ package testutils
 
 import (
 	"context"
+	"fmt"
 	"time"
 	"wano-island/common/core"
 
@@ -90,3 +91,28 @@ func CreatePostgresContainer(ctx context.Context) *postgres.PostgresContainer {
 
 	return postgresContainer
 }
+
+// SkipIfDockerIsNotRunning skips the current spec when Docker is not available, so that the specs
+// needing a PostgreSQL container do not fail on machines without Docker.
+func SkipIfDockerIsNotRunning(ctx context.Context) {
+	if err := checkDocker(ctx); err != nil {
+		Skip(fmt.Sprintf("Docker is not running: %v", err))
+	}
+}
+
+// checkDocker returns an error when the Docker daemon cannot be reached. Testcontainers panics
+// when it cannot find the Docker host, so the panic is turned into an error.
+func checkDocker(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	if err != nil {
+		return err
+	}
+
+	return provider.Health(ctx)
+}